@@ -0,0 +1,282 @@
+package smhi
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	airQualityURL = "https://datavardluft.smhi.se/52North/api/v1"
+)
+
+// Pollutant is an air pollutant.
+type Pollutant string
+
+// Pollutant constants.
+const (
+	NO2  Pollutant = "NO2"
+	O3   Pollutant = "O3"
+	PM10 Pollutant = "PM10"
+	PM25 Pollutant = "PM2.5"
+	SO2  Pollutant = "SO2"
+)
+
+// pollutantNames holds the names of the phenomena of the pollutants, in
+// the order that they are matched.
+var pollutantNames = []struct {
+	pollutant Pollutant
+	names     []string
+}{
+	{PM25, []string{"pm2.5", "pm 2.5", "pm25"}},
+	{PM10, []string{"pm10", "pm 10"}},
+	{NO2, []string{"no2", "nitrogen dioxide", "kvävedioxid"}},
+	{O3, []string{"o3", "ozone", "ozon"}},
+	{SO2, []string{"so2", "sulphur dioxide", "sulfur dioxide", "svaveldioxid"}},
+}
+
+// AirQualityLevel is a level of the European Air Quality Index.
+type AirQualityLevel int
+
+// AirQualityLevel constants.
+const (
+	GoodAirQuality AirQualityLevel = iota
+	FairAirQuality
+	ModerateAirQuality
+	PoorAirQuality
+	VeryPoorAirQuality
+	ExtremelyPoorAirQuality
+)
+
+// airQualityBands holds the upper limits in µg/m³ of the levels of the
+// European Air Quality Index below ExtremelyPoorAirQuality.
+var airQualityBands = map[Pollutant][]float64{
+	NO2:  {40, 90, 120, 230, 340},
+	O3:   {50, 100, 130, 240, 380},
+	PM10: {20, 40, 50, 100, 150},
+	PM25: {10, 20, 25, 50, 75},
+	SO2:  {100, 200, 350, 500, 750},
+}
+
+// GetAirQualityLevel returns the level of the European Air Quality Index
+// of the concentration of the pollutant in µg/m³.
+func GetAirQualityLevel(p Pollutant, v float64) AirQualityLevel {
+	bands := airQualityBands[p]
+	for i, max := range bands {
+		if v <= max {
+			return AirQualityLevel(i)
+		}
+	}
+	return ExtremelyPoorAirQuality
+}
+
+// AirQualitySeries is a series of measurements of a pollutant at an air
+// quality station.
+type AirQualitySeries struct {
+	ID          string
+	StationID   string
+	StationName string
+	Lon         float64
+	Lat         float64
+	Pollutant   Pollutant
+	Unit        string
+}
+
+// AirQualityValue is a measured concentration of a pollutant.
+type AirQualityValue struct {
+	Timestamp   time.Time
+	Value       float64
+	Level       AirQualityLevel
+	Description map[string]string
+}
+
+// AirQualitySeriesAPI defines the data structure of the series that is
+// returned by the air quality API.
+type AirQualitySeriesAPI []struct {
+	ID      string
+	Uom     string
+	Station struct {
+		Properties struct {
+			ID    string
+			Label string
+		}
+		Geometry struct {
+			Coordinates []float64
+		}
+	}
+	Parameters struct {
+		Phenomenon struct {
+			Label string
+		}
+	}
+}
+
+// AirQualityDataAPI defines the data structure of the measurements that is
+// returned by the air quality API.
+type AirQualityDataAPI struct {
+	Values []struct {
+		Timestamp int64
+		Value     *float64
+	}
+}
+
+// GetAirQualitySeries fetches the series of the pollutants that are
+// measured at the air quality stations using the default client.
+func GetAirQualitySeries() ([]AirQualitySeries, error) {
+	return defaultClient.GetAirQualitySeries()
+}
+
+// GetAirQualitySeries fetches the series of the pollutants that are
+// measured at the air quality stations of the national air quality data
+// host at SMHI, the series of other phenomena are left out.
+func (c *Client) GetAirQualitySeries() ([]AirQualitySeries, error) {
+	var err error
+
+	var decodedData AirQualitySeriesAPI
+	if err = c.getJSON(airQualityURL+"/timeseries?expanded=true", &decodedData); err != nil {
+		return nil, err
+	}
+
+	var ret []AirQualitySeries
+	for _, s := range decodedData {
+		p, ok := toPollutant(s.Parameters.Phenomenon.Label)
+		if !ok || len(s.Station.Geometry.Coordinates) < 2 {
+			continue
+		}
+
+		ret = append(ret, AirQualitySeries{
+			ID:          s.ID,
+			StationID:   s.Station.Properties.ID,
+			StationName: s.Station.Properties.Label,
+			Lon:         s.Station.Geometry.Coordinates[0],
+			Lat:         s.Station.Geometry.Coordinates[1],
+			Pollutant:   p,
+			Unit:        s.Uom,
+		})
+	}
+
+	return ret, nil
+}
+
+// toPollutant returns the pollutant of the name of a phenomenon.
+func toPollutant(name string) (Pollutant, bool) {
+	name = strings.ToLower(name)
+	for _, pn := range pollutantNames {
+		for _, n := range pn.names {
+			if strings.Contains(name, n) {
+				return pn.pollutant, true
+			}
+		}
+	}
+	return "", false
+}
+
+// NearestAirQualitySeries returns the series of the pollutant that is
+// measured nearest to the given longitude and latitude, along with its
+// distance in km, using the default client.
+func NearestAirQualitySeries(p Pollutant, lon, lat float64) (*AirQualitySeries, float64, error) {
+	return defaultClient.NearestAirQualitySeries(p, lon, lat)
+}
+
+// NearestAirQualitySeries returns the series of the pollutant that is
+// measured nearest to the given longitude and latitude, along with its
+// distance in km.
+func (c *Client) NearestAirQualitySeries(p Pollutant, lon, lat float64) (*AirQualitySeries, float64, error) {
+	var err error
+
+	var series []AirQualitySeries
+	if series, err = c.GetAirQualitySeries(); err != nil {
+		return nil, 0, err
+	}
+
+	var ret *AirQualitySeries
+	var min float64
+	for i := range series {
+		s := &series[i]
+		if s.Pollutant != p {
+			continue
+		}
+		if dist := distance(lon, lat, s.Lon, s.Lat); ret == nil || dist < min {
+			ret, min = s, dist
+		}
+	}
+	if ret == nil {
+		return nil, 0, ErrNoStation
+	}
+
+	return ret, min, nil
+}
+
+// GetAirQuality fetches the measurements of the series between from and
+// to using the default client.
+func GetAirQuality(s *AirQualitySeries, from, to time.Time) ([]AirQualityValue, error) {
+	return defaultClient.GetAirQuality(s, from, to)
+}
+
+// GetAirQuality fetches the measurements of the series between from and
+// to, the missing measurements are left out.
+func (c *Client) GetAirQuality(s *AirQualitySeries, from, to time.Time) ([]AirQualityValue, error) {
+	var err error
+
+	timespan := from.UTC().Format(time.RFC3339) + "/" + to.UTC().Format(time.RFC3339)
+
+	var decodedData AirQualityDataAPI
+	if err = c.getJSON(fmt.Sprintf("%s/timeseries/%s/getData?timespan=%s", airQualityURL, url.PathEscape(s.ID), url.QueryEscape(timespan)), &decodedData); err != nil {
+		return nil, err
+	}
+
+	var ret []AirQualityValue
+	for _, v := range decodedData.Values {
+		if v.Value == nil {
+			continue
+		}
+
+		av := AirQualityValue{
+			Timestamp: time.Unix(0, v.Timestamp*int64(time.Millisecond)).UTC(),
+			Value:     *v.Value,
+			Level:     GetAirQualityLevel(s.Pollutant, *v.Value),
+		}
+		if c.descriptions {
+			av.Description = getAirQualityLevelDescription(av.Level)
+		}
+		ret = append(ret, av)
+	}
+
+	return ret, nil
+}
+
+// getAirQualityLevelDescription returns a friendly air quality level
+// description.
+func getAirQualityLevelDescription(l AirQualityLevel) map[string]string {
+	ret := make(map[string]string)
+
+	switch l {
+	case GoodAirQuality:
+		ret["sv-SE"] = "Bra luftkvalitet"
+		ret["en-US"] = "Good air quality"
+		break
+	case FairAirQuality:
+		ret["sv-SE"] = "Godtagbar luftkvalitet"
+		ret["en-US"] = "Fair air quality"
+		break
+	case ModerateAirQuality:
+		ret["sv-SE"] = "Måttlig luftkvalitet"
+		ret["en-US"] = "Moderate air quality"
+		break
+	case PoorAirQuality:
+		ret["sv-SE"] = "Dålig luftkvalitet"
+		ret["en-US"] = "Poor air quality"
+		break
+	case VeryPoorAirQuality:
+		ret["sv-SE"] = "Mycket dålig luftkvalitet"
+		ret["en-US"] = "Very poor air quality"
+		break
+	case ExtremelyPoorAirQuality:
+		ret["sv-SE"] = "Extremt dålig luftkvalitet"
+		ret["en-US"] = "Extremely poor air quality"
+		break
+	}
+
+	return ret
+}
@@ -0,0 +1,100 @@
+package smhi
+
+import (
+	"fmt"
+	"time"
+)
+
+// DefaultAnomalySigma is the number of standard deviations from the normal
+// that is considered an anomaly unless another number is given.
+const DefaultAnomalySigma = 2.0
+
+// AnomalyKind is the kind of an anomaly.
+type AnomalyKind int
+
+// AnomalyKind constants.
+const (
+	HeatAnomaly AnomalyKind = iota
+	ColdAnomaly
+	PrecipitationAnomaly
+)
+
+// Anomaly is raised for a forecast day that deviates significantly from
+// the normal. Sigma is the deviation in standard deviations.
+type Anomaly struct {
+	Kind        AnomalyKind
+	Day         time.Time
+	Value       float64
+	Normal      float64
+	Sigma       float64
+	Description map[string]string
+}
+
+// Anomalies returns the days of the forecast in the given location where
+// the mean temperature or the precipitation deviates from the normal by at
+// least sigma standard deviations, DefaultAnomalySigma is used if sigma is
+// zero. Only precipitation above normal is considered an anomaly.
+func (pf *PointForecast) Anomalies(n *Normals, loc *time.Location, sigma float64) []Anomaly {
+	if sigma <= 0 {
+		sigma = DefaultAnomalySigma
+	}
+
+	var ret []Anomaly
+	for _, s := range pf.Days(loc) {
+		m := n.Month(s.From.Month())
+
+		if m.TemperatureStdDev > 0 {
+			z := (s.MeanAirTemperature - m.Temperature) / m.TemperatureStdDev
+			if z >= sigma {
+				ret = append(ret, newAnomaly(HeatAnomaly, s.From, s.MeanAirTemperature, m.Temperature, z))
+			} else if z <= -sigma {
+				ret = append(ret, newAnomaly(ColdAnomaly, s.From, s.MeanAirTemperature, m.Temperature, z))
+			}
+		}
+
+		if m.PrecipitationStdDev > 0 {
+			z := (s.Precipitation - m.Precipitation) / m.PrecipitationStdDev
+			if z >= sigma {
+				ret = append(ret, newAnomaly(PrecipitationAnomaly, s.From, s.Precipitation, m.Precipitation, z))
+			}
+		}
+	}
+
+	return ret
+}
+
+// newAnomaly returns an anomaly with its description.
+func newAnomaly(kind AnomalyKind, day time.Time, value, normal, sigma float64) Anomaly {
+	a := Anomaly{
+		Kind:   kind,
+		Day:    day,
+		Value:  value,
+		Normal: normal,
+		Sigma:  sigma,
+	}
+	a.Description = getAnomalyDescription(&a)
+
+	return a
+}
+
+// getAnomalyDescription returns a friendly description of the anomaly.
+func getAnomalyDescription(a *Anomaly) map[string]string {
+	ret := make(map[string]string)
+
+	switch a.Kind {
+	case HeatAnomaly:
+		ret["sv-SE"] = fmt.Sprintf("Ovanligt varmt, %.1f °C mot normala %.1f °C", a.Value, a.Normal)
+		ret["en-US"] = fmt.Sprintf("Unusually warm, %.1f °C compared to the normal %.1f °C", a.Value, a.Normal)
+		break
+	case ColdAnomaly:
+		ret["sv-SE"] = fmt.Sprintf("Ovanligt kallt, %.1f °C mot normala %.1f °C", a.Value, a.Normal)
+		ret["en-US"] = fmt.Sprintf("Unusually cold, %.1f °C compared to the normal %.1f °C", a.Value, a.Normal)
+		break
+	case PrecipitationAnomaly:
+		ret["sv-SE"] = fmt.Sprintf("Ovanligt mycket nederbörd, %.1f mm mot normala %.1f mm", a.Value, a.Normal)
+		ret["en-US"] = fmt.Sprintf("Unusually heavy precipitation, %.1f mm compared to the normal %.1f mm", a.Value, a.Normal)
+		break
+	}
+
+	return ret
+}
@@ -0,0 +1,256 @@
+package smhi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// archiveTimeFormat is the format of the timestamps that are used as file
+// names in the archive, it sorts lexically in chronological order.
+const archiveTimeFormat = "20060102T150405Z"
+
+// ErrIteratorClosed is returned when an iterator is used after it has been
+// closed or exhausted.
+var ErrIteratorClosed = errors.New("smhi: iterator is closed")
+
+// Archive stores point forecasts on disk, each forecast run is stored in a
+// JSON file of its own in a directory per grid point.
+type Archive struct {
+	dir string
+}
+
+// OpenArchive opens the archive in the given directory, the directory is
+// created if it doesn't exist.
+func OpenArchive(dir string) (*Archive, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	return &Archive{dir: dir}, nil
+}
+
+// WithArchive makes the client store every fetched forecast in the given
+// archive.
+func WithArchive(a *Archive) Option {
+	return func(c *Client) {
+		c.archive = a
+	}
+}
+
+// ObservationsPath returns the path of the corrected archive CSV file of
+// the parameter at the station.
+func (a *Archive) ObservationsPath(parameter, station int) string {
+	return filepath.Join(a.dir, "observations", fmt.Sprintf("%d", parameter), fmt.Sprintf("%d.csv", station))
+}
+
+// locationDir returns the directory that holds the forecast runs for the
+// given grid point.
+func (a *Archive) locationDir(lon, lat float64) string {
+	return filepath.Join(a.dir, fmt.Sprintf("%.6f,%.6f", lon, lat))
+}
+
+// Store writes the forecast to the archive, it's keyed on the grid point
+// of the forecast and its approved time.
+func (a *Archive) Store(pf *PointForecast) error {
+	var err error
+
+	if len(pf.Geometry.Coordinates) == 0 || len(pf.Geometry.Coordinates[0]) < 2 {
+		return errors.New("smhi: forecast has no coordinates")
+	}
+	c := pf.Geometry.Coordinates[0]
+
+	dir := a.locationDir(c[0], c[1])
+	if err = os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	var data []byte
+	if data, err = json.Marshal(pf); err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so that readers never see a
+	// partially written forecast.
+	return writeFile(filepath.Join(dir, pf.ApprovedTime.UTC().Format(archiveTimeFormat)+".json"), data)
+}
+
+// writeFile writes the data to a temporary file of its own in the
+// directory of name and renames it to name, so that readers never see a
+// partially written file and concurrent writers don't write to the same
+// temporary file.
+func writeFile(name string, data []byte) error {
+	var err error
+
+	var f *os.File
+	if f, err = ioutil.TempFile(filepath.Dir(name), filepath.Base(name)+".*.tmp"); err != nil {
+		return err
+	}
+	if _, err = f.Write(data); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err = f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	if err = os.Chmod(f.Name(), 0644); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+
+	return os.Rename(f.Name(), name)
+}
+
+// Query returns an iterator over the forecast runs for the given grid
+// point that were approved within the range from and to, both inclusive.
+// A zero from or to leaves that end of the range open. Only one forecast
+// is held in memory at a time, so it's safe to iterate over long ranges.
+func (a *Archive) Query(lon, lat float64, from, to time.Time) (*ArchiveIterator, error) {
+	var err error
+
+	var infos []os.FileInfo
+	if infos, err = ioutil.ReadDir(a.locationDir(lon, lat)); err != nil {
+		if os.IsNotExist(err) {
+			return &ArchiveIterator{}, nil
+		}
+		return nil, err
+	}
+
+	var files []string
+	for _, fi := range infos {
+		name := fi.Name()
+		if fi.IsDir() || filepath.Ext(name) != ".json" {
+			continue
+		}
+
+		var t time.Time
+		if t, err = time.Parse(archiveTimeFormat, name[:len(name)-len(".json")]); err != nil {
+			continue
+		}
+		if (!from.IsZero() && t.Before(from)) || (!to.IsZero() && t.After(to)) {
+			continue
+		}
+
+		files = append(files, filepath.Join(a.locationDir(lon, lat), name))
+	}
+	sort.Strings(files)
+
+	return &ArchiveIterator{files: files}, nil
+}
+
+// ArchiveIterator iterates over forecast runs in an archive in
+// chronological order.
+type ArchiveIterator struct {
+	files  []string
+	cur    *PointForecast
+	err    error
+	closed bool
+}
+
+// Next loads the next forecast run, it returns false when there are no
+// more runs or if an error occurred, which is reported by Err.
+func (it *ArchiveIterator) Next() bool {
+	if it.closed || it.err != nil || len(it.files) == 0 {
+		it.cur = nil
+		return false
+	}
+
+	var data []byte
+	if data, it.err = ioutil.ReadFile(it.files[0]); it.err != nil {
+		return false
+	}
+	it.files = it.files[1:]
+
+	var pf PointForecast
+	if it.err = json.Unmarshal(data, &pf); it.err != nil {
+		return false
+	}
+	it.cur = &pf
+
+	return true
+}
+
+// Scan copies the current forecast run into pf.
+func (it *ArchiveIterator) Scan(pf *PointForecast) error {
+	if it.cur == nil {
+		return ErrIteratorClosed
+	}
+
+	*pf = *it.cur
+	return nil
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *ArchiveIterator) Err() error {
+	return it.err
+}
+
+// Close stops the iteration and releases the current forecast run.
+func (it *ArchiveIterator) Close() error {
+	it.closed = true
+	it.cur = nil
+	it.files = nil
+	return nil
+}
+
+// ArchiveEntry is a file in an archive.
+type ArchiveEntry struct {
+	Path    string
+	Size    int64
+	ModTime time.Time
+}
+
+// Entries returns the files of the archive, which are the forecast runs
+// and the downloaded observations, with their paths relative to the
+// archive directory.
+func (a *Archive) Entries() ([]ArchiveEntry, error) {
+	var ret []ArchiveEntry
+
+	err := filepath.Walk(a.dir, func(path string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if fi.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(a.dir, path)
+		if err != nil {
+			return err
+		}
+		ret = append(ret, ArchiveEntry{rel, fi.Size(), fi.ModTime()})
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// Clear removes everything in the archive, the archive directory itself is
+// kept.
+func (a *Archive) Clear() error {
+	var err error
+
+	var infos []os.FileInfo
+	if infos, err = ioutil.ReadDir(a.dir); err != nil {
+		return err
+	}
+
+	for _, fi := range infos {
+		if err = os.RemoveAll(filepath.Join(a.dir, fi.Name())); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,164 @@
+// Package awslambda is a handler of API Gateway requests for the point
+// forecasts, to be started with lambda.Start of the aws-lambda-go module:
+//
+//	lambda.Start(awslambda.NewHandler().Handle)
+//
+// The request and the response mirror the JSON of the API Gateway proxy
+// integration, so the package doesn't depend on the AWS modules. The
+// forecasts are cached between the invocations of a warm function.
+package awslambda
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/osm/smhi"
+)
+
+// DefaultTTL is the time that the forecasts are cached unless SMHI_CACHE_TTL
+// says otherwise.
+const DefaultTTL = 15 * time.Minute
+
+// Request holds the fields of an API Gateway proxy request that are used by
+// the handler, both the REST and the HTTP API payloads have them.
+type Request struct {
+	QueryStringParameters map[string]string `json:"queryStringParameters"`
+}
+
+// Response is an API Gateway proxy response.
+type Response struct {
+	StatusCode int               `json:"statusCode"`
+	Headers    map[string]string `json:"headers"`
+	Body       string            `json:"body"`
+}
+
+// Handler responds with the point forecast of the lon and lat query
+// parameters.
+type Handler struct {
+	// Client is used to fetch the forecasts, the default client is used if
+	// it's nil.
+	Client *smhi.Client
+
+	// TTL is the time that the forecasts are cached, they aren't cached if
+	// it's zero.
+	TTL time.Duration
+
+	mu    sync.Mutex
+	cache map[string]cached
+}
+
+// cached is a cached forecast.
+type cached struct {
+	body    string
+	expires time.Time
+}
+
+// categoryVersions holds the current versions of the known forecast
+// categories.
+var categoryVersions = map[string]int{
+	smhi.CategoryPMP3G:  2,
+	smhi.CategorySNOW1G: 1,
+}
+
+// NewHandler returns a handler that is configured by the environment:
+//
+//	SMHI_CATEGORY      forecast category, such as pmp3g or snow1g
+//	SMHI_VERSION       version of the forecast category, which defaults to
+//	                   the current version of pmp3g and snow1g and must be
+//	                   set for the other categories
+//	SMHI_WARNINGS      attach the warnings of the location if true
+//	SMHI_DESCRIPTIONS  leave out the descriptions if false
+//	SMHI_CACHE_TTL     time to cache the forecasts, such as 10m
+func NewHandler() *Handler {
+	var opts []smhi.Option
+
+	if category := os.Getenv("SMHI_CATEGORY"); category != "" {
+		version, _ := strconv.Atoi(os.Getenv("SMHI_VERSION"))
+		if version == 0 {
+			version = categoryVersions[category]
+		}
+		opts = append(opts, smhi.WithForecastCategory(category, version))
+	}
+	if b, err := strconv.ParseBool(os.Getenv("SMHI_WARNINGS")); err == nil && b {
+		opts = append(opts, smhi.WithWarnings())
+	}
+	if b, err := strconv.ParseBool(os.Getenv("SMHI_DESCRIPTIONS")); err == nil && !b {
+		opts = append(opts, smhi.WithoutDescriptions())
+	}
+
+	ttl := DefaultTTL
+	if d, err := time.ParseDuration(os.Getenv("SMHI_CACHE_TTL")); err == nil {
+		ttl = d
+	}
+
+	return &Handler{Client: smhi.NewClient(opts...), TTL: ttl}
+}
+
+// Handle responds with the forecast as JSON, the errors are returned as
+// responses so that API Gateway passes them on.
+func (h *Handler) Handle(ctx context.Context, req Request) (Response, error) {
+	lon, errLon := strconv.ParseFloat(req.QueryStringParameters["lon"], 64)
+	lat, errLat := strconv.ParseFloat(req.QueryStringParameters["lat"], 64)
+	if errLon != nil || errLat != nil {
+		return respond(http.StatusBadRequest, `{"error":"lon and lat are required"}`), nil
+	}
+
+	key := fmt.Sprintf("%.4f,%.4f", lon, lat)
+	now := time.Now()
+
+	h.mu.Lock()
+	if c, ok := h.cache[key]; ok && now.Before(c.expires) {
+		h.mu.Unlock()
+		return respond(http.StatusOK, c.body), nil
+	}
+	h.mu.Unlock()
+
+	c := h.Client
+	if c == nil {
+		c = smhi.NewClient()
+	}
+
+	var err error
+
+	var f *smhi.PointForecast
+	if f, err = c.GetPointForecastContext(ctx, lon, lat); err != nil {
+		data, _ := json.Marshal(map[string]string{"error": err.Error()})
+		return respond(http.StatusBadGateway, string(data)), nil
+	}
+
+	var data []byte
+	if data, err = json.Marshal(f); err != nil {
+		return Response{}, err
+	}
+
+	if h.TTL > 0 {
+		h.mu.Lock()
+		if h.cache == nil {
+			h.cache = make(map[string]cached)
+		}
+		for k, c := range h.cache {
+			if now.After(c.expires) {
+				delete(h.cache, k)
+			}
+		}
+		h.cache[key] = cached{body: string(data), expires: now.Add(h.TTL)}
+		h.mu.Unlock()
+	}
+
+	return respond(http.StatusOK, string(data)), nil
+}
+
+// respond returns a JSON response.
+func respond(status int, body string) Response {
+	return Response{
+		StatusCode: status,
+		Headers:    map[string]string{"Content-Type": "application/json"},
+		Body:       body,
+	}
+}
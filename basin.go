@@ -0,0 +1,250 @@
+package smhi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// ErrNoBasin is returned when no basin contains the given coordinate.
+var ErrNoBasin = errors.New("smhi: no basin found")
+
+// BasinOptions configures how basin geometries are loaded.
+type BasinOptions struct {
+	// IDProperty is the feature property that holds the id of the basin,
+	// such as the AROID or SUBID, "AROID" is used if it's empty.
+	IDProperty string
+
+	// NameProperty is the feature property that holds the name of the
+	// basin, if any.
+	NameProperty string
+
+	// SWEREF99TM tells that the geometries are given in SWEREF 99 TM
+	// rather than in longitude and latitude.
+	SWEREF99TM bool
+}
+
+// Basin is a hydrological sub-basin.
+type Basin struct {
+	ID         string
+	Name       string
+	Properties map[string]interface{}
+	polygons   [][][][2]float64
+	min, max   [2]float64
+}
+
+// BasinIndex finds the basins that contain a coordinate.
+type BasinIndex struct {
+	basins     []Basin
+	sweref99TM bool
+}
+
+// geoJSONFeatureCollection defines the parts of a GeoJSON feature
+// collection that are used for polygon features.
+type geoJSONFeatureCollection struct {
+	Features []struct {
+		Properties map[string]interface{}
+		Geometry   geoJSONGeometry
+	}
+}
+
+// geoJSONGeometry defines a GeoJSON geometry.
+type geoJSONGeometry struct {
+	Type        string
+	Coordinates json.RawMessage
+}
+
+// polygons returns the polygons of the geometry, nil is returned for
+// geometries that aren't polygons or multi polygons.
+func (g *geoJSONGeometry) polygons() ([][][][2]float64, error) {
+	switch g.Type {
+	case "Polygon":
+		var p [][][2]float64
+		if err := json.Unmarshal(g.Coordinates, &p); err != nil {
+			return nil, err
+		}
+		return [][][][2]float64{p}, nil
+	case "MultiPolygon":
+		var mp [][][][2]float64
+		if err := json.Unmarshal(g.Coordinates, &mp); err != nil {
+			return nil, err
+		}
+		return mp, nil
+	}
+
+	return nil, nil
+}
+
+// boundingBox returns the bounding box of the exterior rings of the
+// polygons, which is used to avoid testing most of the polygons.
+func boundingBox(polygons [][][][2]float64) (min, max [2]float64) {
+	min = [2]float64{math.Inf(1), math.Inf(1)}
+	max = [2]float64{math.Inf(-1), math.Inf(-1)}
+
+	for _, p := range polygons {
+		if len(p) == 0 {
+			continue
+		}
+		for _, c := range p[0] {
+			min[0], min[1] = math.Min(min[0], c[0]), math.Min(min[1], c[1])
+			max[0], max[1] = math.Max(max[0], c[0]), math.Max(max[1], c[1])
+		}
+	}
+
+	return min, max
+}
+
+// boxContains returns true if the bounding box contains the point.
+func boxContains(min, max [2]float64, x, y float64) bool {
+	return x >= min[0] && x <= max[0] && y >= min[1] && y <= max[1]
+}
+
+// LoadBasins loads the basins from a GeoJSON feature collection of polygons
+// or multi polygons, such as the sub-basin geometries downloaded from
+// SMHI Vattenwebb.
+func LoadBasins(r io.Reader, opts BasinOptions) (*BasinIndex, error) {
+	var err error
+
+	if opts.IDProperty == "" {
+		opts.IDProperty = "AROID"
+	}
+
+	var fc geoJSONFeatureCollection
+	if err = json.NewDecoder(r).Decode(&fc); err != nil {
+		return nil, err
+	}
+
+	ret := &BasinIndex{sweref99TM: opts.SWEREF99TM}
+	for _, f := range fc.Features {
+		b := Basin{
+			ID:         fmt.Sprint(f.Properties[opts.IDProperty]),
+			Properties: f.Properties,
+		}
+		if opts.NameProperty != "" {
+			b.Name = fmt.Sprint(f.Properties[opts.NameProperty])
+		}
+
+		if b.polygons, err = f.Geometry.polygons(); err != nil {
+			return nil, err
+		}
+		if b.polygons == nil {
+			continue
+		}
+		b.min, b.max = boundingBox(b.polygons)
+
+		ret.basins = append(ret.basins, b)
+	}
+
+	return ret, nil
+}
+
+// Lookup returns the basin that contains the given longitude and latitude.
+func (bi *BasinIndex) Lookup(lon, lat float64) (*Basin, error) {
+	x, y := lon, lat
+	if bi.sweref99TM {
+		x, y = toSWEREF99TM(lon, lat)
+	}
+
+	for i := range bi.basins {
+		b := &bi.basins[i]
+		if !boxContains(b.min, b.max, x, y) {
+			continue
+		}
+
+		for _, p := range b.polygons {
+			if polygonContains(p, x, y) {
+				return b, nil
+			}
+		}
+	}
+
+	return nil, ErrNoBasin
+}
+
+// InBasin returns a station filter that keeps the stations that are
+// located within the basin of the index.
+func InBasin(bi *BasinIndex, b *Basin) StationFilter {
+	return func(s *Station) bool {
+		sb, err := bi.Lookup(s.Longitude, s.Latitude)
+		return err == nil && sb.ID == b.ID
+	}
+}
+
+// polygonContains returns true if the polygon contains the point, the
+// first ring is the exterior and the remaining rings are holes.
+func polygonContains(p [][][2]float64, x, y float64) bool {
+	if len(p) == 0 || !ringContains(p[0], x, y) {
+		return false
+	}
+
+	for _, hole := range p[1:] {
+		if ringContains(hole, x, y) {
+			return false
+		}
+	}
+	return true
+}
+
+// ringContains returns true if the ring contains the point.
+func ringContains(ring [][2]float64, x, y float64) bool {
+	var in bool
+
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		xi, yi := ring[i][0], ring[i][1]
+		xj, yj := ring[j][0], ring[j][1]
+		if (yi > y) != (yj > y) && x < (xj-xi)*(y-yi)/(yj-yi)+xi {
+			in = !in
+		}
+	}
+
+	return in
+}
+
+// toSWEREF99TM projects the longitude and latitude to SWEREF 99 TM
+// easting and northing with the Gauss-Krüger formulas of Lantmäteriet.
+func toSWEREF99TM(lon, lat float64) (float64, float64) {
+	const (
+		a         = 6378137.0
+		f         = 1 / 298.257222101
+		lambda0   = 15.0
+		k0        = 0.9996
+		falseEast = 500000.0
+	)
+
+	e2 := f * (2 - f)
+	n := f / (2 - f)
+	aRoof := a / (1 + n) * (1 + n*n/4 + n*n*n*n/64)
+
+	A := e2
+	B := (5*e2*e2 - e2*e2*e2) / 6
+	C := (104*e2*e2*e2 - 45*e2*e2*e2*e2) / 120
+	D := 1237 * e2 * e2 * e2 * e2 / 1260
+
+	beta1 := n/2 - 2*n*n/3 + 5*n*n*n/16 + 41*n*n*n*n/180
+	beta2 := 13*n*n/48 - 3*n*n*n/5 + 557*n*n*n*n/1440
+	beta3 := 61*n*n*n/240 - 103*n*n*n*n/140
+	beta4 := 49561 * n * n * n * n / 161280
+
+	phi := lat * math.Pi / 180
+	dLambda := (lon - lambda0) * math.Pi / 180
+
+	s := math.Sin(phi)
+	phiStar := phi - s*math.Cos(phi)*(A+B*s*s+C*math.Pow(s, 4)+D*math.Pow(s, 6))
+	xi := math.Atan(math.Tan(phiStar) / math.Cos(dLambda))
+	eta := math.Atanh(math.Cos(phiStar) * math.Sin(dLambda))
+
+	north := k0 * aRoof * (xi +
+		beta1*math.Sin(2*xi)*math.Cosh(2*eta) +
+		beta2*math.Sin(4*xi)*math.Cosh(4*eta) +
+		beta3*math.Sin(6*xi)*math.Cosh(6*eta) +
+		beta4*math.Sin(8*xi)*math.Cosh(8*eta))
+	east := k0*aRoof*(eta+
+		beta1*math.Cos(2*xi)*math.Sinh(2*eta)+
+		beta2*math.Cos(4*xi)*math.Sinh(4*eta)+
+		beta3*math.Cos(6*xi)*math.Sinh(6*eta)+
+		beta4*math.Cos(8*xi)*math.Sinh(8*eta)) + falseEast
+
+	return east, north
+}
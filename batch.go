@@ -0,0 +1,93 @@
+package smhi
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// BatchPoint is a location of a batch.
+type BatchPoint struct {
+	ID  string
+	Lon float64
+	Lat float64
+}
+
+// BatchResult is the forecast of a batch point, or the error that
+// prevented it from being fetched.
+type BatchResult struct {
+	Point    BatchPoint
+	Forecast *PointForecast
+	Err      error
+}
+
+// Batch fetches the point forecasts of many locations concurrently, with
+// a limit on the rate of the requests.
+type Batch struct {
+	// Client is used to fetch the forecasts, the default client is used
+	// if it's nil.
+	Client *Client
+
+	// Concurrency is the number of forecasts that are fetched at the
+	// same time, one if it's zero.
+	Concurrency int
+
+	// Interval is the least time between the start of two requests, the
+	// requests aren't limited if it's zero.
+	Interval time.Duration
+}
+
+// Fetch fetches the forecasts of the points and calls fn with the result
+// of each point as it's done, fn may be called concurrently. Points that
+// haven't been started when the context is done are skipped, and the
+// error of the context is returned.
+func (b *Batch) Fetch(ctx context.Context, points []BatchPoint, fn func(r BatchResult)) error {
+	c := b.Client
+	if c == nil {
+		c = defaultClient
+	}
+	concurrency := b.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var tick <-chan time.Time
+	if b.Interval > 0 {
+		t := time.NewTicker(b.Interval)
+		defer t.Stop()
+		tick = t.C
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+	for i, p := range points {
+		// Wait for the rate limit, the first request is started at once.
+		if tick != nil && i > 0 {
+			select {
+			case <-tick:
+			case <-ctx.Done():
+				wg.Wait()
+				return ctx.Err()
+			}
+		}
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return ctx.Err()
+		}
+
+		wg.Add(1)
+		go func(p BatchPoint) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			f, err := c.GetPointForecastContext(ctx, p.Lon, p.Lat)
+			fn(BatchResult{p, f, err})
+		}(p)
+	}
+	wg.Wait()
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package smhi
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without sending the request while the circuit
+// breaker of the client is open.
+var ErrCircuitOpen = errors.New("smhi: circuit breaker is open")
+
+// circuitBreaker counts the consecutive failed requests, and opens when
+// there are threshold of them. The generation is increased every time the
+// breaker opens, so that the outcomes of the requests that were sent
+// before it can be told apart from the outcome of the probe.
+type circuitBreaker struct {
+	mu         sync.Mutex
+	threshold  int
+	cooldown   time.Duration
+	failures   int
+	openedAt   time.Time
+	generation uint64
+	probing    bool
+}
+
+// breakerToken identifies an allowed request to the circuit breaker.
+type breakerToken struct {
+	generation uint64
+	probe      bool
+}
+
+// WithCircuitBreaker makes the client stop sending requests after the given
+// number of consecutive failures, ErrCircuitOpen is returned at once
+// instead. After the cool-down one request is let through as a probe, the
+// breaker is closed if it succeeds and opened for another cool-down if it
+// fails. Network errors, 429 Too Many Requests and the 5xx responses are
+// failures, once any retries are done, but not the requests whose context
+// is done. The outcomes of the requests that were sent before the breaker
+// opened are ignored. The breaker is shared by the copies of Client.With.
+func WithCircuitBreaker(failures int, cooldown time.Duration) Option {
+	return func(c *Client) {
+		if failures < 1 {
+			failures = 1
+		}
+		c.breaker = &circuitBreaker{threshold: failures, cooldown: cooldown}
+	}
+}
+
+// allow returns the token of the request, which is passed to record with
+// its outcome. ErrCircuitOpen is returned if the breaker is open and it
+// isn't the time for a probe, or if another request is already probing.
+func (b *circuitBreaker) allow(now time.Time) (breakerToken, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < b.threshold {
+		return breakerToken{generation: b.generation}, nil
+	}
+	if b.probing || now.Sub(b.openedAt) < b.cooldown {
+		return breakerToken{}, ErrCircuitOpen
+	}
+	b.probing = true
+	return breakerToken{generation: b.generation, probe: true}, nil
+}
+
+// record records the outcome of the request with the token. The outcome is
+// ignored if the request was canceled, or if it was allowed before the
+// breaker opened, since the breaker has already opened because of the
+// failures of the requests that were sent at that time.
+func (b *circuitBreaker) record(t breakerToken, res *http.Response, err error, canceled bool, now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if t.generation != b.generation {
+		return
+	}
+	if t.probe {
+		b.probing = false
+	}
+	if canceled {
+		return
+	}
+
+	if err == nil && res.StatusCode != http.StatusTooManyRequests && res.StatusCode < 500 {
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if t.probe || b.failures == b.threshold {
+		b.openedAt = now
+		b.generation++
+	}
+}
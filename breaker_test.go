@@ -0,0 +1,126 @@
+package smhi
+
+import (
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var (
+	okResponse          = &http.Response{StatusCode: http.StatusOK}
+	unavailableResponse = &http.Response{StatusCode: http.StatusServiceUnavailable}
+)
+
+// mustAllow returns the token of a request that the breaker must allow.
+func mustAllow(t *testing.T, b *circuitBreaker, now time.Time) breakerToken {
+	t.Helper()
+
+	token, err := b.allow(now)
+	if err != nil {
+		t.Fatalf("got error %v, want the request to be allowed", err)
+	}
+	return token
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	b := &circuitBreaker{threshold: 3, cooldown: time.Minute}
+
+	// The breaker opens after the threshold of consecutive failures, a
+	// success in between resets the count.
+	b.record(mustAllow(t, b, now), unavailableResponse, nil, false, now)
+	b.record(mustAllow(t, b, now), okResponse, nil, false, now)
+	for i := 0; i < 3; i++ {
+		b.record(mustAllow(t, b, now), unavailableResponse, nil, false, now)
+	}
+	if _, err := b.allow(now.Add(59 * time.Second)); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("got error %v, want %v", err, ErrCircuitOpen)
+	}
+
+	// One probe is allowed after the cool-down, a failed one opens the
+	// breaker for another cool-down.
+	now = now.Add(time.Minute)
+	probe := mustAllow(t, b, now)
+	if _, err := b.allow(now); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("got error %v during the probe, want %v", err, ErrCircuitOpen)
+	}
+	b.record(probe, nil, errors.New("connection refused"), false, now)
+	if _, err := b.allow(now.Add(59 * time.Second)); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("got error %v, want %v", err, ErrCircuitOpen)
+	}
+
+	// A canceled probe lets another one through.
+	now = now.Add(time.Minute)
+	b.record(mustAllow(t, b, now), nil, errors.New("context canceled"), true, now)
+
+	// A successful probe closes the breaker.
+	b.record(mustAllow(t, b, now), okResponse, nil, false, now)
+	for i := 0; i < 3; i++ {
+		mustAllow(t, b, now)
+	}
+}
+
+func TestCircuitBreakerIgnoresStaleOutcomes(t *testing.T) {
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	b := &circuitBreaker{threshold: 2, cooldown: time.Minute}
+
+	// Two slow requests are sent while the breaker is closed, and the
+	// breaker opens because of two other ones before they are done.
+	slowFailure := mustAllow(t, b, now)
+	slowSuccess := mustAllow(t, b, now)
+	b.record(mustAllow(t, b, now), unavailableResponse, nil, false, now)
+	b.record(mustAllow(t, b, now), unavailableResponse, nil, false, now)
+
+	now = now.Add(time.Minute)
+	probe := mustAllow(t, b, now)
+
+	// Neither of the slow requests ends the probe, or closes or reopens
+	// the breaker.
+	b.record(slowSuccess, okResponse, nil, false, now)
+	if _, err := b.allow(now); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("got error %v after a stale success, want %v", err, ErrCircuitOpen)
+	}
+	b.record(slowFailure, unavailableResponse, nil, false, now)
+	if _, err := b.allow(now); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("got error %v after a stale failure, want %v", err, ErrCircuitOpen)
+	}
+
+	// The outcome of the probe decides.
+	b.record(probe, okResponse, nil, false, now)
+	mustAllow(t, b, now)
+}
+
+func TestWithCircuitBreaker(t *testing.T) {
+	srv, n := failingServer(http.StatusServiceUnavailable, 2)
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL), WithCircuitBreaker(2, 50*time.Millisecond))
+	for i := 0; i < 2; i++ {
+		var apiErr *APIError
+		if _, err := c.GetPointForecast(18.0686, 59.3293); !errors.As(err, &apiErr) {
+			t.Fatalf("got error %v, want an APIError", err)
+		}
+	}
+
+	if _, err := c.GetPointForecast(18.0686, 59.3293); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("got error %v, want %v", err, ErrCircuitOpen)
+	}
+	if got := atomic.LoadInt64(n); got != 2 {
+		t.Fatalf("got %d requests, want 2", got)
+	}
+
+	// The probe after the cool-down succeeds and closes the breaker, which
+	// is shared by the copies of the client.
+	time.Sleep(60 * time.Millisecond)
+	if _, err := c.With(WithoutDescriptions()).GetPointForecast(18.0686, 59.3293); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetPointForecast(18.0686, 59.3293); err != nil {
+		t.Fatal(err)
+	}
+	if got := atomic.LoadInt64(n); got != 4 {
+		t.Errorf("got %d requests, want 4", got)
+	}
+}
@@ -0,0 +1,141 @@
+package smhi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultCacheTTL is the time after the approved time of a forecast that
+// it's cached, SMHI approves new point forecasts about once an hour.
+const DefaultCacheTTL = time.Hour
+
+// cacheMinTTL is the least time that a forecast is cached, so that the
+// forecasts aren't fetched on every call when a new forecast is late.
+const cacheMinTTL = 5 * time.Minute
+
+// ErrNotCached is returned by clients with WithCacheOnly when the forecast
+// isn't in the cache, or when they have no cache.
+var ErrNotCached = errors.New("smhi: forecast isn't cached")
+
+// cachePolicy decides how a client uses its cache.
+type cachePolicy int
+
+const (
+	// cacheDefault uses the cache and fetches what isn't in it.
+	cacheDefault cachePolicy = iota
+
+	// cacheForceRefresh fetches the forecasts and updates the cache.
+	cacheForceRefresh
+
+	// cacheOnly uses the cache and never fetches.
+	cacheOnly
+)
+
+// WithForceRefresh makes the client fetch the forecasts even when they are
+// cached, the cache is updated with the fetched forecasts.
+func WithForceRefresh() Option {
+	return func(c *Client) {
+		c.cachePolicy = cacheForceRefresh
+	}
+}
+
+// WithCacheOnly makes the client return the cached forecasts and never
+// send any requests, ErrNotCached is returned for the forecasts that
+// aren't cached.
+func WithCacheOnly() Option {
+	return func(c *Client) {
+		c.cachePolicy = cacheOnly
+	}
+}
+
+// Cache holds point forecasts in memory, keyed on their coordinates and the
+// options of the client, until the approved time of the forecast plus the
+// TTL has passed. A Cache is safe for concurrent use and can be shared by
+// clients. The cached forecasts are shared by the callers, so they must not
+// be modified.
+type Cache struct {
+	// TTL is the time after the approved time of a forecast that it's
+	// cached, DefaultCacheTTL is used if it's zero. Forecasts are always
+	// cached for at least five minutes after they are fetched.
+	TTL time.Duration
+
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+// cacheEntry is a cached forecast and the time that it expires.
+type cacheEntry struct {
+	pf      *PointForecast
+	expires time.Time
+}
+
+// WithCache makes the client return the forecasts of the cache, and store
+// the forecasts that it fetches in it.
+func WithCache(cache *Cache) Option {
+	return func(c *Client) {
+		c.cache = cache
+	}
+}
+
+// Len returns the number of forecasts in the cache, including the expired
+// ones that haven't been removed yet.
+func (cache *Cache) Len() int {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	return len(cache.entries)
+}
+
+// get returns the forecast of the key if it hasn't expired.
+func (cache *Cache) get(key string, now time.Time) (*PointForecast, bool) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	e, ok := cache.entries[key]
+	if !ok || now.After(e.expires) {
+		return nil, false
+	}
+	return e.pf, true
+}
+
+// put stores the forecast with the key and removes the expired forecasts.
+func (cache *Cache) put(key string, pf *PointForecast, now time.Time) {
+	ttl := cache.TTL
+	if ttl == 0 {
+		ttl = DefaultCacheTTL
+	}
+	expires := pf.ApprovedTime.Add(ttl)
+	if min := now.Add(cacheMinTTL); expires.Before(min) {
+		expires = min
+	}
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	if cache.entries == nil {
+		cache.entries = make(map[string]cacheEntry)
+	}
+	for k, e := range cache.entries {
+		if now.After(e.expires) {
+			delete(cache.entries, k)
+		}
+	}
+	cache.entries[key] = cacheEntry{pf, expires}
+}
+
+// cacheKey returns the key of the forecast of the coordinates, which holds
+// the options of the client that change the forecasts.
+func (c *Client) cacheKey(lon, lat float64) string {
+	return fmt.Sprint(c.pointForecastURL(lon, lat), c.descriptions, c.interpolation, c.waves, c.warnings, c.raw, c.parameters, c.precision)
+}
+
+// cacheOutcomeKey is the context key of the cache outcome of the requests.
+type cacheOutcomeKey struct{}
+
+// withCacheOutcome returns a context that makes the journal record the
+// requests with the cache outcome.
+func withCacheOutcome(ctx context.Context, outcome string) context.Context {
+	return context.WithValue(ctx, cacheOutcomeKey{}, outcome)
+}
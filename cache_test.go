@@ -0,0 +1,150 @@
+package smhi
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// forecastServer returns a test server that responds with the forecast,
+// along with the number of requests that it has served.
+func forecastServer() (*httptest.Server, *int64) {
+	return failingServer(http.StatusOK, 0)
+}
+
+func TestCache(t *testing.T) {
+	srv, n := forecastServer()
+	defer srv.Close()
+
+	cache := &Cache{}
+	c := NewClient(WithBaseURL(srv.URL), WithCache(cache))
+
+	first, err := c.GetPointForecast(18.0686, 59.3293)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := c.GetPointForecast(18.0686, 59.3293)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first != second {
+		t.Error("the cached forecast wasn't returned")
+	}
+	if got := atomic.LoadInt64(n); got != 1 {
+		t.Errorf("got %d requests, want 1", got)
+	}
+
+	// Other coordinates and other options that change the forecast miss.
+	if _, err = c.GetPointForecast(11.9746, 57.7089); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = c.With(WithoutDescriptions()).GetPointForecast(18.0686, 59.3293); err != nil {
+		t.Fatal(err)
+	}
+	if got := atomic.LoadInt64(n); got != 3 {
+		t.Errorf("got %d requests, want 3", got)
+	}
+
+	// A client that shares the cache hits as well.
+	if _, err = NewClient(WithBaseURL(srv.URL), WithCache(cache)).GetPointForecast(18.0686, 59.3293); err != nil {
+		t.Fatal(err)
+	}
+	if got := atomic.LoadInt64(n); got != 3 {
+		t.Errorf("got %d requests, want 3", got)
+	}
+	if got := cache.Len(); got != 3 {
+		t.Errorf("got %d cached forecasts, want 3", got)
+	}
+}
+
+func TestCacheForceRefresh(t *testing.T) {
+	srv, n := forecastServer()
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL), WithCache(&Cache{}))
+	first, err := c.GetPointForecast(18.0686, 59.3293)
+	if err != nil {
+		t.Fatal(err)
+	}
+	refreshed, err := c.With(WithForceRefresh()).GetPointForecast(18.0686, 59.3293)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if refreshed == first {
+		t.Error("the cached forecast was returned")
+	}
+
+	// The refreshed forecast is cached.
+	cached, err := c.GetPointForecast(18.0686, 59.3293)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cached != refreshed {
+		t.Error("the refreshed forecast wasn't cached")
+	}
+	if got := atomic.LoadInt64(n); got != 2 {
+		t.Errorf("got %d requests, want 2", got)
+	}
+}
+
+func TestCacheOnly(t *testing.T) {
+	srv, n := forecastServer()
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL), WithCache(&Cache{}))
+	offline := c.With(WithCacheOnly())
+
+	if _, err := offline.GetPointForecast(18.0686, 59.3293); !errors.Is(err, ErrNotCached) {
+		t.Fatalf("got error %v, want %v", err, ErrNotCached)
+	}
+	if got := atomic.LoadInt64(n); got != 0 {
+		t.Fatalf("got %d requests, want none", got)
+	}
+
+	if _, err := c.GetPointForecast(18.0686, 59.3293); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := offline.GetPointForecast(18.0686, 59.3293); err != nil {
+		t.Fatal(err)
+	}
+	if got := atomic.LoadInt64(n); got != 1 {
+		t.Errorf("got %d requests, want 1", got)
+	}
+
+	// A client without a cache has nothing to return.
+	if _, err := NewClient(WithBaseURL(srv.URL), WithCacheOnly()).GetPointForecast(18.0686, 59.3293); !errors.Is(err, ErrNotCached) {
+		t.Errorf("got error %v, want %v", err, ErrNotCached)
+	}
+}
+
+func TestCacheExpiry(t *testing.T) {
+	now := time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC)
+	approved := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		ttl      time.Duration
+		approved time.Time
+		at       time.Duration
+		ok       bool
+	}{
+		{"fresh", 0, approved, 20 * time.Minute, true},
+		{"expired", 0, approved, 31 * time.Minute, false},
+		{"custom ttl", 2 * time.Hour, approved, time.Hour, true},
+		{"late forecast is kept for the least ttl", 0, approved.Add(-2 * time.Hour), 4 * time.Minute, true},
+		{"late forecast expires after the least ttl", 0, approved.Add(-2 * time.Hour), 6 * time.Minute, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := &Cache{TTL: tt.ttl}
+			cache.put("key", &PointForecast{ApprovedTime: tt.approved}, now)
+			if _, ok := cache.get("key", now.Add(tt.at)); ok != tt.ok {
+				t.Errorf("got %v, want %v", ok, tt.ok)
+			}
+		})
+	}
+}
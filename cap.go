@@ -0,0 +1,208 @@
+package smhi
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CAPAlert defines the structure of a Common Alerting Protocol 1.2 alert.
+type CAPAlert struct {
+	Identifier string    `xml:"identifier"`
+	Sent       string    `xml:"sent"`
+	Info       []CAPInfo `xml:"info"`
+}
+
+// CAPInfo is the information of a CAP alert in one language.
+type CAPInfo struct {
+	Language    string `xml:"language"`
+	Event       string `xml:"event"`
+	Severity    string `xml:"severity"`
+	Onset       string `xml:"onset"`
+	Effective   string `xml:"effective"`
+	Expires     string `xml:"expires"`
+	Description string `xml:"description"`
+	EventCode   []struct {
+		ValueName string `xml:"valueName"`
+		Value     string `xml:"value"`
+	} `xml:"eventCode"`
+	Parameter []struct {
+		ValueName string `xml:"valueName"`
+		Value     string `xml:"value"`
+	} `xml:"parameter"`
+	Area []struct {
+		AreaDesc string   `xml:"areaDesc"`
+		Polygon  []string `xml:"polygon"`
+	} `xml:"area"`
+}
+
+// ParseCAP parses one or more CAP alerts, such as the SMHI warnings that
+// are exchanged in CAP form, into warnings with one warning per area. The
+// infos of the languages are merged into the localized fields.
+func ParseCAP(r io.Reader) ([]Warning, error) {
+	var err error
+	var ret []Warning
+
+	d := xml.NewDecoder(r)
+	for {
+		var a CAPAlert
+		if err = d.Decode(&a); err == io.EOF {
+			return ret, nil
+		} else if err != nil {
+			return nil, err
+		}
+
+		var warnings []Warning
+		if warnings, err = a.warnings(); err != nil {
+			return nil, err
+		}
+		ret = append(ret, warnings...)
+	}
+}
+
+// warnings returns the warnings of the areas of the alert, the areas of
+// the infos are matched by their order.
+func (a *CAPAlert) warnings() ([]Warning, error) {
+	var err error
+
+	if len(a.Info) == 0 {
+		return nil, nil
+	}
+
+	ret := make([]Warning, len(a.Info[0].Area))
+	for i := range ret {
+		ret[i].ID = capID(a.Identifier)
+		ret[i].Event = make(map[string]string)
+		ret[i].Area = make(map[string]string)
+		ret[i].Description = make(map[string]string)
+	}
+
+	for n, info := range a.Info {
+		lang := capLanguage(info.Language)
+
+		for i := range ret {
+			w := &ret[i]
+			w.Event[lang] = info.Event
+			w.Description[lang] = info.Description
+			if i < len(info.Area) {
+				w.Area[lang] = info.Area[i].AreaDesc
+			}
+			if n > 0 {
+				continue
+			}
+
+			if len(info.EventCode) > 0 {
+				w.EventCode = info.EventCode[0].Value
+			}
+			w.Level = info.level()
+
+			start := info.Onset
+			if start == "" {
+				start = info.Effective
+			}
+			if start == "" {
+				start = a.Sent
+			}
+			if w.Start, err = time.Parse(time.RFC3339, start); err != nil {
+				return nil, err
+			}
+			if info.Expires != "" {
+				if w.End, err = time.Parse(time.RFC3339, info.Expires); err != nil {
+					return nil, err
+				}
+			}
+
+			for _, p := range info.Area[i].Polygon {
+				var ring [][2]float64
+				if ring, err = capPolygon(p); err != nil {
+					return nil, err
+				}
+				w.polygons = append(w.polygons, [][][2]float64{ring})
+			}
+			w.min, w.max = boundingBox(w.polygons)
+		}
+	}
+
+	return ret, nil
+}
+
+// level returns the warning level of the info, as given by the MeteoAlarm
+// awareness level parameter or else by the severity.
+func (info *CAPInfo) level() string {
+	for _, p := range info.Parameter {
+		if p.ValueName != "awareness_level" {
+			continue
+		}
+
+		v := strings.ToLower(p.Value)
+		if strings.Contains(v, "red") {
+			return WarningRed
+		} else if strings.Contains(v, "orange") {
+			return WarningOrange
+		} else if strings.Contains(v, "yellow") {
+			return WarningYellow
+		}
+	}
+
+	switch info.Severity {
+	case "Extreme":
+		return WarningRed
+	case "Severe":
+		return WarningOrange
+	case "Moderate":
+		return WarningYellow
+	}
+	return WarningMessage
+}
+
+// capLanguage returns the locale of a CAP language, such as "sv-SE" for
+// "sv". CAP defaults to en-US when the language is missing.
+func capLanguage(lang string) string {
+	switch strings.ToLower(strings.SplitN(lang, "-", 2)[0]) {
+	case "sv":
+		return "sv-SE"
+	case "en", "":
+		return "en-US"
+	}
+	return lang
+}
+
+// capID returns the trailing number of the identifier of the alert, zero
+// is returned if it doesn't end with a number.
+func capID(identifier string) int {
+	i := len(identifier)
+	for i > 0 && identifier[i-1] >= '0' && identifier[i-1] <= '9' {
+		i--
+	}
+
+	id, _ := strconv.Atoi(identifier[i:])
+	return id
+}
+
+// capPolygon parses a CAP polygon, which is a list of latitude and
+// longitude pairs separated by spaces.
+func capPolygon(s string) ([][2]float64, error) {
+	var ret [][2]float64
+
+	for _, pair := range strings.Fields(s) {
+		c := strings.Split(pair, ",")
+		if len(c) != 2 {
+			return nil, fmt.Errorf("smhi: invalid CAP polygon point %q", pair)
+		}
+
+		lat, err := strconv.ParseFloat(c[0], 64)
+		if err != nil {
+			return nil, err
+		}
+		lon, err := strconv.ParseFloat(c[1], 64)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, [2]float64{lon, lat})
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,668 @@
+package smhi
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	forecastBaseURL = "https://opendata-download-metfcst.smhi.se"
+	forecastURL     = "%s/api/category/%s/version/%d/geotype/point/lon/%f/lat/%f/data.json"
+)
+
+// Categories of the point forecast API.
+const (
+	// CategoryPMP3G is the forecast of the MEPS and ECMWF models, its
+	// current version is 2.
+	CategoryPMP3G = "pmp3g"
+
+	// CategorySNOW1G is the forecast of the SNOW model, its current
+	// version is 1.
+	CategorySNOW1G = "snow1g"
+)
+
+// errNotFound is the kind of the API errors of 404 Not Found responses.
+var errNotFound = errors.New("smhi: not found")
+
+// ErrOutsideGrid is the kind of the API errors of the point forecasts whose
+// coordinates are outside of the grid of the forecast.
+var ErrOutsideGrid = errors.New("smhi: coordinates are outside of the grid")
+
+// APIError is returned when the API responds with a status other than 2xx,
+// it holds the status, the body of the response and the requested URL.
+// Err is the kind of the error if it's known, such as ErrOutsideGrid, so
+//
+//	errors.Is(err, smhi.ErrOutsideGrid)
+//
+// tells whether the point is outside of the grid.
+type APIError struct {
+	StatusCode int
+	Status     string
+	URL        string
+	Body       []byte
+	Err        error
+}
+
+// Error returns the status of the response and the requested URL, along
+// with the kind of the error if it's known.
+func (e *APIError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%s: %s responded with %s", e.Err, e.URL, e.Status)
+	}
+	return fmt.Sprintf("smhi: %s responded with %s", e.URL, e.Status)
+}
+
+// Unwrap returns the kind of the error.
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
+
+// ErrInvalidCategory is returned when the forecast category or version of
+// the client isn't valid.
+var ErrInvalidCategory = errors.New("smhi: invalid forecast category or version")
+
+// validCategory matches the names of the forecast categories.
+var validCategory = regexp.MustCompile(`^[a-z0-9]+$`)
+
+// defaultClient is the client that is used by the package level functions.
+var defaultClient = NewClient()
+
+// Client holds the configuration that is used when fetching and parsing
+// data from the SMHI API. A Client is safe for concurrent use by multiple
+// goroutines, so one client should be shared rather than one created per
+// request.
+type Client struct {
+	descriptions  bool
+	archive       *Archive
+	waves         bool
+	warnings      bool
+	raw           bool
+	parameters    map[string]bool
+	category      string
+	version       int
+	transport     http.RoundTripper
+	httpClient    *http.Client
+	maxConns      int
+	idleTimeout   time.Duration
+	tuned         http.RoundTripper
+	timeout       time.Duration
+	userAgent     string
+	baseURL       string
+	hypeURL       string
+	retry         *RetryPolicy
+	cache         *Cache
+	validators    *validatorStore
+	limiter       *rateLimiter
+	breaker       *circuitBreaker
+	metrics       Metrics
+	interpolation bool
+	precision     Precision
+	journal       *Journal
+	cachePolicy   cachePolicy
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// NewClient returns a new Client configured with the given options.
+func NewClient(opts ...Option) *Client {
+	c := &Client{
+		descriptions: true,
+		category:     CategoryPMP3G,
+		version:      2,
+		baseURL:      forecastBaseURL,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.tuneTransport()
+
+	return c
+}
+
+// With returns a copy of the client with the options applied, which lets a
+// single call use other options than the client, such as
+//
+//	c.With(smhi.WithForceRefresh()).GetPointForecast(lon, lat)
+//
+// The copy shares the archive, the cache, the remembered responses of the
+// conditional requests, the rate limit, the circuit breaker and the journal
+// of the client.
+func (c *Client) With(opts ...Option) *Client {
+	ret := *c
+	for _, opt := range opts {
+		opt(&ret)
+	}
+	ret.tuneTransport()
+	return &ret
+}
+
+// WithoutDescriptions makes the client skip populating the localized
+// description maps while parsing, which is useful for callers that only
+// need the numeric data.
+func WithoutDescriptions() Option {
+	return func(c *Client) {
+		c.descriptions = false
+	}
+}
+
+// WithForecastCategory makes the client fetch the point forecasts from the
+// given category and version, such as CategorySNOW1G and 1. Categories and
+// versions that aren't known by the package can be used as well, as long
+// as they are lower case names and positive versions.
+func WithForecastCategory(category string, version int) Option {
+	return func(c *Client) {
+		c.category = category
+		c.version = version
+	}
+}
+
+// WithParameters makes the client only populate the fields of the given
+// SMHI parameters, such as "t", "ws" and "pmean", and skip the rest. The
+// derived fields and the descriptions are left empty, since they depend on
+// parameters that may not be populated.
+func WithParameters(names ...string) Option {
+	return func(c *Client) {
+		c.parameters = make(map[string]bool)
+		for _, name := range names {
+			c.parameters[name] = true
+		}
+	}
+}
+
+// WithRawResponse makes the client keep the body and the header of the
+// response in the point forecasts, which is useful for auditing and for
+// serving the original data.
+func WithRawResponse() Option {
+	return func(c *Client) {
+		c.raw = true
+	}
+}
+
+// WithTransport makes the client send its requests with the given
+// transport instead of the default transport of net/http, such as a
+// FetchTransport in js/wasm builds.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(c *Client) {
+		c.transport = rt
+		c.tuned = nil
+	}
+}
+
+// WithHTTPClient makes the client send its requests with the given HTTP
+// client instead of http.DefaultClient, which keeps its timeout, redirect
+// policy, cookie jar and transport, such as one with custom TLS settings,
+// a proxy or an instrumented RoundTripper. A transport that is given by
+// WithTransport, or tuned by WithMaxConnsPerHost and WithIdleConnTimeout,
+// replaces the transport of a copy of the HTTP client.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = hc
+		c.tuned = nil
+	}
+}
+
+// WithTimeout limits the time of each request, including reading the body
+// of the response. There is no limit by default, other than the one of the
+// HTTP client that is given by WithHTTPClient.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = d
+	}
+}
+
+// WithUserAgent makes the client send the given User-Agent header, SMHI
+// asks the users of its API to identify themselves with one, such as
+// "myapp/1.0 (me@example.com)".
+func WithUserAgent(ua string) Option {
+	return func(c *Client) {
+		c.userAgent = ua
+	}
+}
+
+// WithBaseURL makes the client fetch the point and the fire risk forecasts
+// from the given base URL instead of
+// https://opendata-download-metfcst.smhi.se, such as a mirror, a caching
+// proxy or a test server. The paths of the API are kept.
+func WithBaseURL(base string) Option {
+	return func(c *Client) {
+		c.baseURL = strings.TrimSuffix(base, "/")
+	}
+}
+
+// WithMaxConnsPerHost limits the number of connections per host and keeps
+// as many of them idle between the requests, which lets services with many
+// concurrent requests reuse their connections. It tunes a copy of the
+// transport of the client, which is the one that is given by WithTransport
+// or WithHTTPClient regardless of the order of the options, or the default
+// transport. It doesn't apply to transports that aren't an *http.Transport.
+func WithMaxConnsPerHost(n int) Option {
+	return func(c *Client) {
+		c.maxConns = n
+		c.tuned = nil
+	}
+}
+
+// WithIdleConnTimeout sets the time that idle connections are kept open.
+// It tunes the transport in the same way as WithMaxConnsPerHost.
+func WithIdleConnTimeout(d time.Duration) Option {
+	return func(c *Client) {
+		c.idleTimeout = d
+		c.tuned = nil
+	}
+}
+
+// tuneTransport resolves the transport that is tuned by WithMaxConnsPerHost
+// and WithIdleConnTimeout once the options have run. The tuned transport is
+// kept until an option changes the transport or the tuning, so the copies
+// of Client.With share its connections.
+func (c *Client) tuneTransport() {
+	if c.tuned != nil || (c.maxConns == 0 && c.idleTimeout == 0) {
+		return
+	}
+
+	rt := c.transport
+	if rt == nil && c.httpClient != nil {
+		rt = c.httpClient.Transport
+	}
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+
+	t, ok := rt.(*http.Transport)
+	if !ok {
+		return
+	}
+	t = t.Clone()
+	if c.maxConns != 0 {
+		t.MaxConnsPerHost = c.maxConns
+		t.MaxIdleConnsPerHost = c.maxConns
+	}
+	if c.idleTimeout != 0 {
+		t.IdleConnTimeout = c.idleTimeout
+	}
+	c.tuned = t
+}
+
+// GetPointForecast fetches a forecast from the SMHI API for the given
+// longitude and latitude using the default client.
+func GetPointForecast(lon, lat float64) (*PointForecast, error) {
+	return defaultClient.GetPointForecast(lon, lat)
+}
+
+// GetPointForecast fetches a forecast from the SMHI API for the given
+// longitude and latitude.
+func (c *Client) GetPointForecast(lon, lat float64) (*PointForecast, error) {
+	return c.GetPointForecastContext(context.Background(), lon, lat)
+}
+
+// GetPointForecastContext fetches a forecast from the SMHI API for the
+// given longitude and latitude using the default client, the requests are
+// canceled when the context is done.
+func GetPointForecastContext(ctx context.Context, lon, lat float64) (*PointForecast, error) {
+	return defaultClient.GetPointForecastContext(ctx, lon, lat)
+}
+
+// GetPointForecastContext fetches a forecast from the SMHI API for the
+// given longitude and latitude. The requests, including the ones for the
+// interpolation, the waves and the warnings, are canceled when the context
+// is done, and the error of the context is returned.
+func (c *Client) GetPointForecastContext(ctx context.Context, lon, lat float64) (*PointForecast, error) {
+	var err error
+
+	if !validCategory.MatchString(c.category) || c.version < 1 {
+		return nil, ErrInvalidCategory
+	}
+
+	// Return the cached forecast unless it's refreshed, the requests of
+	// the forecasts that aren't cached are journaled as misses.
+	var key string
+	if c.cache != nil {
+		key = c.cacheKey(lon, lat)
+		if c.cachePolicy != cacheForceRefresh {
+			if pf, ok := c.cache.get(key, time.Now()); ok {
+				c.journalCacheHit(c.pointForecastURL(lon, lat))
+				return pf, nil
+			}
+		}
+		ctx = withCacheOutcome(ctx, CacheMiss)
+	}
+
+	// Nothing is fetched when only cached forecasts may be returned.
+	if c.cachePolicy == cacheOnly {
+		return nil, ErrNotCached
+	}
+
+	// Fetch the forecast for the given longitude and latitude.
+	var ret *PointForecast
+	var data []byte
+	var header http.Header
+	if ret, data, header, err = c.fetchPointForecast(ctx, lon, lat); err != nil {
+		return nil, err
+	}
+
+	// Interpolate the forecast from the surrounding grid points.
+	if c.interpolation {
+		if err = c.interpolate(ctx, ret, lon, lat); err != nil {
+			return nil, err
+		}
+	}
+
+	// Keep the original response if it's asked for.
+	if c.raw {
+		ret.Raw, ret.RawHeader = data, header
+	}
+
+	// Merge the wave forecast into the forecast if the point is over sea.
+	if c.waves {
+		if err = c.mergeWaves(ctx, ret, lon, lat); err != nil {
+			return nil, err
+		}
+	}
+
+	// Attach the active warnings of the location.
+	if c.warnings {
+		if ret.Warnings, err = c.GetWarningsAtContext(ctx, lon, lat); err != nil {
+			return nil, err
+		}
+	}
+
+	// Round the values if a precision is given.
+	if c.precision != nil {
+		ret.Round(c.precision)
+	}
+
+	// Keep a copy of the forecast in the archive, if there is one.
+	if c.archive != nil {
+		if err = c.archive.Store(ret); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.cache != nil {
+		c.cache.put(key, ret, time.Now())
+	}
+
+	return ret, nil
+}
+
+// pointForecastURL returns the URL of the point forecast of the given
+// longitude and latitude.
+func (c *Client) pointForecastURL(lon, lat float64) string {
+	return fmt.Sprintf(forecastURL, c.baseURL, c.category, c.version, lon, lat)
+}
+
+// fetchPointForecast fetches and converts the forecast of the grid point
+// that is nearest to the given longitude and latitude, the header of the
+// response is returned as well, along with the body if the client keeps
+// the raw responses.
+func (c *Client) fetchPointForecast(ctx context.Context, lon, lat float64) (*PointForecast, []byte, http.Header, error) {
+	var err error
+
+	url := c.pointForecastURL(lon, lat)
+	fetchedAt := time.Now()
+
+	var body io.ReadCloser
+	var header http.Header
+	if body, header, err = c.open(ctx, url); err != nil {
+		// SMHI responds with either 400 or 404 for points outside of the
+		// grid.
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && (apiErr.StatusCode == http.StatusBadRequest || apiErr.StatusCode == http.StatusNotFound) {
+			apiErr.Err = ErrOutsideGrid
+		}
+		return nil, nil, nil, err
+	}
+	defer body.Close()
+
+	// The body is only read into a buffer if it's kept.
+	var r io.Reader = body
+	var data []byte
+	if c.raw {
+		if data, err = ioutil.ReadAll(body); err != nil {
+			return nil, nil, nil, err
+		}
+		r = bytes.NewReader(data)
+	}
+
+	// Decode the data into the data structure that's defined by SMHI,
+	// either schema of the point forecasts is accepted.
+	var decodedData *PointForecastAPI
+	if decodedData, err = decodePointForecast(r); err != nil {
+		return nil, nil, nil, err
+	}
+
+	// Create a new copy of the data in a structure that is defined by us,
+	// which makes it easier to find the given temperature etc.
+	var ret *PointForecast
+	if ret, err = c.toPointForecast(decodedData); err != nil {
+		return nil, nil, nil, err
+	}
+	ret.Meta = newMeta(url, fetchedAt, header)
+
+	return ret, data, header, nil
+}
+
+// newMeta returns the metadata of a response, the headers that can't be
+// parsed are left as zero.
+func newMeta(url string, fetchedAt time.Time, header http.Header) Meta {
+	m := Meta{URL: url, FetchedAt: fetchedAt, ETag: header.Get("ETag")}
+	m.Date, _ = http.ParseTime(header.Get("Date"))
+	m.Expires, _ = http.ParseTime(header.Get("Expires"))
+	m.LastModified, _ = http.ParseTime(header.Get("Last-Modified"))
+	return m
+}
+
+// do sends the request and returns the response.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	hc := http.DefaultClient
+	if c.httpClient != nil {
+		hc = c.httpClient
+	}
+	rt := c.transport
+	if c.tuned != nil {
+		rt = c.tuned
+	}
+	if rt != nil || c.timeout > 0 {
+		configured := *hc
+		if rt != nil {
+			configured.Transport = rt
+		}
+		if c.timeout > 0 {
+			configured.Timeout = c.timeout
+		}
+		hc = &configured
+	}
+
+	if c.userAgent != "" && req.Header.Get("User-Agent") == "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+
+	// Fail fast while the circuit breaker is open.
+	var token breakerToken
+	if c.breaker != nil {
+		var err error
+		if token, err = c.breaker.allow(time.Now()); err != nil {
+			return nil, err
+		}
+	}
+
+	res, err := c.doWithRetry(hc, req)
+	if c.breaker != nil {
+		c.breaker.record(token, res, err, req.Context().Err() != nil, time.Now())
+	}
+	return res, err
+}
+
+// get fetches the given URL and returns the body of the response.
+func (c *Client) get(url string) ([]byte, error) {
+	data, _, err := c.getWithHeader(url)
+	return data, err
+}
+
+// getContext fetches the given URL with the context and returns the body
+// of the response.
+func (c *Client) getContext(ctx context.Context, url string) ([]byte, error) {
+	data, _, err := c.getWithHeaderContext(ctx, url)
+	return data, err
+}
+
+// getWithHeader fetches the given URL and returns the body and the header
+// of the response.
+func (c *Client) getWithHeader(url string) ([]byte, http.Header, error) {
+	return c.getWithHeaderContext(context.Background(), url)
+}
+
+// getWithHeaderContext fetches the given URL with the context and returns
+// the body and the header of the response.
+func (c *Client) getWithHeaderContext(ctx context.Context, url string) ([]byte, http.Header, error) {
+	var err error
+
+	var body io.ReadCloser
+	var header http.Header
+	if body, header, err = c.open(ctx, url); err != nil {
+		return nil, nil, err
+	}
+	defer body.Close()
+
+	// Read all of the data into a buffer.
+	var data []byte
+	if data, err = ioutil.ReadAll(body); err != nil {
+		return nil, nil, err
+	}
+
+	return data, header, nil
+}
+
+// getJSON fetches the given URL and decodes the JSON of the response into
+// v.
+func (c *Client) getJSON(url string, v interface{}) error {
+	return c.getJSONContext(context.Background(), url, v)
+}
+
+// getJSONContext fetches the given URL with the context and decodes the
+// JSON of the response into v, the JSON is decoded as it's read rather
+// than from a buffer of the whole response.
+func (c *Client) getJSONContext(ctx context.Context, url string, v interface{}) error {
+	body, _, err := c.open(ctx, url)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	return json.NewDecoder(body).Decode(v)
+}
+
+// open fetches the given URL with the context and returns the body and the
+// header of the response, the body must be closed. The responses that
+// aren't 2xx are returned as an APIError. The body is read into a buffer
+// when the client remembers the responses of the conditional requests,
+// otherwise it's read from the connection as it's used.
+func (c *Client) open(ctx context.Context, url string) (io.ReadCloser, http.Header, error) {
+	var err error
+
+	var req *http.Request
+	if req, err = http.NewRequest(http.MethodGet, url, nil); err != nil {
+		return nil, nil, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Accept-Encoding", "gzip")
+	if c.validators != nil {
+		c.validators.prepare(url, req)
+	}
+
+	var res *http.Response
+	if res, err = c.do(req); err != nil {
+		return nil, nil, err
+	}
+
+	var body io.ReadCloser
+	if body, err = decodedBody(res); err != nil {
+		res.Body.Close()
+		return nil, nil, err
+	}
+
+	// Use the remembered body if it hasn't been modified.
+	if res.StatusCode == http.StatusNotModified && c.validators != nil {
+		if data, header, ok := c.validators.notModified(url, res.Header); ok {
+			body.Close()
+			return ioutil.NopCloser(bytes.NewReader(data)), header, nil
+		}
+	}
+
+	if res.StatusCode < 200 || res.StatusCode > 299 || c.validators != nil {
+		defer body.Close()
+
+		var data []byte
+		if data, err = ioutil.ReadAll(body); err != nil {
+			return nil, nil, err
+		}
+
+		if res.StatusCode < 200 || res.StatusCode > 299 {
+			return nil, nil, newAPIError(url, res, data)
+		}
+
+		c.validators.store(url, data, res.Header)
+		return ioutil.NopCloser(bytes.NewReader(data)), res.Header, nil
+	}
+
+	return body, res.Header, nil
+}
+
+// newAPIError returns the APIError of the response of the URL with the
+// given body.
+func newAPIError(url string, res *http.Response, body []byte) *APIError {
+	ret := &APIError{StatusCode: res.StatusCode, Status: res.Status, URL: url, Body: body}
+	if res.StatusCode == http.StatusNotFound {
+		ret.Err = errNotFound
+	}
+	return ret
+}
+
+// responseBody reads a response body, and reads the rest of it when it's
+// closed so that the connection can be reused and the journal records the
+// size of the whole response.
+type responseBody struct {
+	io.Reader
+	body io.ReadCloser
+}
+
+// Close reads the rest of the body and closes it.
+func (b *responseBody) Close() error {
+	io.Copy(ioutil.Discard, b.body)
+	return b.body.Close()
+}
+
+// decodedBody returns the body of the response, which is decompressed if
+// it's gzip encoded. The requests of the client ask for gzip themselves,
+// so the responses aren't decompressed by the transport, which lets
+// transports that don't support compression benefit from it as well. The
+// encoding and the length are removed from the header of decompressed
+// responses, the same way as by the transport.
+func decodedBody(res *http.Response) (io.ReadCloser, error) {
+	if !strings.EqualFold(res.Header.Get("Content-Encoding"), "gzip") {
+		return &responseBody{res.Body, res.Body}, nil
+	}
+	res.Header.Del("Content-Encoding")
+	res.Header.Del("Content-Length")
+
+	zr, err := gzip.NewReader(res.Body)
+	if err == io.EOF {
+		return &responseBody{bytes.NewReader(nil), res.Body}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return &responseBody{zr, res.Body}, nil
+}
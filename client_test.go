@@ -0,0 +1,396 @@
+package smhi
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/tls"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testForecastJSON is a point forecast in the pmp3g version 2 schema with
+// two time steps.
+const testForecastJSON = `{
+	"approvedTime": "2024-05-01T10:00:00Z",
+	"referenceTime": "2024-05-01T09:00:00Z",
+	"geometry": {"type": "Point", "coordinates": [[18.0686, 59.3293]]},
+	"timeSeries": [
+		{
+			"validTime": "2024-05-01T11:00:00Z",
+			"parameters": [
+				{"name": "t", "levelType": "hl", "level": 2, "unit": "Cel", "values": [12.5]},
+				{"name": "ws", "levelType": "hl", "level": 10, "unit": "m/s", "values": [4.2]},
+				{"name": "wd", "levelType": "hl", "level": 10, "unit": "degree", "values": [270]},
+				{"name": "Wsymb2", "levelType": "hl", "level": 0, "unit": "category", "values": [3]}
+			]
+		},
+		{
+			"validTime": "2024-05-01T12:00:00Z",
+			"parameters": [
+				{"name": "t", "levelType": "hl", "level": 2, "unit": "Cel", "values": [13.1]},
+				{"name": "ws", "levelType": "hl", "level": 10, "unit": "m/s", "values": [5]},
+				{"name": "wd", "levelType": "hl", "level": 10, "unit": "degree", "values": [265]},
+				{"name": "Wsymb2", "levelType": "hl", "level": 0, "unit": "category", "values": [6]}
+			]
+		}
+	]
+}`
+
+// roundTripFunc is an http.RoundTripper that calls the function.
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+// RoundTrip calls the function.
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// jsonResponse returns a 200 OK response of the request with the body.
+func jsonResponse(req *http.Request, body string) *http.Response {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Status:     "200 OK",
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+		Request:    req,
+	}
+}
+
+// countingMetrics counts the requests that it's notified of.
+type countingMetrics struct {
+	started int64
+	done    int64
+}
+
+func (m *countingMetrics) OnRequestStart(method, url string) {
+	atomic.AddInt64(&m.started, 1)
+}
+
+func (m *countingMetrics) OnRequestDone(r RequestMetrics) {
+	atomic.AddInt64(&m.done, 1)
+}
+
+func TestClientConcurrentUse(t *testing.T) {
+	var requests int64
+	rt := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		atomic.AddInt64(&requests, 1)
+		return jsonResponse(req, testForecastJSON), nil
+	})
+
+	metrics := &countingMetrics{}
+	c := NewClient(
+		WithTransport(rt),
+		WithMaxConnsPerHost(4),
+		WithRetry(DefaultRetryPolicy),
+		WithCache(&Cache{}),
+		WithConditionalRequests(),
+		WithRateLimit(10000, 50),
+		WithCircuitBreaker(5, time.Second),
+		WithMetrics(metrics),
+		WithJournal(NewJournal(ioutil.Discard)),
+	)
+
+	const goroutines = 50
+	var wg sync.WaitGroup
+	errs := make(chan error, goroutines)
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			// Two goroutines share each coordinate, so that the cache is
+			// read and written concurrently, and every fifth one uses a
+			// copy of the client.
+			cc := c
+			if i%5 == 0 {
+				cc = c.With(WithForceRefresh())
+			}
+			if _, err := cc.GetPointForecastContext(context.Background(), 18+float64(i%25)/100, 59.3); err != nil {
+				errs <- err
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+	if n := atomic.LoadInt64(&requests); n == 0 || n > goroutines {
+		t.Errorf("got %d requests, want between 1 and %d", n, goroutines)
+	}
+	if started, done := atomic.LoadInt64(&metrics.started), atomic.LoadInt64(&metrics.done); started != done {
+		t.Errorf("got %d started and %d done requests", started, done)
+	}
+}
+
+func TestTransportTuning(t *testing.T) {
+	custom := &http.Transport{TLSClientConfig: &tls.Config{ServerName: "custom"}}
+	other := &http.Transport{TLSClientConfig: &tls.Config{ServerName: "other"}}
+
+	tests := []struct {
+		name       string
+		opts       []Option
+		serverName string
+	}{
+		{"default transport", []Option{WithMaxConnsPerHost(4), WithIdleConnTimeout(time.Minute)}, ""},
+		{"http client after tuning", []Option{WithMaxConnsPerHost(4), WithIdleConnTimeout(time.Minute), WithHTTPClient(&http.Client{Transport: custom})}, "custom"},
+		{"http client before tuning", []Option{WithHTTPClient(&http.Client{Transport: custom}), WithMaxConnsPerHost(4), WithIdleConnTimeout(time.Minute)}, "custom"},
+		{"transport after tuning", []Option{WithMaxConnsPerHost(4), WithIdleConnTimeout(time.Minute), WithTransport(custom)}, "custom"},
+		{"transport over http client", []Option{WithTransport(custom), WithMaxConnsPerHost(4), WithHTTPClient(&http.Client{Transport: other}), WithIdleConnTimeout(time.Minute)}, "custom"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewClient(tt.opts...)
+
+			tr, ok := c.tuned.(*http.Transport)
+			if !ok {
+				t.Fatalf("got tuned transport %T, want *http.Transport", c.tuned)
+			}
+			if tr == custom || tr == http.DefaultTransport {
+				t.Error("the transport was tuned in place")
+			}
+			if tr.MaxConnsPerHost != 4 || tr.MaxIdleConnsPerHost != 4 || tr.IdleConnTimeout != time.Minute {
+				t.Errorf("got MaxConnsPerHost %d, MaxIdleConnsPerHost %d and IdleConnTimeout %s", tr.MaxConnsPerHost, tr.MaxIdleConnsPerHost, tr.IdleConnTimeout)
+			}
+			var serverName string
+			if tr.TLSClientConfig != nil {
+				serverName = tr.TLSClientConfig.ServerName
+			}
+			if serverName != tt.serverName {
+				t.Errorf("got server name %q, want %q", serverName, tt.serverName)
+			}
+		})
+	}
+
+	// The copies share the tuned transport unless they change it.
+	c := NewClient(WithMaxConnsPerHost(4))
+	if c.With(WithForceRefresh()).tuned != c.tuned {
+		t.Error("the copy got a transport of its own")
+	}
+	if tr := c.With(WithTransport(custom)).tuned.(*http.Transport); tr.TLSClientConfig.ServerName != "custom" || tr.MaxConnsPerHost != 4 {
+		t.Error("the copy didn't tune its transport")
+	}
+}
+
+func TestTransportTuningIgnoresOtherRoundTrippers(t *testing.T) {
+	var used bool
+	rt := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		used = true
+		return jsonResponse(req, testForecastJSON), nil
+	})
+
+	c := NewClient(WithMaxConnsPerHost(4), WithTransport(rt))
+	if c.tuned != nil {
+		t.Fatalf("got tuned transport %T, want none", c.tuned)
+	}
+	if _, err := c.GetPointForecast(18.0686, 59.3293); err != nil {
+		t.Fatal(err)
+	}
+	if !used {
+		t.Error("the transport wasn't used")
+	}
+}
+
+func TestGetPointForecastContext(t *testing.T) {
+	var path string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		w.Write([]byte(testForecastJSON))
+	}))
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL))
+	pf, err := c.GetPointForecastContext(context.Background(), 18.0686, 59.3293)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := "/api/category/pmp3g/version/2/geotype/point/lon/18.068600/lat/59.329300/data.json"; path != want {
+		t.Errorf("got path %s, want %s", path, want)
+	}
+	if len(pf.TimeSeries) != 2 {
+		t.Fatalf("got %d time steps, want 2", len(pf.TimeSeries))
+	}
+	if f := pf.TimeSeries[0]; f.AirTemperature != 12.5 || f.WindSpeed != 4.2 || f.WindDirection != 270 || f.WeatherSymbol != VariableCloudiness {
+		t.Errorf("got t %v, ws %v, wd %v and Wsymb2 %v", f.AirTemperature, f.WindSpeed, f.WindDirection, f.WeatherSymbol)
+	}
+}
+
+func TestGetPointForecastContextCanceled(t *testing.T) {
+	canceled := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+			close(canceled)
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	c := NewClient(WithBaseURL(srv.URL))
+	if _, err := c.GetPointForecastContext(ctx, 18.0686, 59.3293); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got error %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	select {
+	case <-canceled:
+	case <-time.After(time.Second):
+		t.Error("the request wasn't canceled")
+	}
+}
+
+func TestAPIError(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		kind    error
+		outside bool
+	}{
+		{"outside of the grid", http.StatusNotFound, ErrOutsideGrid, true},
+		{"bad request", http.StatusBadRequest, ErrOutsideGrid, true},
+		{"server error", http.StatusInternalServerError, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte("failed"))
+			}))
+			defer srv.Close()
+
+			_, err := NewClient(WithBaseURL(srv.URL)).GetPointForecast(18.0686, 59.3293)
+
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("got error %v, want an APIError", err)
+			}
+			if apiErr.StatusCode != tt.status || string(apiErr.Body) != "failed" || !strings.HasPrefix(apiErr.URL, srv.URL) {
+				t.Errorf("got status %d, body %q and URL %s", apiErr.StatusCode, apiErr.Body, apiErr.URL)
+			}
+			if apiErr.Err != tt.kind {
+				t.Errorf("got kind %v, want %v", apiErr.Err, tt.kind)
+			}
+			if errors.Is(err, ErrOutsideGrid) != tt.outside {
+				t.Errorf("errors.Is(err, ErrOutsideGrid) = %v, want %v", !tt.outside, tt.outside)
+			}
+		})
+	}
+}
+
+// gzipped returns the gzip encoding of the string.
+func gzipped(t *testing.T, s string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write([]byte(s)); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestGzip(t *testing.T) {
+	body := gzipped(t, testForecastJSON)
+
+	var acceptEncoding string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		acceptEncoding = r.Header.Get("Accept-Encoding")
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Write(body)
+	}))
+	defer srv.Close()
+
+	// The transport doesn't decompress the response, since the client asks
+	// for gzip itself, and neither does a transport that isn't an
+	// *http.Transport.
+	var compressed bool
+	rt := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		res, err := http.DefaultTransport.RoundTrip(req)
+		if err == nil {
+			compressed = !res.Uncompressed && res.Header.Get("Content-Encoding") == "gzip"
+		}
+		return res, err
+	})
+
+	pf, err := NewClient(WithBaseURL(srv.URL), WithTransport(rt)).GetPointForecast(18.0686, 59.3293)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if acceptEncoding != "gzip" {
+		t.Errorf("got Accept-Encoding %q, want gzip", acceptEncoding)
+	}
+	if !compressed {
+		t.Error("the transport decompressed the response")
+	}
+	if len(pf.TimeSeries) != 2 || pf.TimeSeries[0].AirTemperature != 12.5 {
+		t.Errorf("got %d time steps", len(pf.TimeSeries))
+	}
+}
+
+func TestDecodedBody(t *testing.T) {
+	tests := []struct {
+		name     string
+		encoding string
+		body     []byte
+		want     string
+	}{
+		{"identity", "", []byte(testForecastJSON), testForecastJSON},
+		{"gzip", "gzip", gzipped(t, testForecastJSON), testForecastJSON},
+		{"upper case gzip", "GZIP", gzipped(t, testForecastJSON), testForecastJSON},
+		{"empty gzip", "gzip", nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := &http.Response{
+				Header: http.Header{"Content-Length": {strconv.Itoa(len(tt.body))}},
+				Body:   ioutil.NopCloser(bytes.NewReader(tt.body)),
+			}
+			if tt.encoding != "" {
+				res.Header.Set("Content-Encoding", tt.encoding)
+			}
+
+			body, err := decodedBody(res)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer body.Close()
+
+			data, err := ioutil.ReadAll(body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("got body %q, want %q", data, tt.want)
+			}
+			if tt.encoding != "" && (res.Header.Get("Content-Encoding") != "" || res.Header.Get("Content-Length") != "") {
+				t.Errorf("got Content-Encoding %q and Content-Length %q, want neither", res.Header.Get("Content-Encoding"), res.Header.Get("Content-Length"))
+			}
+		})
+	}
+
+	res := &http.Response{
+		Header: http.Header{"Content-Encoding": {"gzip"}},
+		Body:   ioutil.NopCloser(strings.NewReader("not gzip")),
+	}
+	if _, err := decodedBody(res); err == nil {
+		t.Error("got no error of a body that isn't gzip")
+	}
+}
@@ -0,0 +1,246 @@
+package smhi
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidClimateData is returned when a climate scenario file doesn't
+// have a year column or any value columns.
+var ErrInvalidClimateData = errors.New("smhi: invalid climate scenario data")
+
+// ErrNoClimateRegion is returned when no climate region matches a name or
+// a code.
+var ErrNoClimateRegion = errors.New("smhi: no climate region found")
+
+// ClimateScenario is an emission scenario of the climate projections.
+type ClimateScenario string
+
+// ClimateScenario constants.
+const (
+	RCP26 ClimateScenario = "RCP2.6"
+	RCP45 ClimateScenario = "RCP4.5"
+	RCP85 ClimateScenario = "RCP8.5"
+)
+
+// ClimateVariable is a variable of the climate projections.
+type ClimateVariable string
+
+// ClimateVariable constants.
+const (
+	ClimateTemperature   ClimateVariable = "temperature"
+	ClimatePrecipitation ClimateVariable = "precipitation"
+)
+
+// ClimateRegion is a county of Sweden, which is the region of the climate
+// scenarios. Lon and Lat is a point near the middle of the county.
+type ClimateRegion struct {
+	Code string
+	Name string
+	Lon  float64
+	Lat  float64
+}
+
+// ClimateRegions holds the counties of Sweden.
+var ClimateRegions = []ClimateRegion{
+	{"01", "Stockholms län", 18.2, 59.4},
+	{"03", "Uppsala län", 17.6, 60.1},
+	{"04", "Södermanlands län", 16.6, 59.1},
+	{"05", "Östergötlands län", 15.6, 58.3},
+	{"06", "Jönköpings län", 14.4, 57.5},
+	{"07", "Kronobergs län", 14.6, 56.8},
+	{"08", "Kalmar län", 16.2, 57.2},
+	{"09", "Gotlands län", 18.5, 57.5},
+	{"10", "Blekinge län", 15.2, 56.3},
+	{"12", "Skåne län", 13.6, 55.9},
+	{"13", "Hallands län", 12.8, 56.9},
+	{"14", "Västra Götalands län", 12.4, 58.2},
+	{"17", "Värmlands län", 13.2, 59.8},
+	{"18", "Örebro län", 15.0, 59.3},
+	{"19", "Västmanlands län", 16.2, 59.7},
+	{"20", "Dalarnas län", 14.7, 61.0},
+	{"21", "Gävleborgs län", 16.2, 61.4},
+	{"22", "Västernorrlands län", 17.4, 63.0},
+	{"23", "Jämtlands län", 14.2, 63.2},
+	{"24", "Västerbottens län", 18.0, 64.9},
+	{"25", "Norrbottens län", 20.5, 67.0},
+}
+
+// LookupClimateRegion returns the climate region of the given code or
+// name, the name is matched case insensitively and with or without "län".
+func LookupClimateRegion(s string) (*ClimateRegion, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	for i := range ClimateRegions {
+		r := &ClimateRegions[i]
+		name := strings.ToLower(r.Name)
+		if s == r.Code || s == name || s == strings.TrimSuffix(name, " län") {
+			return r, nil
+		}
+	}
+	return nil, ErrNoClimateRegion
+}
+
+// NearestClimateRegion returns the climate region whose middle is nearest
+// to the given longitude and latitude. It's a rough lookup that may pick a
+// neighbouring county near the borders.
+func NearestClimateRegion(lon, lat float64) *ClimateRegion {
+	ret := &ClimateRegions[0]
+	for i := range ClimateRegions[1:] {
+		r := &ClimateRegions[i+1]
+		if distance(lon, lat, r.Lon, r.Lat) < distance(lon, lat, ret.Lon, ret.Lat) {
+			ret = r
+		}
+	}
+	return ret
+}
+
+// ClimateProjectionValue is the projected change of a year. Median is the
+// median of the models, and Min and Max are the lowest and the highest of
+// them. Min and Max equal Median if the file only has one value column.
+type ClimateProjectionValue struct {
+	Year   int
+	Min    float64
+	Median float64
+	Max    float64
+}
+
+// ClimateProjection is a series of projected changes of a variable in a
+// region for a scenario.
+type ClimateProjection struct {
+	Region   *ClimateRegion
+	Scenario ClimateScenario
+	Variable ClimateVariable
+	Values   []ClimateProjectionValue
+}
+
+// ParseClimateProjection parses a series that is exported as CSV from the
+// climate scenario service of SMHI. The columns are found by their
+// headers, a year column ("år" or "year") and a median, a mean or a value
+// column along with optional min and max columns. Both commas and
+// semicolons are accepted as separators, and decimal commas are accepted
+// when the separator is a semicolon.
+func ParseClimateProjection(r io.Reader) ([]ClimateProjectionValue, error) {
+	var err error
+
+	var data []byte
+	if data, err = ioutil.ReadAll(r); err != nil {
+		return nil, err
+	}
+
+	// The separator is the one that is used in the header.
+	header := data
+	if i := bytes.IndexByte(data, '\n'); i >= 0 {
+		header = data[:i]
+	}
+	comma := ','
+	if bytes.IndexByte(header, ';') >= 0 {
+		comma = ';'
+	}
+
+	cr := csv.NewReader(bytes.NewReader(data))
+	cr.Comma = comma
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	var records [][]string
+	if records, err = cr.ReadAll(); err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, ErrInvalidClimateData
+	}
+
+	year, min, median, max := -1, -1, -1, -1
+	for i, h := range records[0] {
+		switch strings.ToLower(strings.TrimSpace(h)) {
+		case "år", "year":
+			year = i
+			break
+		case "min", "minimum":
+			min = i
+			break
+		case "median", "medel", "mean", "value", "värde":
+			median = i
+			break
+		case "max", "maximum":
+			max = i
+			break
+		}
+	}
+	if year < 0 || median < 0 {
+		return nil, ErrInvalidClimateData
+	}
+
+	var ret []ClimateProjectionValue
+	for _, rec := range records[1:] {
+		if len(rec) <= year || strings.TrimSpace(rec[year]) == "" {
+			continue
+		}
+
+		var v ClimateProjectionValue
+		if v.Year, err = strconv.Atoi(strings.TrimSpace(rec[year])); err != nil {
+			return nil, err
+		}
+		if v.Median, err = parseClimateValue(rec, median, comma); err != nil {
+			return nil, err
+		}
+		v.Min, v.Max = v.Median, v.Median
+		if min >= 0 {
+			if v.Min, err = parseClimateValue(rec, min, comma); err != nil {
+				return nil, err
+			}
+		}
+		if max >= 0 {
+			if v.Max, err = parseClimateValue(rec, max, comma); err != nil {
+				return nil, err
+			}
+		}
+		ret = append(ret, v)
+	}
+
+	return ret, nil
+}
+
+// parseClimateValue parses the value of the column of the record.
+func parseClimateValue(rec []string, i int, comma rune) (float64, error) {
+	if i >= len(rec) {
+		return 0, ErrInvalidClimateData
+	}
+
+	s := strings.TrimSpace(rec[i])
+	if comma == ';' {
+		s = strings.Replace(s, ",", ".", 1)
+	}
+	return strconv.ParseFloat(s, 64)
+}
+
+// GetClimateProjection downloads and parses a series of the climate
+// scenario service from the given URL using the default client.
+func GetClimateProjection(url string, region *ClimateRegion, scenario ClimateScenario, variable ClimateVariable) (*ClimateProjection, error) {
+	return defaultClient.GetClimateProjection(url, region, scenario, variable)
+}
+
+// GetClimateProjection downloads and parses a series of the climate
+// scenario service from the given URL. The service has no stable API, so
+// the URL is the one of the exported file, and the region, the scenario
+// and the variable are the ones that it was exported for.
+func (c *Client) GetClimateProjection(url string, region *ClimateRegion, scenario ClimateScenario, variable ClimateVariable) (*ClimateProjection, error) {
+	var err error
+
+	var data []byte
+	if data, err = c.get(url); err != nil {
+		return nil, err
+	}
+
+	ret := ClimateProjection{Region: region, Scenario: scenario, Variable: variable}
+	if ret.Values, err = ParseClimateProjection(bytes.NewReader(data)); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
@@ -0,0 +1,108 @@
+package smhi
+
+import (
+	"time"
+)
+
+// ClothingRule gives its advice for the periods that it matches.
+type ClothingRule struct {
+	Match  func(s *Summary) bool
+	Advice map[string]string
+}
+
+// DefaultClothingRules holds the rules that are used when no rules are
+// given to ClothingAdvice.
+var DefaultClothingRules = []ClothingRule{
+	{
+		Match: func(s *Summary) bool {
+			return s.MinApparentTemperature < -10
+		},
+		Advice: map[string]string{
+			"sv-SE": "Varma vinterkläder, mössa och vantar rekommenderas.",
+			"en-US": "Warm winter clothing, hat and gloves recommended.",
+		},
+	},
+	{
+		Match: func(s *Summary) bool {
+			return s.MinApparentTemperature >= -10 && s.MinApparentTemperature < 5
+		},
+		Advice: map[string]string{
+			"sv-SE": "Vinterjacka rekommenderas.",
+			"en-US": "Winter jacket recommended.",
+		},
+	},
+	{
+		Match: func(s *Summary) bool {
+			return s.MinApparentTemperature >= 5 && s.MinApparentTemperature < 15
+		},
+		Advice: map[string]string{
+			"sv-SE": "Tunn jacka eller tröja rekommenderas.",
+			"en-US": "Light jacket or sweater recommended.",
+		},
+	},
+	{
+		Match: func(s *Summary) bool {
+			return s.MaxApparentTemperature >= 25
+		},
+		Advice: map[string]string{
+			"sv-SE": "Lätta kläder rekommenderas, glöm inte att dricka vatten.",
+			"en-US": "Light clothing recommended, remember to drink water.",
+		},
+	},
+	{
+		Match: func(s *Summary) bool {
+			return s.Precipitation >= 0.5 && s.PrecipitationCategory != Snow
+		},
+		Advice: map[string]string{
+			"sv-SE": "Regnjacka och vattentäta skor rekommenderas.",
+			"en-US": "Rain jacket and waterproof shoes recommended.",
+		},
+	},
+	{
+		Match: func(s *Summary) bool {
+			return s.Precipitation >= 0.5 && (s.PrecipitationCategory == Snow || s.PrecipitationCategory == SnowAndRain)
+		},
+		Advice: map[string]string{
+			"sv-SE": "Vinterkängor rekommenderas.",
+			"en-US": "Winter boots recommended.",
+		},
+	},
+	{
+		Match: func(s *Summary) bool {
+			return s.MaxWindGustSpeed >= 14
+		},
+		Advice: map[string]string{
+			"sv-SE": "Blåsigt, vindtäta kläder rekommenderas.",
+			"en-US": "Windy, windproof clothing recommended.",
+		},
+	},
+	{
+		Match: func(s *Summary) bool {
+			return s.MaxThunderProbability >= 30
+		},
+		Advice: map[string]string{
+			"sv-SE": "Risk för åska, undvik aktiviteter i det fria.",
+			"en-US": "Risk of thunder, avoid outdoor activities.",
+		},
+	},
+}
+
+// ClothingAdvice returns the advice of each rule that matches the summary
+// of the period from and to, DefaultClothingRules are used if rules is
+// nil.
+func (pf *PointForecast) ClothingAdvice(from, to time.Time, rules []ClothingRule) []map[string]string {
+	if rules == nil {
+		rules = DefaultClothingRules
+	}
+
+	s := pf.Summarize(from, to)
+
+	var ret []map[string]string
+	for _, r := range rules {
+		if r.Match(&s) {
+			ret = append(ret, r.Advice)
+		}
+	}
+
+	return ret
+}
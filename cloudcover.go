@@ -0,0 +1,41 @@
+package smhi
+
+// OctasToPercent converts a cloud cover in octas (0-8) to percent.
+func OctasToPercent(octas uint8) float64 {
+	if octas > 8 {
+		octas = 8
+	}
+	return float64(octas) * 100 / 8
+}
+
+// CloudCoverDescription returns a friendly name for the cloud cover in
+// octas, clear is 0, few is 1-2, scattered is 3-4, broken is 5-7 and
+// overcast is 8.
+func CloudCoverDescription(octas uint8) map[string]string {
+	ret := make(map[string]string)
+
+	switch {
+	case octas == 0:
+		ret["sv-SE"] = "Klart"
+		ret["en-US"] = "Clear"
+		break
+	case octas <= 2:
+		ret["sv-SE"] = "Nästan klart"
+		ret["en-US"] = "Few clouds"
+		break
+	case octas <= 4:
+		ret["sv-SE"] = "Halvklart"
+		ret["en-US"] = "Scattered clouds"
+		break
+	case octas <= 7:
+		ret["sv-SE"] = "Molnigt"
+		ret["en-US"] = "Broken clouds"
+		break
+	default:
+		ret["sv-SE"] = "Mulet"
+		ret["en-US"] = "Overcast"
+		break
+	}
+
+	return ret
+}
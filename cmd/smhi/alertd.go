@@ -0,0 +1,198 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/smtp"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/osm/smhi"
+	"github.com/osm/smhi/events"
+)
+
+// alertConfig is the configuration file of alertd, the places are given
+// in the same way as in the configuration file of the CLI.
+type alertConfig struct {
+	config
+
+	// Interval is the time between the checks and Cooldown the least
+	// time between two alerts of a place and a rule, such as "15m".
+	Interval string `json:"interval"`
+	Cooldown string `json:"cooldown"`
+
+	// Rules are the thresholds of the parameters, such as
+	// {"param": "ws", "op": ">", "value": 15, "window": "12h"}.
+	Rules []struct {
+		Param  string  `json:"param"`
+		Op     string  `json:"op"`
+		Value  float64 `json:"value"`
+		Window string  `json:"window"`
+	} `json:"rules"`
+
+	// Warnings raises an alert for the warnings of the places.
+	Warnings bool `json:"warnings"`
+
+	// Notifiers are the destinations of the alerts, the type is one of
+	// webhook, slack, email and mqtt.
+	Notifiers []struct {
+		Type     string   `json:"type"`
+		URL      string   `json:"url"`
+		Addr     string   `json:"addr"`
+		From     string   `json:"from"`
+		To       []string `json:"to"`
+		Topic    string   `json:"topic"`
+		ClientID string   `json:"client_id"`
+		Username string   `json:"username"`
+		Password string   `json:"password"`
+	} `json:"notifiers"`
+}
+
+// alertd watches the places of the configuration file and dispatches the
+// alerts of the rules and the warnings to the notifiers, until it's
+// killed.
+func alertd(args []string) error {
+	fs := newFlagSet("alertd")
+	path := fs.String("config", "alerts.json", tr("configuration file of the alerts"))
+	fs.Parse(args)
+
+	if ext := strings.ToLower(filepath.Ext(*path)); ext == ".yaml" || ext == ".yml" {
+		return errors.New(tr("yaml isn't supported, it needs a parser outside of the standard library"))
+	}
+
+	var err error
+
+	var data []byte
+	if data, err = ioutil.ReadFile(*path); err != nil {
+		return err
+	}
+	var cfg alertConfig
+	if err = json.Unmarshal(data, &cfg); err != nil {
+		return err
+	}
+
+	var places []place
+	if places, err = cfg.places(); err != nil {
+		return err
+	}
+	if len(places) == 0 {
+		return errors.New(tr("alertd needs at least one place"))
+	}
+
+	interval := events.DefaultInterval
+	if cfg.Interval != "" {
+		if interval, err = time.ParseDuration(cfg.Interval); err != nil {
+			return err
+		}
+	}
+	d := &events.Dispatcher{}
+	if cfg.Cooldown != "" {
+		if d.Cooldown, err = time.ParseDuration(cfg.Cooldown); err != nil {
+			return err
+		}
+	}
+
+	for _, n := range cfg.Notifiers {
+		switch n.Type {
+		case "webhook":
+			d.Notifiers = append(d.Notifiers, &events.Webhook{URL: n.URL})
+			break
+		case "slack":
+			d.Notifiers = append(d.Notifiers, &events.Slack{WebhookURL: n.URL})
+			break
+		case "email":
+			e := &events.Email{Addr: n.Addr, From: n.From, To: n.To}
+			if n.Username != "" {
+				e.Auth = smtp.PlainAuth("", n.Username, n.Password, strings.Split(n.Addr, ":")[0])
+			}
+			d.Notifiers = append(d.Notifiers, e)
+			break
+		case "mqtt":
+			d.Notifiers = append(d.Notifiers, &events.MQTT{Broker: n.Addr, Topic: n.Topic, ClientID: n.ClientID, Username: n.Username, Password: n.Password})
+			break
+		default:
+			return fmt.Errorf(tr("unknown notifier %q"), n.Type)
+		}
+	}
+	if len(d.Notifiers) == 0 {
+		return errors.New(tr("alertd needs at least one notifier"))
+	}
+
+	var rules []events.Rule
+	for _, r := range cfg.Rules {
+		rule := events.Rule{Param: r.Param, Op: r.Op, Value: r.Value}
+		if r.Window != "" {
+			if rule.Window, err = time.ParseDuration(r.Window); err != nil {
+				return err
+			}
+		}
+		rules = append(rules, rule)
+	}
+
+	ctx := context.Background()
+	c := smhi.NewClient()
+	w := &events.Watcher{Client: c, Interval: interval, OnError: func(err error) { log.Println(err) }}
+	loc, _ := time.LoadLocation("Europe/Stockholm")
+
+	// Each place and rule is watched on its own, and the alerts are
+	// deduplicated by the dispatcher.
+	var wg sync.WaitGroup
+	for _, p := range places {
+		for _, r := range rules {
+			var ch <-chan events.Event
+			if ch, err = w.Subscribe(ctx, events.Location{Lon: p.lon, Lat: p.lat}, r); err != nil {
+				return err
+			}
+
+			wg.Add(1)
+			go func(p place, r events.Rule, ch <-chan events.Event) {
+				defer wg.Done()
+				for e := range ch {
+					a := events.Alert{
+						Key:     p.name + "|" + r.String(),
+						Title:   fmt.Sprintf("SMHI: %s", p.name),
+						Message: fmt.Sprintf("%s %s", r, e.Forecast.Timestamp.In(loc).Format("2006-01-02 15:04")),
+						Time:    e.Time,
+					}
+					if err := d.Notify(ctx, a); err != nil {
+						log.Println(err)
+					}
+				}
+			}(p, r, ch)
+		}
+
+		if cfg.Warnings {
+			wg.Add(1)
+			go func(p place) {
+				defer wg.Done()
+				for {
+					warnings, err := c.GetWarningsAt(p.lon, p.lat)
+					if err != nil {
+						log.Println(err)
+					}
+					for _, wn := range warnings {
+						a := events.Alert{
+							Key:     fmt.Sprintf("%s|warning|%d", p.name, wn.ID),
+							Title:   fmt.Sprintf("SMHI: %s", p.name),
+							Message: fmt.Sprintf("%s: %s, %s", wn.Level, wn.Event[descriptionLocale()], wn.Area[descriptionLocale()]),
+							Time:    time.Now(),
+						}
+						if err = d.Notify(ctx, a); err != nil {
+							log.Println(err)
+						}
+					}
+					time.Sleep(interval)
+				}
+			}(p)
+		}
+	}
+	wg.Wait()
+
+	return nil
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/osm/smhi"
+)
+
+// batch fetches the forecasts of the points in a CSV file of id, lon and
+// lat and writes one file per point to the output directory.
+func batch(args []string) error {
+	fs := newFlagSet("batch")
+	in := fs.String("in", "", tr("CSV file of id,lon,lat, stdin if empty"))
+	out := fs.String("out", ".", tr("output directory"))
+	format := fs.String("format", "csv", tr("output format, csv or geojson"))
+	concurrency := fs.Int("concurrency", 4, tr("number of concurrent requests"))
+	interval := fs.Duration("interval", 100*time.Millisecond, tr("least time between two requests"))
+	fs.Parse(args)
+
+	if *format != "csv" && *format != "geojson" {
+		return fmt.Errorf(tr("unknown format %q"), *format)
+	}
+
+	var err error
+
+	var r io.Reader = os.Stdin
+	if *in != "" {
+		var f *os.File
+		if f, err = os.Open(*in); err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	var points []smhi.BatchPoint
+	if points, err = readPoints(r); err != nil {
+		return err
+	}
+	if err = os.MkdirAll(*out, 0755); err != nil {
+		return err
+	}
+
+	var mu sync.Mutex
+	var failed int
+	b := &smhi.Batch{Concurrency: *concurrency, Interval: *interval}
+	err = b.Fetch(context.Background(), points, func(res smhi.BatchResult) {
+		if res.Err == nil {
+			res.Err = writeForecast(filepath.Join(*out, res.Point.ID+"."+*format), *format, res.Forecast)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		if res.Err != nil {
+			failed++
+			fmt.Fprintf(os.Stderr, "%s: %v\n", res.Point.ID, res.Err)
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return fmt.Errorf(tr("%d of %d points failed"), failed, len(points))
+	}
+	return nil
+}
+
+// readPoints reads the points from CSV lines of id, lon and lat, a header
+// line is skipped.
+func readPoints(r io.Reader) ([]smhi.BatchPoint, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = 3
+	cr.TrimLeadingSpace = true
+
+	var ret []smhi.BatchPoint
+	for line := 1; ; line++ {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		lon, err1 := strconv.ParseFloat(rec[1], 64)
+		lat, err2 := strconv.ParseFloat(rec[2], 64)
+		if err1 != nil || err2 != nil {
+			if line == 1 {
+				continue
+			}
+			return nil, fmt.Errorf(tr("line %d: invalid coordinate"), line)
+		}
+		if rec[0] == "" || strings.ContainsAny(rec[0], `/\`) {
+			return nil, fmt.Errorf(tr("line %d: invalid id %q"), line, rec[0])
+		}
+
+		ret = append(ret, smhi.BatchPoint{ID: rec[0], Lon: lon, Lat: lat})
+	}
+	if len(ret) == 0 {
+		return nil, errors.New(tr("no points"))
+	}
+
+	return ret, nil
+}
+
+// writeForecast writes the forecast to the file in the given format.
+func writeForecast(path, format string, f *smhi.PointForecast) error {
+	var err error
+
+	var file *os.File
+	if file, err = os.Create(path); err != nil {
+		return err
+	}
+
+	if format == "geojson" {
+		err = f.WriteGeoJSON(file)
+	} else {
+		err = f.WriteCSV(file)
+	}
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/osm/smhi"
+)
+
+// cache lists, summarizes or clears the archive.
+func cache(args []string) error {
+	fs := newFlagSet("cache")
+	dir := fs.String("archive", defaultArchiveDir(), tr("archive directory"))
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), tr("Usage: smhi cache [flags] ls|clear|stats"))
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return errors.New(tr("cache needs one of ls, clear or stats"))
+	}
+
+	var err error
+
+	var a *smhi.Archive
+	if a, err = smhi.OpenArchive(*dir); err != nil {
+		return err
+	}
+
+	if fs.Arg(0) == "clear" {
+		return a.Clear()
+	}
+
+	var entries []smhi.ArchiveEntry
+	if entries, err = a.Entries(); err != nil {
+		return err
+	}
+
+	switch fs.Arg(0) {
+	case "ls":
+		for _, e := range entries {
+			fmt.Printf("%10s %8s %s\n", formatSize(e.Size), formatAge(e.ModTime), e.Path)
+		}
+		break
+	case "stats":
+		var size int64
+		var oldest, newest time.Time
+		for _, e := range entries {
+			size += e.Size
+			if oldest.IsZero() || e.ModTime.Before(oldest) {
+				oldest = e.ModTime
+			}
+			if e.ModTime.After(newest) {
+				newest = e.ModTime
+			}
+		}
+
+		fmt.Printf("%-10s %s\n", tr("directory:"), *dir)
+		fmt.Printf("%-10s %d\n", tr("entries:"), len(entries))
+		fmt.Printf("%-10s %s\n", tr("size:"), formatSize(size))
+		if len(entries) > 0 {
+			fmt.Printf("%-10s %s\n", tr("oldest:"), formatAge(oldest))
+			fmt.Printf("%-10s %s\n", tr("newest:"), formatAge(newest))
+		}
+		break
+	default:
+		fs.Usage()
+		return fmt.Errorf(tr("unknown cache command %q"), fs.Arg(0))
+	}
+
+	return nil
+}
+
+// formatSize returns the size in a human readable form.
+func formatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
+
+// formatAge returns the time since t in a human readable form.
+func formatAge(t time.Time) string {
+	d := time.Since(t)
+	if d < time.Hour {
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	} else if d < 48*time.Hour {
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	}
+	return fmt.Sprintf("%dd", int(d.Hours()/24))
+}
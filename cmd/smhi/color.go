@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/osm/smhi"
+)
+
+// ANSI color codes.
+const (
+	colorRed    = "31"
+	colorGreen  = "32"
+	colorYellow = "33"
+	colorBlue   = "34"
+	colorCyan   = "36"
+)
+
+// temperatureColors holds the colors of the temperature gradient, the
+// first color is used below the first threshold and so on.
+var temperatureColors = []string{colorBlue, colorCyan, colorGreen, colorYellow, colorRed}
+
+// colorizer colors the output if it's enabled. The temperatures are
+// colored by the thresholds in °C, and the precipitation from the
+// threshold in mm/h.
+type colorizer struct {
+	enabled       bool
+	temperatures  []float64
+	precipitation float64
+}
+
+// newColorizer returns a colorizer for the color mode auto, always or
+// never, auto colors the output if stdout is a terminal.
+func newColorizer(mode, temperatures string, precipitation float64) (*colorizer, error) {
+	c := &colorizer{precipitation: precipitation}
+
+	switch mode {
+	case "always":
+		c.enabled = true
+		break
+	case "never":
+		c.enabled = false
+		break
+	case "auto":
+		fi, err := os.Stdout.Stat()
+		c.enabled = err == nil && fi.Mode()&os.ModeCharDevice != 0 &&
+			os.Getenv("NO_COLOR") == "" && os.Getenv("TERM") != "dumb"
+		break
+	default:
+		return nil, fmt.Errorf(tr("unknown color mode %q"), mode)
+	}
+
+	for _, s := range strings.Split(temperatures, ",") {
+		t, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+		if err != nil {
+			return nil, fmt.Errorf(tr("invalid temperature threshold %q"), s)
+		}
+		c.temperatures = append(c.temperatures, t)
+	}
+	if len(c.temperatures) != len(temperatureColors)-1 {
+		return nil, fmt.Errorf(tr("%d temperature thresholds are needed"), len(temperatureColors)-1)
+	}
+
+	return c, nil
+}
+
+// paint returns s in the given color.
+func (c *colorizer) paint(color, s string) string {
+	if !c.enabled {
+		return s
+	}
+	return "\x1b[" + color + "m" + s + "\x1b[0m"
+}
+
+// temperature returns s in the color of the temperature.
+func (c *colorizer) temperature(t float64, s string) string {
+	i := 0
+	for i < len(c.temperatures) && t >= c.temperatures[i] {
+		i++
+	}
+	return c.paint(temperatureColors[i], s)
+}
+
+// rain returns s in blue if the precipitation reaches the
+// threshold.
+func (c *colorizer) rain(p float64, s string) string {
+	if p < c.precipitation {
+		return s
+	}
+	return c.paint(colorBlue, s)
+}
+
+// fireRisk returns s in green for the low fire risk levels, yellow for
+// moderate and red from high.
+func (c *colorizer) fireRisk(l smhi.FireRiskLevel, s string) string {
+	switch {
+	case l >= smhi.HighFireRisk:
+		return c.paint(colorRed, s)
+	case l == smhi.ModerateFireRisk:
+		return c.paint(colorYellow, s)
+	case l >= smhi.VeryLowFireRisk:
+		return c.paint(colorGreen, s)
+	}
+	return s
+}
+
+// warning returns s in red.
+func (c *colorizer) warning(s string) string {
+	return c.paint(colorRed, s)
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/osm/smhi"
+)
+
+// compare prints the forecasts of two or more places side by side, with
+// one row per time step.
+func compare(args []string) error {
+	var places placeFlags
+	fs := newFlagSet("compare")
+	fs.Var(&places, "place", tr("place name or lon,lat, may be repeated"))
+	fs.Parse(args)
+
+	if len(places) < 2 {
+		return errors.New(tr("compare needs at least two places"))
+	}
+
+	// Fetch the forecasts and index the time steps by their timestamps.
+	steps := make([]map[time.Time]smhi.Forecast, len(places))
+	var timestamps []time.Time
+	for i, p := range places {
+		f, err := smhi.GetPointForecast(p.lon, p.lat)
+		if err != nil {
+			return fmt.Errorf("%s: %w", p.name, err)
+		}
+
+		steps[i] = make(map[time.Time]smhi.Forecast)
+		for _, t := range f.TimeSeries {
+			steps[i][t.Timestamp] = t
+		}
+		if i == 0 {
+			for _, t := range f.TimeSeries {
+				timestamps = append(timestamps, t.Timestamp)
+			}
+		}
+	}
+
+	loc, _ := time.LoadLocation("Europe/Stockholm")
+
+	header := []string{fmt.Sprintf("%-16s", "")}
+	for _, p := range places {
+		header = append(header, fmt.Sprintf("%-18s", p.name))
+	}
+	fmt.Println(strings.TrimSpace(strings.Join(header, " ")))
+
+	for _, ts := range timestamps {
+		row := []string{ts.In(loc).Format("2006-01-02 15:04")}
+		for i := range places {
+			t, ok := steps[i][ts]
+			if !ok {
+				row = append(row, fmt.Sprintf("%-18s", "-"))
+				continue
+			}
+			row = append(row, fmt.Sprintf("%5.1f C %4.1f m/s  ", t.AirTemperature, t.WindSpeed))
+		}
+		fmt.Println(strings.TrimSpace(strings.Join(row, " ")))
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// config is the configuration file of the CLI.
+type config struct {
+	// Places are the places of the location switcher, the coordinates
+	// can be left out for the places that are known by name.
+	Places []struct {
+		Name string  `json:"name"`
+		Lon  float64 `json:"lon"`
+		Lat  float64 `json:"lat"`
+	} `json:"places"`
+}
+
+// defaultConfigPath returns the path of the configuration file that the
+// CLI uses unless another one is given.
+func defaultConfigPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "smhi.json"
+	}
+	return filepath.Join(dir, "smhi", "config.json")
+}
+
+// loadConfig loads the configuration file, an empty configuration is
+// returned if the file doesn't exist.
+func loadConfig(path string) (*config, error) {
+	var err error
+
+	var data []byte
+	if data, err = ioutil.ReadFile(path); os.IsNotExist(err) {
+		return &config{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var c config
+	if err = json.Unmarshal(data, &c); err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+// places returns the places of the configuration.
+func (c *config) places() ([]place, error) {
+	var ret []place
+	for _, p := range c.Places {
+		if p.Lon == 0 && p.Lat == 0 {
+			known, err := lookupPlace(p.Name)
+			if err != nil {
+				return nil, err
+			}
+			ret = append(ret, known)
+			continue
+		}
+		ret = append(ret, place{p.Name, p.Lon, p.Lat})
+	}
+	return ret, nil
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/osm/smhi"
+)
+
+// defaultArchiveDir returns the directory of the archive that the CLI uses
+// unless another one is given.
+func defaultArchiveDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "smhi")
+}
+
+// diff fetches the latest forecast run, stores it in the archive and prints
+// what changed since the previous run in the archive.
+func diff(args []string) error {
+	fs := newFlagSet("diff")
+	lon := fs.Float64("lon", 11.785, tr("longitude"))
+	lat := fs.Float64("lat", 57.634, tr("latitude"))
+	dir := fs.String("archive", defaultArchiveDir(), tr("archive directory"))
+	fs.Parse(args)
+
+	var err error
+
+	var a *smhi.Archive
+	if a, err = smhi.OpenArchive(*dir); err != nil {
+		return err
+	}
+
+	var f *smhi.PointForecast
+	if f, err = smhi.NewClient(smhi.WithArchive(a)).GetPointForecast(*lon, *lat); err != nil {
+		return err
+	}
+
+	// Find the latest run before the one that was just fetched, the runs
+	// are stored by the grid point that the forecast is given for.
+	c := f.Geometry.Coordinates[0]
+	var it *smhi.ArchiveIterator
+	if it, err = a.Query(c[0], c[1], time.Time{}, f.ApprovedTime.Add(-time.Second)); err != nil {
+		return err
+	}
+	defer it.Close()
+
+	var prev *smhi.PointForecast
+	for it.Next() {
+		var pf smhi.PointForecast
+		if err = it.Scan(&pf); err != nil {
+			return err
+		}
+		prev = &pf
+	}
+	if err = it.Err(); err != nil {
+		return err
+	}
+
+	loc, _ := time.LoadLocation("Europe/Stockholm")
+
+	if prev == nil {
+		fmt.Printf(tr("no previous run of %s in %s")+"\n", f.ApprovedTime.In(loc).Format("2006-01-02 15:04"), *dir)
+		return nil
+	}
+	fmt.Println(prev.ApprovedTime.In(loc).Format("2006-01-02 15:04"), "->", f.ApprovedTime.In(loc).Format("2006-01-02 15:04"))
+
+	changes := smhi.Diff(prev, f)
+	if len(changes) == 0 {
+		fmt.Println(tr("no changes"))
+		return nil
+	}
+	for _, ch := range changes {
+		fmt.Printf("%s %-8s %8.2f -> %8.2f\n", ch.Timestamp.In(loc).Format("2006-01-02 15:04"), ch.Parameter, ch.Old, ch.New)
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/osm/smhi"
+)
+
+// export writes the forecast, or the observations of a station, to a file
+// or to stdout.
+func export(args []string) error {
+	fs := newFlagSet("export")
+	lon := fs.Float64("lon", 11.785, tr("longitude"))
+	lat := fs.Float64("lat", 57.634, tr("latitude"))
+	format := fs.String("format", "csv", tr("output format, csv or geojson"))
+	out := fs.String("out", "", tr("output file, stdout if empty"))
+	parameter := fs.Int("parameter", 0, tr("export the observations of this parameter instead of the forecast"))
+	station := fs.Int("station", 0, tr("station of the observations"))
+	period := fs.String("period", string(smhi.PeriodLatestDay), tr("period of the observations"))
+	precision := fs.String("precision", "", tr("decimals of the parameters, such as t=0,msl=0, or none"))
+	fs.Parse(args)
+
+	if *format == "parquet" {
+		return errors.New(tr("parquet isn't supported, it needs an encoder outside of the standard library"))
+	}
+
+	var err error
+
+	var write func(w io.Writer) error
+	if *parameter != 0 {
+		if *format != "csv" {
+			return errors.New(tr("observations can only be exported as csv"))
+		}
+
+		var o *smhi.Observations
+		if o, err = smhi.GetObservations(*parameter, *station, smhi.ObservationPeriod(*period)); err != nil {
+			return err
+		}
+		write = o.WriteCSV
+	} else {
+		var opts []smhi.Option
+		if opts, err = precisionOptions(*precision); err != nil {
+			return err
+		}
+
+		var f *smhi.PointForecast
+		if f, err = smhi.NewClient(opts...).GetPointForecast(*lon, *lat); err != nil {
+			return err
+		}
+
+		switch *format {
+		case "csv":
+			write = f.WriteCSV
+			break
+		case "geojson":
+			write = f.WriteGeoJSON
+			break
+		default:
+			return fmt.Errorf(tr("unknown format %q"), *format)
+		}
+	}
+
+	if *out == "" {
+		return write(os.Stdout)
+	}
+
+	var file *os.File
+	if file, err = os.Create(*out); err != nil {
+		return err
+	}
+	if err = write(file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
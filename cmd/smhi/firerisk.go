@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/osm/smhi"
+)
+
+// firerisk prints the daily fire risk forecast of a location, with the
+// fire weather index and the grass fire risk colored by their levels.
+func firerisk(args []string) error {
+	fs := newFlagSet("firerisk")
+	lon := fs.Float64("lon", 11.785, tr("longitude"))
+	lat := fs.Float64("lat", 57.634, tr("latitude"))
+	color := fs.String("color", "auto", tr("color the output, auto, always or never"))
+	fs.Parse(args)
+
+	var err error
+
+	var c *colorizer
+	if c, err = newColorizer(*color, "0,10,20,25", 0.1); err != nil {
+		return err
+	}
+
+	var fr *smhi.FireRiskForecast
+	if fr, err = smhi.GetFireRisk(*lon, *lat); err != nil {
+		return err
+	}
+
+	loc, _ := time.LoadLocation("Europe/Stockholm")
+
+	for _, d := range fr.Days {
+		fmt.Printf("%s %s  FWI %5.1f  %s  %s: %s\n",
+			weekday(int(d.Date.In(loc).Weekday())),
+			d.Date.In(loc).Format("2006-01-02"),
+			d.FWI,
+			c.fireRisk(d.FWILevel, d.FWILevelDescription[descriptionLocale()]),
+			tr("grass fire"),
+			c.fireRisk(d.GrassFireLevel, d.GrassFireLevelDescription[descriptionLocale()]),
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/osm/smhi"
+)
+
+// grafanaQuery is the body of a query of the Grafana JSON data sources.
+type grafanaQuery struct {
+	Range struct {
+		From time.Time `json:"from"`
+		To   time.Time `json:"to"`
+	} `json:"range"`
+	Targets []struct {
+		Target string `json:"target"`
+	} `json:"targets"`
+}
+
+// grafanaSeries is a time series of a query response, the data points are
+// pairs of a value and a timestamp in milliseconds.
+type grafanaSeries struct {
+	Target     string       `json:"target"`
+	Datapoints [][2]float64 `json:"datapoints"`
+}
+
+// handleGrafana adds the endpoints of the SimpleJSON and Infinity data
+// sources of Grafana under /grafana. The targets are named by the place and
+// the SMHI parameter, such as "Göteborg:t".
+func handleGrafana(mux *http.ServeMux, c *smhi.Client, places placeFlags) {
+	// The data source tests the connection with the root.
+	mux.HandleFunc("/grafana/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/grafana/" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte("OK"))
+	})
+
+	mux.HandleFunc("/grafana/search", func(w http.ResponseWriter, r *http.Request) {
+		targets := []string{}
+		for _, p := range places {
+			for _, name := range smhi.ParameterNames() {
+				targets = append(targets, p.name+":"+name)
+			}
+		}
+		writeJSON(w, targets)
+	})
+
+	mux.HandleFunc("/grafana/query", func(w http.ResponseWriter, r *http.Request) {
+		var q grafanaQuery
+		if err := json.NewDecoder(r.Body).Decode(&q); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		// The forecast of each place is only fetched once per query.
+		forecasts := make(map[string]*smhi.PointForecast)
+		ret := []grafanaSeries{}
+		for _, t := range q.Targets {
+			i := strings.LastIndex(t.Target, ":")
+			if i < 0 {
+				continue
+			}
+			name, param := t.Target[:i], t.Target[i+1:]
+
+			f, ok := forecasts[name]
+			if !ok {
+				for _, p := range places {
+					if p.name != name {
+						continue
+					}
+
+					var err error
+					if f, err = c.GetPointForecastContext(r.Context(), p.lon, p.lat); err != nil {
+						http.Error(w, err.Error(), http.StatusBadGateway)
+						return
+					}
+					break
+				}
+				forecasts[name] = f
+			}
+			if f == nil {
+				continue
+			}
+
+			s := grafanaSeries{Target: t.Target, Datapoints: [][2]float64{}}
+			for i := range f.TimeSeries {
+				step := &f.TimeSeries[i]
+				if !q.Range.From.IsZero() && step.Timestamp.Before(q.Range.From) {
+					continue
+				}
+				if !q.Range.To.IsZero() && step.Timestamp.After(q.Range.To) {
+					continue
+				}
+				if v, ok := step.Value(param); ok {
+					s.Datapoints = append(s.Datapoints, [2]float64{v, float64(step.Timestamp.UnixNano() / int64(time.Millisecond))})
+				}
+			}
+			ret = append(ret, s)
+		}
+		writeJSON(w, ret)
+	})
+}
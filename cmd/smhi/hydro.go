@@ -0,0 +1,129 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/osm/smhi"
+)
+
+// hydroParameters holds the labels of the hydrological parameters that are
+// printed, along with the text that their titles contain.
+var hydroParameters = []struct {
+	label string
+	title string
+}{
+	{"discharge", "vattenföring"},
+	{"water level", "vattenstånd"},
+}
+
+// hydro resolves the sub-basin of a location and prints the latest
+// discharge and water level observations of the nearest gauging stations,
+// within the sub-basin if the basins are given, followed by the S-HYPE
+// discharge and water level forecasts of the sub-basin.
+func hydro(args []string) error {
+	fs := newFlagSet("hydro")
+	lon := fs.Float64("lon", 11.785, tr("longitude"))
+	lat := fs.Float64("lat", 57.634, tr("latitude"))
+	basins := fs.String("basins", "", tr("GeoJSON file of the sub-basins, such as from SMHI Vattenwebb"))
+	idProperty := fs.String("basin-id", "AROID", tr("property of the basin ids"))
+	nameProperty := fs.String("basin-name", "", tr("property of the basin names"))
+	sweref := fs.Bool("sweref99tm", false, tr("the basins are given in SWEREF 99 TM"))
+	period := fs.String("period", string(smhi.PeriodLatestDay), tr("period of the observations"))
+	forecast := fs.Bool("forecast", true, tr("print the S-HYPE forecasts of the sub-basin"))
+	fs.Parse(args)
+
+	var err error
+
+	// Keep to the stations of the sub-basin if the basins are given.
+	var filters []smhi.StationFilter
+	if *basins != "" {
+		var f *os.File
+		if f, err = os.Open(*basins); err != nil {
+			return err
+		}
+		defer f.Close()
+
+		var bi *smhi.BasinIndex
+		if bi, err = smhi.LoadBasins(f, smhi.BasinOptions{IDProperty: *idProperty, NameProperty: *nameProperty, SWEREF99TM: *sweref}); err != nil {
+			return err
+		}
+
+		var b *smhi.Basin
+		if b, err = bi.Lookup(*lon, *lat); err != nil {
+			return err
+		}
+		fmt.Printf("%s %s %s\n", tr("sub-basin:"), b.ID, b.Name)
+		filters = append(filters, smhi.InBasin(bi, b))
+	}
+
+	loc, _ := time.LoadLocation("Europe/Stockholm")
+
+	for _, hp := range hydroParameters {
+		var p int
+		if p, err = smhi.HydroParameter(hp.title); err != nil {
+			return err
+		}
+
+		var s *smhi.Station
+		var dist float64
+		if s, dist, err = smhi.NearestHydroStation(p, *lon, *lat, filters...); err == smhi.ErrNoStation {
+			fmt.Printf("%s: %s\n", tr(hp.label), tr("no station"))
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		var o *smhi.Observations
+		if o, err = smhi.GetHydroObservations(p, s.ID, smhi.ObservationPeriod(*period)); err != nil {
+			return err
+		}
+
+		fmt.Printf("%s: %s (%.1f km)\n", tr(hp.label), s.Name, dist)
+		for _, v := range o.Values {
+			fmt.Printf("  %s %8.2f %s\n", v.Timestamp.In(loc).Format("2006-01-02 15:04"), v.Value, o.Unit)
+		}
+	}
+
+	if !*forecast {
+		return nil
+	}
+
+	// The forecasts are per S-HYPE sub-basin, which may differ from the
+	// basins of the file.
+	var sb *smhi.SubBasin
+	if sb, err = smhi.GetSubBasin(*lon, *lat); errors.Is(err, smhi.ErrNoBasin) {
+		fmt.Printf("%s: %s\n", tr("forecast"), tr("no sub-basin"))
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	var hf *smhi.HydroForecast
+	if hf, err = smhi.GetHydroForecast(sb.ID); err != nil {
+		return err
+	}
+
+	fmt.Printf("%s %d %s (%s %s)\n", tr("S-HYPE sub-basin:"), sb.ID, sb.Name, tr("forecast"), hf.ReferenceTime.In(loc).Format("2006-01-02 15:04"))
+	for _, series := range []struct {
+		label  string
+		unit   string
+		values []smhi.Observation
+	}{
+		{"discharge", "m³/s", hf.Discharge},
+		{"water level", "cm", hf.WaterLevel},
+	} {
+		if len(series.values) == 0 {
+			continue
+		}
+
+		fmt.Printf("%s:\n", tr(series.label))
+		for _, v := range series.values {
+			fmt.Printf("  %s %8.2f %s\n", v.Timestamp.Format("2006-01-02"), v.Value, series.unit)
+		}
+	}
+
+	return nil
+}
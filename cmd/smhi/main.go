@@ -4,31 +4,98 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/osm/smhi"
 )
 
+// commands maps the subcommands to the functions that run them with the
+// remaining arguments, the forecast of a single location is printed when
+// no subcommand is given.
+var commands = map[string]func(args []string) error{
+	"alertd":   alertd,
+	"batch":    batch,
+	"cache":    cache,
+	"compare":  compare,
+	"diff":     diff,
+	"export":   export,
+	"firerisk": firerisk,
+	"hydro":    hydro,
+	"mesan":    mesan,
+	"notify":   notify,
+	"ocean":    ocean,
+	"pollen":   pollen,
+	"radar":    radar,
+	"serve":    serve,
+	"strang":   strang,
+	"tui":      tui,
+}
+
 func main() {
-	lon := flag.Float64("lon", 11.785, "longitude")
-	lat := flag.Float64("lat", 57.634, "latitude")
-	flag.Parse()
+	args := parseLocale(os.Args[1:])
+	if len(args) > 0 {
+		if cmd, ok := commands[args[0]]; ok {
+			if err := cmd(args[1:]); err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
 
-	var f *smhi.PointForecast
+	lon := flag.Float64("lon", 11.785, tr("longitude"))
+	lat := flag.Float64("lat", 57.634, tr("latitude"))
+	color := flag.String("color", "auto", tr("color the output, auto, always or never"))
+	temperatures := flag.String("temperatures", "0,10,20,25", tr("temperature thresholds of the colors in C"))
+	precipitation := flag.Float64("precipitation", 0.1, tr("precipitation in mm/h that is colored"))
+	warnings := flag.Bool("warnings", false, tr("print the active warnings of the location"))
+	precision := flag.String("precision", "", tr("decimals of the parameters, such as t=0,msl=0, or none"))
+	// The locale has already been parsed, it's only defined here so that
+	// it's part of the usage.
+	flag.String("locale", "", tr("language of the output, such as sv-SE or en-US"))
+	flag.Usage = usage
+	flag.CommandLine.Parse(args)
+
+	var c *colorizer
 	var err error
-	if f, err = smhi.GetPointForecast(*lon, *lat); err != nil {
+	if c, err = newColorizer(*color, *temperatures, *precipitation); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	var opts []smhi.Option
+	if opts, err = precisionOptions(*precision); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	if *warnings {
+		opts = append(opts, smhi.WithWarnings())
+	}
+
+	var f *smhi.PointForecast
+	if f, err = smhi.NewClient(opts...).GetPointForecast(*lon, *lat); err != nil {
 		fmt.Println(err)
 		os.Exit(1)
 	}
 
 	loc, _ := time.LoadLocation("Europe/Stockholm")
 
+	for _, w := range f.Warnings {
+		fmt.Println(c.warning(fmt.Sprintf("%s: %s, %s", w.Level, w.Event[descriptionLocale()], w.Area[descriptionLocale()])))
+	}
+
 	for _, t := range f.TimeSeries {
-		fmt.Println(
-			t.Timestamp.In(loc).Format("2006-01-02T15:04:05.999"),
-			t.WeatherSymbolDescription["sv-SE"],
-			t.AirTemperature, "C",
-			t.WindSpeed, t.WindSpeedDescription["sv-SE"],
-		)
+		fmt.Println(formatStep(c, loc, &t))
 	}
 }
+
+// formatStep returns the time step as a line of the forecast table.
+func formatStep(c *colorizer, loc *time.Location, t *smhi.Forecast) string {
+	return strings.TrimSuffix(fmt.Sprintln(
+		t.Timestamp.In(loc).Format("2006-01-02T15:04:05.999"),
+		c.rain(t.MeanPrecipitationIntensity, t.WeatherSymbolDescription[descriptionLocale()]),
+		c.temperature(t.AirTemperature, fmt.Sprint(t.AirTemperature, " C")),
+		t.WindSpeed, t.WindSpeedDescription[descriptionLocale()],
+	), "\n")
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/osm/smhi"
+)
+
+// mesan prints the latest MESAN analysis of a location next to the time
+// step of the forecast that is nearest to it, in the layout of the
+// forecast table.
+func mesan(args []string) error {
+	fs := newFlagSet("mesan")
+	lon := fs.Float64("lon", 11.785, tr("longitude"))
+	lat := fs.Float64("lat", 57.634, tr("latitude"))
+	color := fs.String("color", "auto", tr("color the output, auto, always or never"))
+	fs.Parse(args)
+
+	var err error
+
+	var c *colorizer
+	if c, err = newColorizer(*color, "0,10,20,25", 0.1); err != nil {
+		return err
+	}
+
+	var analysis, forecast *smhi.PointForecast
+	if analysis, err = smhi.GetMesanAnalysis(*lon, *lat); err != nil {
+		return err
+	}
+	if forecast, err = smhi.GetPointForecast(*lon, *lat); err != nil {
+		return err
+	}
+	if len(analysis.TimeSeries) == 0 || len(forecast.TimeSeries) == 0 {
+		return errors.New(tr("empty analysis or forecast"))
+	}
+
+	// Compare the latest analysis with the forecast for the same time.
+	now := &analysis.TimeSeries[0]
+	for i := range analysis.TimeSeries {
+		if analysis.TimeSeries[i].Timestamp.After(now.Timestamp) {
+			now = &analysis.TimeSeries[i]
+		}
+	}
+	nearest := &forecast.TimeSeries[0]
+	for i := range forecast.TimeSeries {
+		if absDuration(forecast.TimeSeries[i].Timestamp.Sub(now.Timestamp)) < absDuration(nearest.Timestamp.Sub(now.Timestamp)) {
+			nearest = &forecast.TimeSeries[i]
+		}
+	}
+
+	loc, _ := time.LoadLocation("Europe/Stockholm")
+
+	fmt.Printf("%-10s %s\n", tr("analysis"), formatStep(c, loc, now))
+	fmt.Printf("%-10s %s\n", tr("forecast"), formatStep(c, loc, nearest))
+
+	return nil
+}
+
+// absDuration returns the absolute value of the duration.
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
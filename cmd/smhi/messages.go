@@ -0,0 +1,187 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// locale is the locale of the output, which is given by the -locale flag
+// or the SMHI_LOCALE environment variable.
+var locale = os.Getenv("SMHI_LOCALE")
+
+// messages holds the translations of the messages of the CLI, keyed by the
+// English message and the locale in the same way as the descriptions.
+var messages = map[string]map[string]string{
+	"%d images":                              {"sv-SE": "%d bilder"},
+	"%d of %d points failed":                 {"sv-SE": "%d av %d punkter misslyckades"},
+	"%d temperature thresholds are needed":   {"sv-SE": "%d temperaturgränser behövs"},
+	"CSV file of id,lon,lat, stdin if empty": {"sv-SE": "CSV-fil med id,lon,lat, stdin om den är tom"},
+	"Commands:":                              {"sv-SE": "Kommandon:"},
+	"GeoJSON file of the sub-basins, such as from SMHI Vattenwebb": {"sv-SE": "GeoJSON-fil med delavrinningsområdena, till exempel från SMHI Vattenwebb"},
+	"No warnings":       {"sv-SE": "Inga varningar"},
+	"S-HYPE sub-basin:": {"sv-SE": "S-HYPE-delavrinningsområde:"},
+	"Usage of %s:":      {"sv-SE": "Användning av %s:"},
+	"Usage: smhi cache [flags] ls|clear|stats":               {"sv-SE": "Användning: smhi cache [flaggor] ls|clear|stats"},
+	"address to listen on":                                   {"sv-SE": "adress att lyssna på"},
+	"alertd needs at least one notifier":                     {"sv-SE": "alertd behöver minst en aviserare"},
+	"alertd needs at least one place":                        {"sv-SE": "alertd behöver minst en ort"},
+	"analysis":                                               {"sv-SE": "analys"},
+	"archive directory":                                      {"sv-SE": "arkivkatalog"},
+	"cache needs one of ls, clear or stats":                  {"sv-SE": "cache behöver ett av ls, clear eller stats"},
+	"color the output, auto, always or never":                {"sv-SE": "färglägg utskriften, auto, always eller never"},
+	"compare needs at least two places":                      {"sv-SE": "compare behöver minst två orter"},
+	"condition, such as 'tstm>30 within 12h'":                {"sv-SE": "villkor, till exempel 'tstm>30 within 12h'"},
+	"configuration file of the alerts":                       {"sv-SE": "konfigurationsfil med larmen"},
+	"configuration file with the places":                     {"sv-SE": "konfigurationsfil med orterna"},
+	"decimals of the parameters, such as t=0,msl=0, or none": {"sv-SE": "decimaler för parametrarna, till exempel t=0,msl=0, eller none"},
+	"directory:":                 {"sv-SE": "katalog:"},
+	"discharge":                  {"sv-SE": "vattenföring"},
+	"empty analysis or forecast": {"sv-SE": "tom analys eller prognos"},
+	"entries:":                   {"sv-SE": "poster:"},
+	"export the observations of this parameter instead of the forecast": {"sv-SE": "exportera observationerna av denna parameter i stället för prognosen"},
+	"file of the animated GIF of the recent radar images":               {"sv-SE": "fil för den animerade GIF:en av de senaste radarbilderna"},
+	"file of the latest radar image":                                    {"sv-SE": "fil för den senaste radarbilden"},
+	"first date, such as 2024-06-01":                                    {"sv-SE": "första datum, till exempel 2024-06-01"},
+	"forecast":                                                          {"sv-SE": "prognos"},
+	"grass fire":                                                        {"sv-SE": "gräsbrand"},
+	"id of the sea level station, the nearest one is used if it's zero": {"sv-SE": "id för vattenståndsstationen, den närmaste används om det är noll"},
+	"invalid date %q":                                                   {"sv-SE": "ogiltigt datum %q"},
+	"j/k scroll  h/l day  n/p place  r refresh  q quit":                 {"sv-SE": "j/k rulla  h/l dag  n/p ort  r uppdatera  q avsluta"},
+	"language of the output, such as sv-SE or en-US":                    {"sv-SE": "språk för utskriften, till exempel sv-SE eller en-US"},
+	"last date, such as 2024-06-30":                                     {"sv-SE": "sista datum, till exempel 2024-06-30"},
+	"latitude":                                                          {"sv-SE": "latitud"},
+	"least time between two requests":                                   {"sv-SE": "minsta tid mellan två anrop"},
+	"line %d: invalid coordinate":                                       {"sv-SE": "rad %d: ogiltig koordinat"},
+	"line %d: invalid id %q":                                            {"sv-SE": "rad %d: ogiltigt id %q"},
+	"listening on %s":                                                   {"sv-SE": "lyssnar på %s"},
+	"longitude":                                                         {"sv-SE": "longitud"},
+	"newest:":                                                           {"sv-SE": "nyaste:"},
+	"no changes":                                                        {"sv-SE": "inga ändringar"},
+	"no points":                                                         {"sv-SE": "inga punkter"},
+	"no previous run of %s in %s":                                       {"sv-SE": "ingen tidigare körning än %s i %s"},
+	"no station":                                                        {"sv-SE": "ingen station"},
+	"no sub-basin":                                                      {"sv-SE": "inget delavrinningsområde"},
+	"notify needs a condition":                                          {"sv-SE": "notify behöver ett villkor"},
+	"number of concurrent requests":                                     {"sv-SE": "antal samtidiga anrop"},
+	"observations can only be exported as csv":                          {"sv-SE": "observationer kan bara exporteras som csv"},
+	"oldest:":                       {"sv-SE": "äldsta:"},
+	"output directory":              {"sv-SE": "utkatalog"},
+	"output file, stdout if empty":  {"sv-SE": "utfil, stdout om den är tom"},
+	"output format, csv or geojson": {"sv-SE": "utformat, csv eller geojson"},
+	"output format, text or csv":    {"sv-SE": "utformat, text eller csv"},
+	"parameter, global, direct, directhorizontal, diffuse, uv, sunshine or par":    {"sv-SE": "parameter, global, direct, directhorizontal, diffuse, uv, sunshine eller par"},
+	"parquet isn't supported, it needs an encoder outside of the standard library": {"sv-SE": "parquet stöds inte, det kräver en kodare utanför standardbiblioteket"},
+	"period of the animated GIF":                        {"sv-SE": "period för den animerade GIF:en"},
+	"period of the observations":                        {"sv-SE": "period för observationerna"},
+	"place name or lon,lat":                             {"sv-SE": "ortnamn eller lon,lat"},
+	"place name or lon,lat, may be repeated":            {"sv-SE": "ortnamn eller lon,lat, kan upprepas"},
+	"precipitation in mm/h that is colored":             {"sv-SE": "nederbörd i mm/h som färgläggs"},
+	"print the S-HYPE forecasts of the sub-basin":       {"sv-SE": "skriv ut S-HYPE-prognoserna för delavrinningsområdet"},
+	"print the active warnings of the location":         {"sv-SE": "skriv ut de aktiva varningarna för platsen"},
+	"property of the basin ids":                         {"sv-SE": "egenskap med områdenas id"},
+	"property of the basin names":                       {"sv-SE": "egenskap med områdenas namn"},
+	"radar needs -out or -animate":                      {"sv-SE": "radar behöver -out eller -animate"},
+	"sea level:":                                        {"sv-SE": "havsvattenstånd:"},
+	"serve needs at least one place":                    {"sv-SE": "serve behöver minst en ort"},
+	"serve the HTML dashboard on /":                     {"sv-SE": "visa HTML-panelen på /"},
+	"size:":                                             {"sv-SE": "storlek:"},
+	"station of the observations":                       {"sv-SE": "station för observationerna"},
+	"sub-basin:":                                        {"sv-SE": "delavrinningsområde:"},
+	"temperature thresholds of the colors in C":         {"sv-SE": "temperaturgränser för färgerna i C"},
+	"the basins are given in SWEREF 99 TM":              {"sv-SE": "områdena är angivna i SWEREF 99 TM"},
+	"time between the checks":                           {"sv-SE": "tid mellan kontrollerna"},
+	"time that each image of the animated GIF is shown": {"sv-SE": "tid som varje bild i den animerade GIF:en visas"},
+	"tui needs a terminal: %w":                          {"sv-SE": "tui behöver en terminal: %w"},
+	"unknown cache command %q":                          {"sv-SE": "okänt cache-kommando %q"},
+	"unknown color mode %q":                             {"sv-SE": "okänt färgläge %q"},
+	"unknown format %q":                                 {"sv-SE": "okänt format %q"},
+	"unknown notifier %q":                               {"sv-SE": "okänd aviserare %q"},
+	"unknown parameter %q":                              {"sv-SE": "okänd parameter %q"},
+	"unknown place %q":                                  {"sv-SE": "okänd ort %q"},
+	"unknown station %d":                                {"sv-SE": "okänd station %d"},
+	"water level":                                       {"sv-SE": "vattenstånd"},
+	"water temperature:":                                {"sv-SE": "vattentemperatur:"},
+	"waves:":                                            {"sv-SE": "vågor:"},
+	"yaml isn't supported, it needs a parser outside of the standard library": {"sv-SE": "yaml stöds inte, det kräver en tolk utanför standardbiblioteket"},
+	"invalid temperature threshold %q":                                        {"sv-SE": "ogiltig temperaturgräns %q"},
+}
+
+// weekdays holds the short names of the weekdays per locale.
+var weekdays = map[string][7]string{
+	"sv-SE": {"sön", "mån", "tis", "ons", "tor", "fre", "lör"},
+	"en-US": {"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+}
+
+// tr returns the message in the locale, the English message is returned
+// if there is no translation.
+func tr(msg string) string {
+	if t, ok := messages[msg][locale]; ok {
+		return t
+	}
+	return msg
+}
+
+// descriptionLocale returns the locale of the descriptions, which are in
+// Swedish unless another locale is given.
+func descriptionLocale() string {
+	if locale == "" {
+		return "sv-SE"
+	}
+	return locale
+}
+
+// weekday returns the short name of the weekday in the locale.
+func weekday(d int) string {
+	if names, ok := weekdays[descriptionLocale()]; ok {
+		return names[d]
+	}
+	return weekdays["en-US"][d]
+}
+
+// parseLocale sets the locale from the -locale flag, which may be given
+// before or after the subcommand, and returns the remaining arguments.
+func parseLocale(args []string) []string {
+	var ret []string
+	for i := 0; i < len(args); i++ {
+		a := args[i]
+		switch {
+		case a == "-locale" || a == "--locale":
+			if i+1 < len(args) {
+				locale = args[i+1]
+				i++
+			}
+			continue
+		case strings.HasPrefix(a, "-locale=") || strings.HasPrefix(a, "--locale="):
+			locale = a[strings.Index(a, "=")+1:]
+			continue
+		}
+		ret = append(ret, a)
+	}
+	return ret
+}
+
+// newFlagSet returns a flag set for the subcommand with a localized usage.
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), tr("Usage of %s:")+"\n", "smhi "+name)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// usage prints the usage of the CLI without a subcommand.
+func usage() {
+	fmt.Fprintf(flag.CommandLine.Output(), tr("Usage of %s:")+"\n", "smhi")
+	flag.PrintDefaults()
+
+	var names []string
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fmt.Fprintln(flag.CommandLine.Output(), tr("Commands:"), strings.Join(names, ", "))
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os/exec"
+	"runtime"
+	"time"
+
+	"github.com/osm/smhi"
+)
+
+// notify checks the forecast periodically and raises a desktop
+// notification when the condition is met, it's raised again only after the
+// condition has stopped being met.
+func notify(args []string) error {
+	fs := newFlagSet("notify")
+	lon := fs.Float64("lon", 11.785, tr("longitude"))
+	lat := fs.Float64("lat", 57.634, tr("latitude"))
+	when := fs.String("when", "", tr("condition, such as 'tstm>30 within 12h'"))
+	interval := fs.Duration("interval", 30*time.Minute, tr("time between the checks"))
+	fs.Parse(args)
+
+	if *when == "" {
+		return errors.New(tr("notify needs a condition"))
+	}
+
+	var err error
+
+	var c *smhi.Condition
+	if c, err = smhi.ParseCondition(*when); err != nil {
+		return err
+	}
+
+	loc, _ := time.LoadLocation("Europe/Stockholm")
+
+	var notified bool
+	for {
+		f, err := smhi.GetPointForecast(*lon, *lat)
+		if err != nil {
+			log.Println(err)
+		} else if t, ok := c.Match(f, time.Now()); !ok {
+			notified = false
+		} else if !notified {
+			msg := fmt.Sprintf("%s at %s", c, t.Timestamp.In(loc).Format("2006-01-02 15:04"))
+			if err = desktopNotification("SMHI", msg); err != nil {
+				log.Println(err)
+			}
+			notified = true
+		}
+
+		time.Sleep(*interval)
+	}
+}
+
+// desktopNotification raises a desktop notification with osascript on
+// macOS and with notify-send elsewhere.
+func desktopNotification(title, msg string) error {
+	if runtime.GOOS == "darwin" {
+		script := fmt.Sprintf("display notification %q with title %q", msg, title)
+		return exec.Command("osascript", "-e", script).Run()
+	}
+
+	return exec.Command("notify-send", title, msg).Run()
+}
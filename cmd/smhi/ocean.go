@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/osm/smhi"
+)
+
+// ocean prints the sea level, the water temperature and the wave forecast
+// of a coastal station, either the given one or the one that is nearest
+// to the location.
+func ocean(args []string) error {
+	fs := newFlagSet("ocean")
+	station := fs.Int("station", 0, tr("id of the sea level station, the nearest one is used if it's zero"))
+	lon := fs.Float64("lon", 11.785, tr("longitude"))
+	lat := fs.Float64("lat", 57.634, tr("latitude"))
+	fs.Parse(args)
+
+	var err error
+
+	// Find the sea level station.
+	var s *smhi.Station
+	var dist float64
+	if *station != 0 {
+		var stations []smhi.Station
+		if stations, err = smhi.GetSeaLevelStations(); err != nil {
+			return err
+		}
+		for i := range stations {
+			if stations[i].ID == *station {
+				s = &stations[i]
+				break
+			}
+		}
+		if s == nil {
+			return fmt.Errorf(tr("unknown station %d"), *station)
+		}
+	} else if s, dist, err = smhi.NearestSeaLevelStation(*lon, *lat); err != nil {
+		return err
+	}
+	fmt.Printf("%s (%.1f km)\n", s.Name, dist)
+
+	loc, _ := time.LoadLocation("Europe/Stockholm")
+
+	// The sea level of the coming day, and the latest observation.
+	var sum *smhi.SeaLevelSummary
+	if sum, err = smhi.GetSeaLevelSummary(s.ID, 24*time.Hour); err != nil {
+		return err
+	}
+	fmt.Printf("%-20s %s\n", tr("sea level:"), sum.Description[descriptionLocale()])
+	var levels []smhi.SeaLevel
+	if levels, err = smhi.GetSeaLevelObservations(s.ID, smhi.PeriodLatestDay); err != nil {
+		return err
+	}
+	if len(levels) > 0 {
+		l := levels[len(levels)-1]
+		fmt.Printf("%-20s %.0f cm %s\n", "", l.Level, l.Timestamp.In(loc).Format("2006-01-02 15:04"))
+	}
+
+	// The water temperature of the nearest coastal station, which isn't
+	// always the sea level station.
+	var wt *smhi.WaterTemperatureStation
+	if wt, _, err = smhi.NearestWaterTemperatureStation(s.Longitude, s.Latitude, smhi.CoastalWaterTemperature); err != nil {
+		return err
+	}
+	var o *smhi.Observation
+	if o, err = smhi.GetLatestWaterTemperature(wt); err != nil {
+		return err
+	}
+	fmt.Printf("%-20s %.1f C %s, %s\n", tr("water temperature:"), o.Value, o.Timestamp.In(loc).Format("2006-01-02 15:04"), wt.Name)
+
+	// The wave forecast at the station.
+	var pf *smhi.PointForecast
+	if pf, err = smhi.NewClient(smhi.WithWaves()).GetPointForecast(s.Longitude, s.Latitude); err != nil {
+		return err
+	}
+	fmt.Println(tr("waves:"))
+	end := time.Now().Add(24 * time.Hour)
+	for _, f := range pf.TimeSeries {
+		h, ok := f.SignificantWaveHeight.Get()
+		if !ok || f.Timestamp.After(end) {
+			continue
+		}
+		fmt.Printf("  %s %4.1f m %s %s s\n", f.Timestamp.In(loc).Format("2006-01-02 15:04"), h, f.WaveDirection, f.WavePeriod)
+	}
+
+	return nil
+}
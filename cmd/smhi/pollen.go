@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/osm/smhi"
+)
+
+// pollen prints today's and tomorrow's pollen levels per species for the
+// region that is nearest to a place.
+func pollen(args []string) error {
+	fs := newFlagSet("pollen")
+	name := fs.String("place", "Göteborg", tr("place name or lon,lat"))
+	fs.Parse(args)
+
+	var err error
+
+	var p place
+	if p, err = lookupPlace(*name); err != nil {
+		return err
+	}
+
+	var pf *smhi.PollenForecast
+	if pf, err = smhi.GetPollenForecast(p.lon, p.lat); err != nil {
+		return err
+	}
+
+	loc, _ := time.LoadLocation("Europe/Stockholm")
+	now := time.Now().In(loc)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+
+	fmt.Println(pf.Region.Name)
+	for _, l := range pf.Levels {
+		d := l.Date.In(loc)
+		if d.Before(today) || !d.Before(today.AddDate(0, 0, 2)) {
+			continue
+		}
+		fmt.Printf("%s %-16s %s\n", weekday(int(d.Weekday())), l.Species, l.Description[descriptionLocale()])
+	}
+
+	return nil
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"github.com/osm/smhi"
+)
+
+// precisionOptions returns the client options of the value of a -precision
+// flag, which is "none" for no rounding, or parameters and decimals that
+// override the default precision, such as "t=0,ws=0".
+func precisionOptions(s string) ([]smhi.Option, error) {
+	if s == "none" {
+		return nil, nil
+	}
+
+	var err error
+
+	var overrides smhi.Precision
+	if overrides, err = smhi.ParsePrecision(s); err != nil {
+		return nil, err
+	}
+
+	p := make(smhi.Precision)
+	for name, d := range smhi.DefaultPrecision {
+		p[name] = d
+	}
+	for name, d := range overrides {
+		p[name] = d
+	}
+
+	return []smhi.Option{smhi.WithPrecision(p)}, nil
+}
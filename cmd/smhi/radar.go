@@ -0,0 +1,73 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/osm/smhi"
+)
+
+// radar downloads the latest radar composite, and optionally an animated
+// GIF of the recent ones.
+func radar(args []string) error {
+	fs := newFlagSet("radar")
+	out := fs.String("out", "", tr("file of the latest radar image"))
+	animate := fs.String("animate", "", tr("file of the animated GIF of the recent radar images"))
+	period := fs.Duration("period", 2*time.Hour, tr("period of the animated GIF"))
+	delay := fs.Duration("delay", 500*time.Millisecond, tr("time that each image of the animated GIF is shown"))
+	fs.Parse(args)
+
+	if *out == "" && *animate == "" {
+		fs.Usage()
+		return errors.New(tr("radar needs -out or -animate"))
+	}
+
+	var err error
+
+	var images []smhi.RadarImage
+	if images, err = smhi.GetRecentRadarImages(*period); err != nil {
+		return err
+	}
+
+	if *out != "" {
+		latest := images[len(images)-1]
+
+		var data []byte
+		if data, err = smhi.GetRadarImage(latest); err != nil {
+			return err
+		}
+		if err = ioutil.WriteFile(*out, data, 0644); err != nil {
+			return err
+		}
+		fmt.Println(*out, latest.Valid.Local().Format("2006-01-02 15:04"))
+	}
+
+	if *animate != "" {
+		var frames [][]byte
+		for _, img := range images {
+			var data []byte
+			if data, err = smhi.GetRadarImage(img); err != nil {
+				return err
+			}
+			frames = append(frames, data)
+		}
+
+		var f *os.File
+		if f, err = os.Create(*animate); err != nil {
+			return err
+		}
+		if err = smhi.EncodeRadarGIF(f, frames, *delay); err != nil {
+			f.Close()
+			return err
+		}
+		if err = f.Close(); err != nil {
+			return err
+		}
+		fmt.Printf("%s %s\n", *animate, fmt.Sprintf(tr("%d images"), len(frames)))
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/osm/smhi"
+)
+
+// dashboard is the page that renders the meteogram and the warnings of the
+// configured places.
+//
+//go:embed dashboard.html
+var dashboard []byte
+
+// serve serves the forecasts of the configured places as JSON, and the
+// dashboard if it's enabled.
+func serve(args []string) error {
+	var places placeFlags
+	fs := newFlagSet("serve")
+	addr := fs.String("addr", ":8080", tr("address to listen on"))
+	withDashboard := fs.Bool("dashboard", false, tr("serve the HTML dashboard on /"))
+	fs.Var(&places, "place", tr("place name or lon,lat, may be repeated"))
+	precision := fs.String("precision", "", tr("decimals of the parameters, such as t=0,msl=0, or none"))
+	fs.Parse(args)
+
+	if len(places) == 0 {
+		return errors.New(tr("serve needs at least one place"))
+	}
+
+	opts, err := precisionOptions(*precision)
+	if err != nil {
+		return err
+	}
+
+	c := smhi.NewClient(append(opts, smhi.WithWarnings())...)
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/api/places", func(w http.ResponseWriter, r *http.Request) {
+		var names []string
+		for _, p := range places {
+			names = append(names, p.name)
+		}
+		writeJSON(w, names)
+	})
+
+	// forecast writes the forecast of the place of the request, converted
+	// by the given function.
+	forecast := func(convert func(f *smhi.PointForecast) interface{}) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			name := r.URL.Query().Get("place")
+			for _, p := range places {
+				if p.name != name {
+					continue
+				}
+
+				f, err := c.GetPointForecastContext(r.Context(), p.lon, p.lat)
+				if err != nil {
+					http.Error(w, err.Error(), http.StatusBadGateway)
+					return
+				}
+				writeJSON(w, convert(f))
+				return
+			}
+			http.NotFound(w, r)
+		}
+	}
+
+	mux.HandleFunc("/api/forecast", forecast(func(f *smhi.PointForecast) interface{} {
+		return f
+	}))
+	mux.HandleFunc("/api/homeassistant", forecast(func(f *smhi.PointForecast) interface{} {
+		return f.HomeAssistant(time.Now())
+	}))
+
+	handleGrafana(mux, c, places)
+
+	if *withDashboard {
+		mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/" {
+				http.NotFound(w, r)
+				return
+			}
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.Write(dashboard)
+		})
+	}
+
+	log.Printf(tr("listening on %s"), *addr)
+	return http.ListenAndServe(*addr, mux)
+}
+
+// writeJSON writes v as JSON to the response.
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Println(err)
+	}
+}
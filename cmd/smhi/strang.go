@@ -0,0 +1,105 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/osm/smhi"
+)
+
+// strangParameters maps the names of the -param flag to the STRÅNG
+// parameters and their units.
+var strangParameters = map[string]struct {
+	param smhi.StrangParameter
+	unit  string
+}{
+	"global":           {smhi.StrangGlobalIrradiance, "W/m²"},
+	"direct":           {smhi.StrangDirectNormalIrradiance, "W/m²"},
+	"directhorizontal": {smhi.StrangDirectHorizontalIrradiance, "W/m²"},
+	"diffuse":          {smhi.StrangDiffuseIrradiance, "W/m²"},
+	"uv":               {smhi.StrangCIEUVIrradiance, "mW/m²"},
+	"sunshine":         {smhi.StrangSunshineDuration, "min"},
+	"par":              {smhi.StrangPhotosyntheticPhotonFluxDensity, "µmol/m²s"},
+}
+
+// strang prints or exports the hourly values of a STRÅNG solar radiation
+// parameter for a location.
+func strang(args []string) error {
+	loc, _ := time.LoadLocation("Europe/Stockholm")
+	today := time.Now().In(loc).Format("2006-01-02")
+
+	fs := newFlagSet("strang")
+	lon := fs.Float64("lon", 11.785, tr("longitude"))
+	lat := fs.Float64("lat", 57.634, tr("latitude"))
+	param := fs.String("param", "global", tr("parameter, global, direct, directhorizontal, diffuse, uv, sunshine or par"))
+	from := fs.String("from", today, tr("first date, such as 2024-06-01"))
+	to := fs.String("to", today, tr("last date, such as 2024-06-30"))
+	format := fs.String("format", "text", tr("output format, text or csv"))
+	out := fs.String("out", "", tr("output file, stdout if empty"))
+	fs.Parse(args)
+
+	var err error
+
+	p, ok := strangParameters[*param]
+	if !ok {
+		return fmt.Errorf(tr("unknown parameter %q"), *param)
+	}
+
+	var start, end time.Time
+	if start, err = time.ParseInLocation("2006-01-02", *from, loc); err != nil {
+		return fmt.Errorf(tr("invalid date %q"), *from)
+	}
+	if end, err = time.ParseInLocation("2006-01-02", *to, loc); err != nil {
+		return fmt.Errorf(tr("invalid date %q"), *to)
+	}
+
+	var values []smhi.StrangValue
+	if values, err = smhi.GetStrang(p.param, *lon, *lat, start, end); err != nil {
+		return err
+	}
+
+	var write func(w io.Writer) error
+	switch *format {
+	case "text":
+		write = func(w io.Writer) error {
+			for _, v := range values {
+				if _, err := fmt.Fprintf(w, "%s %8.1f %s\n", v.Timestamp.In(loc).Format("2006-01-02 15:04"), v.Value, p.unit); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		break
+	case "csv":
+		write = func(w io.Writer) error {
+			cw := csv.NewWriter(w)
+			cw.Write([]string{"timestamp", *param})
+			for _, v := range values {
+				cw.Write([]string{v.Timestamp.UTC().Format(time.RFC3339), strconv.FormatFloat(v.Value, 'f', -1, 64)})
+			}
+			cw.Flush()
+			return cw.Error()
+		}
+		break
+	default:
+		return fmt.Errorf(tr("unknown format %q"), *format)
+	}
+
+	if *out == "" {
+		return write(os.Stdout)
+	}
+
+	var file *os.File
+	if file, err = os.Create(*out); err != nil {
+		return err
+	}
+	if err = write(file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
@@ -0,0 +1,243 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/osm/smhi"
+)
+
+// terminalUI is an interactive terminal UI with a time series per day, a
+// warnings pane and a switcher between the configured places.
+type terminalUI struct {
+	client    *smhi.Client
+	loc       *time.Location
+	places    []place
+	forecasts map[int]*smhi.PointForecast
+	errs      map[int]error
+	place     int
+	day       int
+	offset    int
+	width     int
+	height    int
+}
+
+// day is the time steps of a calendar day.
+type day struct {
+	date  time.Time
+	steps []smhi.Forecast
+}
+
+// tui runs the terminal UI until it's quit.
+func tui(args []string) error {
+	var places placeFlags
+	fs := newFlagSet("tui")
+	configPath := fs.String("config", defaultConfigPath(), tr("configuration file with the places"))
+	fs.Var(&places, "place", tr("place name or lon,lat, may be repeated"))
+	fs.Parse(args)
+
+	var err error
+
+	if len(places) == 0 {
+		var c *config
+		if c, err = loadConfig(*configPath); err != nil {
+			return err
+		}
+		if places, err = c.places(); err != nil {
+			return err
+		}
+	}
+	if len(places) == 0 {
+		places = placeFlags{{"Göteborg", 11.785, 57.634}}
+	}
+
+	// Put the terminal in raw mode so that the keys are read as they are
+	// pressed, and restore it when done.
+	var state string
+	if state, err = stty("-g"); err != nil {
+		return fmt.Errorf(tr("tui needs a terminal: %w"), err)
+	}
+	if _, err = stty("raw", "-echo"); err != nil {
+		return err
+	}
+	defer stty(strings.TrimSpace(state))
+	defer fmt.Print("\x1b[?25h\x1b[H\x1b[2J")
+	fmt.Print("\x1b[?25l")
+
+	loc, _ := time.LoadLocation("Europe/Stockholm")
+	t := &terminalUI{
+		client:    smhi.NewClient(smhi.WithWarnings()),
+		loc:       loc,
+		places:    places,
+		forecasts: make(map[int]*smhi.PointForecast),
+		errs:      make(map[int]error),
+	}
+
+	buf := make([]byte, 8)
+	for {
+		t.fetch(false)
+		t.render()
+
+		n, err := os.Stdin.Read(buf)
+		if err != nil {
+			return err
+		}
+
+		switch key := string(buf[:n]); key {
+		case "q", "\x03":
+			return nil
+		case "j", "\x1b[B":
+			t.offset++
+			break
+		case "k", "\x1b[A":
+			t.offset--
+			break
+		case "l", "\x1b[C":
+			t.day, t.offset = t.day+1, 0
+			break
+		case "h", "\x1b[D":
+			t.day, t.offset = t.day-1, 0
+			break
+		case "n", "\t":
+			t.place, t.day, t.offset = (t.place+1)%len(t.places), 0, 0
+			break
+		case "p":
+			t.place, t.day, t.offset = (t.place+len(t.places)-1)%len(t.places), 0, 0
+			break
+		case "r":
+			t.fetch(true)
+			break
+		}
+	}
+}
+
+// stty runs stty with the given arguments on the terminal.
+func stty(args ...string) (string, error) {
+	cmd := exec.Command("stty", args...)
+	cmd.Stdin = os.Stdin
+	out, err := cmd.Output()
+	return string(out), err
+}
+
+// fetch fetches the forecast of the current place unless it has already
+// been fetched, or always if force is set.
+func (t *terminalUI) fetch(force bool) {
+	if _, ok := t.forecasts[t.place]; ok && !force {
+		return
+	}
+
+	f, err := t.client.GetPointForecast(t.places[t.place].lon, t.places[t.place].lat)
+	t.forecasts[t.place], t.errs[t.place] = f, err
+}
+
+// days groups the time steps of the current forecast by calendar day.
+func (t *terminalUI) days() []day {
+	var ret []day
+
+	f := t.forecasts[t.place]
+	if f == nil {
+		return nil
+	}
+	for _, s := range f.TimeSeries {
+		ts := s.Timestamp.In(t.loc)
+		date := time.Date(ts.Year(), ts.Month(), ts.Day(), 0, 0, 0, 0, t.loc)
+		if len(ret) == 0 || !ret[len(ret)-1].date.Equal(date) {
+			ret = append(ret, day{date: date})
+		}
+		ret[len(ret)-1].steps = append(ret[len(ret)-1].steps, s)
+	}
+
+	return ret
+}
+
+// render draws the UI, the size of the terminal is read on every render
+// so that resizes are handled.
+func (t *terminalUI) render() {
+	t.width, t.height = 80, 24
+	if size, err := stty("size"); err == nil {
+		var h, w int
+		if fmt.Sscan(size, &h, &w); h > 0 && w > 0 {
+			t.height, t.width = h, w
+		}
+	}
+
+	var lines []string
+
+	// The places, with the current one in reverse video.
+	var tabs []string
+	for i, p := range t.places {
+		if i == t.place {
+			tabs = append(tabs, "\x1b[7m "+p.name+" \x1b[0m")
+		} else {
+			tabs = append(tabs, " "+p.name+" ")
+		}
+	}
+	lines = append(lines, strings.Join(tabs, ""))
+
+	days := t.days()
+	if t.day >= len(days) {
+		t.day = len(days) - 1
+	}
+	if t.day < 0 {
+		t.day = 0
+	}
+
+	// The day tabs.
+	tabs = nil
+	for i, d := range days {
+		name := weekday(int(d.date.Weekday())) + d.date.Format(" 02")
+		if i == t.day {
+			tabs = append(tabs, "\x1b[7m "+name+" \x1b[0m")
+		} else {
+			tabs = append(tabs, " "+name+" ")
+		}
+	}
+	lines = append(lines, strings.Join(tabs, ""), "")
+
+	// The warnings pane takes up to five lines at the bottom.
+	var warnings []string
+	if f := t.forecasts[t.place]; f != nil {
+		for _, w := range f.Warnings {
+			warnings = append(warnings, fmt.Sprintf("\x1b[31m%s: %s, %s\x1b[0m", w.Level, w.Event[descriptionLocale()], w.Area[descriptionLocale()]))
+		}
+	}
+	if len(warnings) == 0 {
+		warnings = []string{tr("No warnings")}
+	}
+	if len(warnings) > 5 {
+		warnings = warnings[:5]
+	}
+
+	rows := t.height - len(lines) - len(warnings) - 2
+	if err := t.errs[t.place]; err != nil {
+		lines = append(lines, err.Error())
+	} else if len(days) > 0 {
+		steps := days[t.day].steps
+		if t.offset > len(steps)-rows {
+			t.offset = len(steps) - rows
+		}
+		if t.offset < 0 {
+			t.offset = 0
+		}
+
+		for i := t.offset; i < len(steps) && i < t.offset+rows; i++ {
+			s := steps[i]
+			lines = append(lines, fmt.Sprintf("%s %6.1f C %5.1f m/s %5.1f mm/h  %s",
+				s.Timestamp.In(t.loc).Format("15:04"),
+				s.AirTemperature, s.WindSpeed, s.MeanPrecipitationIntensity,
+				s.WeatherSymbolDescription[descriptionLocale()]))
+		}
+	}
+	for len(lines) < t.height-len(warnings)-2 {
+		lines = append(lines, "")
+	}
+
+	lines = append(lines, strings.Repeat("─", t.width))
+	lines = append(lines, warnings...)
+	lines = append(lines, "\x1b[2m"+tr("j/k scroll  h/l day  n/p place  r refresh  q quit")+"\x1b[0m")
+
+	fmt.Print("\x1b[H\x1b[2J" + strings.Join(lines, "\r\n"))
+}
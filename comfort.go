@@ -0,0 +1,70 @@
+package smhi
+
+import (
+	"math"
+)
+
+// globalIrradiance estimates the global irradiance in W/m² from the
+// elevation of the sun and the total cloud cover in octas.
+func globalIrradiance(elevation float64, octas uint8) float64 {
+	if elevation <= 0 {
+		return 0
+	}
+
+	clear := 910*math.Sin(elevation*math.Pi/180) - 30
+	if clear < 0 {
+		return 0
+	}
+
+	return clear * (1 - 0.75*math.Pow(float64(octas)/8, 3.4))
+}
+
+// ApparentTemperature returns the apparent temperature of the forecast at
+// the given longitude and latitude according to Steadman's formula, which
+// takes the air temperature, humidity, wind and the radiation absorbed by
+// the body into account. The absorbed radiation is estimated from the
+// position of the sun and the total cloud cover. It's used for the
+// forecasts of other providers that lack the apparent temperature.
+func ApparentTemperature(f *Forecast, lon, lat float64) float64 {
+	ws := f.WindSpeed
+	e := float64(f.RelativeHumidity) / 100 * 6.105 * math.Exp(17.27*f.AirTemperature/(237.7+f.AirTemperature))
+	q := 0.1 * globalIrradiance(solarElevation(f.Timestamp, lon, lat), f.MeanValueOfTotalCloudCover)
+
+	return f.AirTemperature + 0.348*e - 0.70*ws + 0.70*q/(ws+10) - 4.25
+}
+
+// getComfortDescription returns a friendly name for the apparent
+// temperature.
+func getComfortDescription(at float64) map[string]string {
+	ret := make(map[string]string)
+
+	if at < -30 {
+		ret["sv-SE"] = "Bitande kallt"
+		ret["en-US"] = "Bitterly cold"
+	} else if at < -15 {
+		ret["sv-SE"] = "Mycket kallt"
+		ret["en-US"] = "Very cold"
+	} else if at < -5 {
+		ret["sv-SE"] = "Kallt"
+		ret["en-US"] = "Cold"
+	} else if at < 5 {
+		ret["sv-SE"] = "Kyligt"
+		ret["en-US"] = "Chilly"
+	} else if at < 15 {
+		ret["sv-SE"] = "Svalt"
+		ret["en-US"] = "Cool"
+	} else if at < 25 {
+		ret["sv-SE"] = "Behagligt"
+		ret["en-US"] = "Comfortable"
+	} else if at < 30 {
+		ret["sv-SE"] = "Varmt"
+		ret["en-US"] = "Warm"
+	} else if at < 38 {
+		ret["sv-SE"] = "Tryckande"
+		ret["en-US"] = "Oppressive"
+	} else {
+		ret["sv-SE"] = "Farligt varmt"
+		ret["en-US"] = "Dangerously hot"
+	}
+	return ret
+}
@@ -0,0 +1,204 @@
+package smhi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// snowParameterNames maps the parameter names of the snow1g version 1
+// schema to the names of the pmp3g version 2 schema.
+var snowParameterNames = map[string]string{
+	"air_pressure_at_mean_sea_level":            "msl",
+	"air_temperature":                           "t",
+	"visibility_in_air":                         "vis",
+	"wind_from_direction":                       "wd",
+	"wind_speed":                                "ws",
+	"relative_humidity":                         "r",
+	"thunderstorm_probability":                  "tstm",
+	"cloud_area_fraction":                       "tcc_mean",
+	"low_type_cloud_area_fraction":              "lcc_mean",
+	"medium_type_cloud_area_fraction":           "mcc_mean",
+	"high_type_cloud_area_fraction":             "hcc_mean",
+	"wind_speed_of_gust":                        "gust",
+	"precipitation_amount_min":                  "pmin",
+	"precipitation_amount_max":                  "pmax",
+	"precipitation_frozen_part":                 "spp",
+	"predominant_precipitation_type_at_surface": "pcat",
+	"precipitation_amount_mean":                 "pmean",
+	"precipitation_amount_median":               "pmedian",
+	"symbol_code":                               "Wsymb2",
+}
+
+// pointForecastStepAPI is a time step of PointForecastAPI.
+type pointForecastStepAPI = struct {
+	ValidTime  string
+	Parameters []pointForecastParameterAPI
+}
+
+// pointForecastParameterAPI is a parameter of a time step of
+// PointForecastAPI.
+type pointForecastParameterAPI = struct {
+	Name      string
+	LevelType string
+	Level     uint8
+	Unit      string
+	Values    []float64
+}
+
+// decodedStepAPI is a time step in either the pmp3g version 2 schema or
+// the snow1g version 1 schema, where the parameters of each time step are
+// given as an object keyed by their names.
+type decodedStepAPI struct {
+	ValidTime  string
+	Parameters []pointForecastParameterAPI
+	Time       string
+	Data       map[string]float64
+}
+
+// toStep converts the time step to the pmp3g version 2 schema, parameters
+// that aren't known by the package keep their names.
+func (s *decodedStepAPI) toStep() pointForecastStepAPI {
+	if s.Data == nil {
+		return pointForecastStepAPI{ValidTime: s.ValidTime, Parameters: s.Parameters}
+	}
+
+	names := make([]string, 0, len(s.Data))
+	for name := range s.Data {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	ret := pointForecastStepAPI{ValidTime: s.Time}
+	for _, name := range names {
+		p := pointForecastParameterAPI{Name: name, Values: []float64{s.Data[name]}}
+		if n, ok := snowParameterNames[name]; ok {
+			p.Name = n
+		}
+		ret.Parameters = append(ret.Parameters, p)
+	}
+
+	return ret
+}
+
+// decodePointForecast decodes a point forecast in either the pmp3g
+// version 2 schema or the snow1g version 1 schema, which is detected by
+// the structure of the time steps. The time steps are decoded one at a
+// time from the reader, so the whole response is never held in memory.
+func decodePointForecast(r io.Reader) (*PointForecastAPI, error) {
+	var err error
+
+	dec := json.NewDecoder(r)
+	if err = expectDelim(dec, '{'); err != nil {
+		return nil, err
+	}
+
+	var ret PointForecastAPI
+	for dec.More() {
+		var tok json.Token
+		if tok, err = dec.Token(); err != nil {
+			return nil, err
+		}
+		key, _ := tok.(string)
+
+		// The keys are matched case insensitively, the same way as by
+		// json.Unmarshal.
+		switch strings.ToLower(key) {
+		case "approvedtime", "createdtime":
+			err = dec.Decode(&ret.ApprovedTime)
+			break
+		case "referencetime":
+			err = dec.Decode(&ret.ReferenceTime)
+			break
+		case "geometry":
+			ret.Geometry, err = decodeGeometry(dec)
+			break
+		case "timeseries":
+			err = decodeTimeSeries(dec, &ret)
+			break
+		default:
+			var skip json.RawMessage
+			err = dec.Decode(&skip)
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err = expectDelim(dec, '}'); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// decodeGeometry decodes a geometry whose coordinates are either a single
+// coordinate or a list of them.
+func decodeGeometry(dec *json.Decoder) (Geometry, error) {
+	var err error
+
+	var g struct {
+		Type        string
+		Coordinates json.RawMessage
+	}
+	if err = dec.Decode(&g); err != nil {
+		return Geometry{}, err
+	}
+
+	ret := Geometry{Type: g.Type}
+	if len(g.Coordinates) == 0 || string(g.Coordinates) == "null" {
+		return ret, nil
+	}
+
+	var c Coordinate
+	if err = json.Unmarshal(g.Coordinates, &c); err == nil && len(c) > 0 {
+		ret.Coordinates = []Coordinate{c}
+	} else if err = json.Unmarshal(g.Coordinates, &ret.Coordinates); err != nil {
+		return Geometry{}, err
+	}
+
+	return ret, nil
+}
+
+// decodeTimeSeries decodes the time steps of the time series one at a
+// time into the forecast.
+func decodeTimeSeries(dec *json.Decoder, pf *PointForecastAPI) error {
+	var err error
+
+	var tok json.Token
+	if tok, err = dec.Token(); err != nil {
+		return err
+	}
+	if tok == nil {
+		return nil
+	}
+	if d, ok := tok.(json.Delim); !ok || d != '[' {
+		return fmt.Errorf("smhi: unexpected %v in the time series", tok)
+	}
+
+	for dec.More() {
+		var s decodedStepAPI
+		if err = dec.Decode(&s); err != nil {
+			return err
+		}
+		pf.TimeSeries = append(pf.TimeSeries, s.toStep())
+	}
+
+	return expectDelim(dec, ']')
+}
+
+// expectDelim reads the next token and returns an error unless it's the
+// given delimiter.
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if d, ok := tok.(json.Delim); !ok || d != delim {
+		return fmt.Errorf("smhi: expected %v but got %v", delim, tok)
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package smhi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// testSnowForecastJSON is a point forecast in the snow1g version 1 schema
+// with two time steps.
+const testSnowForecastJSON = `{
+	"createdTime": "2024-05-01T10:00:00Z",
+	"referenceTime": "2024-05-01T09:00:00Z",
+	"geometry": {"type": "Point", "coordinates": [18.0686, 59.3293]},
+	"timeSeries": [
+		{
+			"time": "2024-05-01T11:00:00Z",
+			"data": {"air_temperature": -1.5, "wind_speed": 4.2, "wind_from_direction": 270, "precipitation_frozen_part": 100, "symbol_code": 15, "unknown_parameter": 1}
+		},
+		{
+			"time": "2024-05-01T12:00:00Z",
+			"data": {"air_temperature": -0.8, "wind_speed": 5, "wind_from_direction": 265, "precipitation_frozen_part": 50, "symbol_code": 17}
+		}
+	]
+}`
+
+// parameterValues returns the first values of the parameters of the step
+// by name.
+func parameterValues(pf *PointForecastAPI, step int) map[string]float64 {
+	ret := make(map[string]float64)
+	for _, p := range pf.TimeSeries[step].Parameters {
+		if len(p.Values) > 0 {
+			ret[p.Name] = p.Values[0]
+		}
+	}
+	return ret
+}
+
+func TestDecodePointForecast(t *testing.T) {
+	tests := []struct {
+		name  string
+		json  string
+		first map[string]float64
+	}{
+		{"pmp3g", testForecastJSON, map[string]float64{"t": 12.5, "ws": 4.2, "wd": 270, "Wsymb2": 3}},
+		{"snow1g", testSnowForecastJSON, map[string]float64{"t": -1.5, "ws": 4.2, "wd": 270, "spp": 100, "Wsymb2": 15, "unknown_parameter": 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pf, err := decodePointForecast(strings.NewReader(tt.json))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if pf.ApprovedTime != "2024-05-01T10:00:00Z" || pf.ReferenceTime != "2024-05-01T09:00:00Z" {
+				t.Errorf("got approved time %q and reference time %q", pf.ApprovedTime, pf.ReferenceTime)
+			}
+			if lon, lat := pf.Geometry.point(); lon != 18.0686 || lat != 59.3293 {
+				t.Errorf("got point %v, %v", lon, lat)
+			}
+			if len(pf.TimeSeries) != 2 {
+				t.Fatalf("got %d time steps, want 2", len(pf.TimeSeries))
+			}
+			if got := pf.TimeSeries[1].ValidTime; got != "2024-05-01T12:00:00Z" {
+				t.Errorf("got valid time %q, want 2024-05-01T12:00:00Z", got)
+			}
+
+			got := parameterValues(pf, 0)
+			if len(got) != len(tt.first) {
+				t.Errorf("got parameters %v, want %v", got, tt.first)
+			}
+			for name, want := range tt.first {
+				if v, ok := got[name]; !ok || v != want {
+					t.Errorf("got %s %v, want %v", name, v, want)
+				}
+			}
+		})
+	}
+}
+
+func TestDecodePointForecastSkipsUnknownKeys(t *testing.T) {
+	pf, err := decodePointForecast(strings.NewReader(`{
+		"comment": {"nested": [1, 2, {"deep": true}]},
+		"APPROVEDTIME": "2024-05-01T10:00:00Z",
+		"geometry": {"type": "Point", "coordinates": null},
+		"timeSeries": null,
+		"extra": "value"
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if pf.ApprovedTime != "2024-05-01T10:00:00Z" {
+		t.Errorf("got approved time %q", pf.ApprovedTime)
+	}
+	if pf.Geometry.Type != "Point" || len(pf.Geometry.Coordinates) != 0 {
+		t.Errorf("got geometry %+v", pf.Geometry)
+	}
+	if len(pf.TimeSeries) != 0 {
+		t.Errorf("got %d time steps, want none", len(pf.TimeSeries))
+	}
+}
+
+func TestDecodePointForecastErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+	}{
+		{"empty", ``},
+		{"not an object", `[]`},
+		{"truncated", `{"approvedTime": "2024-05-01T10:00:00Z", "timeSeries": [{"validTime": "2024-05-01T11:00:00Z"`},
+		{"time series that isn't a list", `{"timeSeries": {}}`},
+		{"bad step", `{"timeSeries": [{"data": {"t": "warm"}}]}`},
+		{"bad coordinates", `{"geometry": {"coordinates": "here"}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := decodePointForecast(strings.NewReader(tt.json)); err == nil {
+				t.Error("got no error")
+			}
+		})
+	}
+}
+
+func TestGetPointForecastSnow(t *testing.T) {
+	var path string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		w.Write([]byte(testSnowForecastJSON))
+	}))
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL), WithForecastCategory(CategorySNOW1G, 1))
+	pf, err := c.GetPointForecast(18.0686, 59.3293)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := "/api/category/snow1g/version/1/geotype/point/lon/18.068600/lat/59.329300/data.json"; path != want {
+		t.Errorf("got path %s, want %s", path, want)
+	}
+	if len(pf.TimeSeries) != 2 {
+		t.Fatalf("got %d time steps, want 2", len(pf.TimeSeries))
+	}
+	if f := pf.TimeSeries[0]; f.AirTemperature != -1.5 || f.WindSpeed != 4.2 || f.WindDirection != 270 || f.PercentOfPrecipitationInFrozenForm != Some[int8](100) {
+		t.Errorf("got t %v, ws %v, wd %v and spp %v", f.AirTemperature, f.WindSpeed, f.WindDirection, f.PercentOfPrecipitationInFrozenForm)
+	}
+}
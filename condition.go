@@ -0,0 +1,161 @@
+package smhi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// conditionOperators holds the comparison operators of conditions, the
+// two character operators are listed first so that they match first.
+var conditionOperators = []string{">=", "<=", "==", "!=", ">", "<"}
+
+// Condition is a condition on the parameters of a forecast, such as
+// "tstm>30 within 12h" or "t<0 and ws>=10 or gust>20". A condition is
+// made of comparisons of an SMHI parameter name and a value, which are
+// combined with "and" and "or" where "and" binds harder. The optional
+// "within" duration limits the time steps to the ones that are at most
+// that far ahead. The comparisons of the parameters that are absent from
+// a time step, such as spp when there is no precipitation, don't match.
+type Condition struct {
+	expr   string
+	any    [][]comparison
+	within time.Duration
+}
+
+// comparison compares a parameter to a value.
+type comparison struct {
+	name  string
+	op    string
+	value float64
+}
+
+// ParseCondition parses the condition expression.
+func ParseCondition(expr string) (*Condition, error) {
+	var err error
+
+	ret := &Condition{expr: expr}
+	s := strings.ToLower(strings.TrimSpace(expr))
+
+	if i := strings.LastIndex(s, " within "); i >= 0 {
+		if ret.within, err = time.ParseDuration(strings.TrimSpace(s[i+len(" within "):])); err != nil {
+			return nil, fmt.Errorf("smhi: invalid condition %q: %w", expr, err)
+		}
+		s = s[:i]
+	}
+
+	for _, or := range strings.Split(s, " or ") {
+		var all []comparison
+		for _, and := range strings.Split(or, " and ") {
+			var c comparison
+			if c, err = parseComparison(strings.TrimSpace(and)); err != nil {
+				return nil, fmt.Errorf("smhi: invalid condition %q: %w", expr, err)
+			}
+			all = append(all, c)
+		}
+		ret.any = append(ret.any, all)
+	}
+
+	return ret, nil
+}
+
+// parseComparison parses a comparison such as "tstm>30".
+func parseComparison(s string) (comparison, error) {
+	var err error
+
+	for _, op := range conditionOperators {
+		i := strings.Index(s, op)
+		if i < 0 {
+			continue
+		}
+
+		// Parameter names are matched regardless of case, since the
+		// expression is lower cased.
+		name := strings.TrimSpace(s[:i])
+		c := comparison{op: op}
+		for _, n := range parameterNames {
+			if strings.EqualFold(n, name) {
+				c.name = n
+			}
+		}
+		if c.name == "" {
+			return c, fmt.Errorf("unknown parameter %q", name)
+		}
+		if c.value, err = strconv.ParseFloat(strings.TrimSpace(s[i+len(op):]), 64); err != nil {
+			return c, err
+		}
+
+		return c, nil
+	}
+
+	return comparison{}, fmt.Errorf("no operator in %q", s)
+}
+
+// String returns the expression of the condition.
+func (c *Condition) String() string {
+	return c.expr
+}
+
+// Match returns the first time step of the forecast that matches the
+// condition, only the time steps from now and within the duration of the
+// condition are considered.
+func (c *Condition) Match(pf *PointForecast, now time.Time) (*Forecast, bool) {
+	for i := range pf.TimeSeries {
+		// Keep the time step of the current hour.
+		f := &pf.TimeSeries[i]
+		if f.Timestamp.Add(time.Hour).Before(now) {
+			continue
+		}
+		if c.within > 0 && f.Timestamp.After(now.Add(c.within)) {
+			break
+		}
+
+		if c.matches(f) {
+			return f, true
+		}
+	}
+
+	return nil, false
+}
+
+// matches returns true if the time step matches the condition.
+func (c *Condition) matches(f *Forecast) bool {
+next:
+	for _, all := range c.any {
+		for _, cmp := range all {
+			if !cmp.matches(f) {
+				continue next
+			}
+		}
+		return true
+	}
+
+	return false
+}
+
+// matches returns true if the time step matches the comparison, which it
+// never does if the parameter is absent from the time step.
+func (cmp comparison) matches(f *Forecast) bool {
+	v, ok := f.Value(cmp.name)
+	if !ok {
+		return false
+	}
+
+	switch cmp.op {
+	case ">=":
+		return v >= cmp.value
+	case "<=":
+		return v <= cmp.value
+	case "==":
+		return v == cmp.value
+	case "!=":
+		return v != cmp.value
+	case ">":
+		return v > cmp.value
+	case "<":
+		return v < cmp.value
+	}
+
+	return false
+}
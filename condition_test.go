@@ -0,0 +1,36 @@
+package smhi
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConditionAbsentParameter(t *testing.T) {
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	dry := Forecast{Timestamp: now, AirTemperature: 12}
+	snow := Forecast{Timestamp: now.Add(time.Hour), AirTemperature: -1, PercentOfPrecipitationInFrozenForm: Some[int8](20)}
+
+	tests := []struct {
+		expr string
+		ts   []Forecast
+		want bool
+	}{
+		{"spp<50", []Forecast{dry}, false},
+		{"spp!=100", []Forecast{dry}, false},
+		{"spp<50", []Forecast{dry, snow}, true},
+		{"spp<50 or t>10", []Forecast{dry}, true},
+		{"spp<50 and t>10", []Forecast{dry}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			c, err := ParseCondition(tt.expr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, ok := c.Match(&PointForecast{TimeSeries: tt.ts}, now); ok != tt.want {
+				t.Errorf("got %v, want %v", ok, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package smhi
+
+import (
+	"net/http"
+	"sync"
+)
+
+// validatorStore holds the latest responses with validators per URL.
+type validatorStore struct {
+	mu        sync.Mutex
+	responses map[string]validatedResponse
+}
+
+// validatedResponse is the body and the header of a response with an ETag
+// or a Last-Modified header.
+type validatedResponse struct {
+	data   []byte
+	header http.Header
+}
+
+// WithConditionalRequests makes the client remember the ETag and the
+// Last-Modified headers of the responses per URL, and send them with
+// If-None-Match and If-Modified-Since when the URL is fetched again. The
+// remembered body is used when the API responds with 304 Not Modified,
+// which saves the bandwidth of pollers. The bodies are kept in memory for
+// as long as the client is, one per URL.
+func WithConditionalRequests() Option {
+	return func(c *Client) {
+		c.validators = &validatorStore{}
+	}
+}
+
+// prepare adds the validators of the remembered response of the URL to the
+// request, if there is one.
+func (s *validatorStore) prepare(url string, req *http.Request) {
+	s.mu.Lock()
+	r, ok := s.responses[url]
+	s.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	if etag := r.header.Get("ETag"); etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	if lm := r.header.Get("Last-Modified"); lm != "" {
+		req.Header.Set("If-Modified-Since", lm)
+	}
+}
+
+// notModified returns the remembered body of the URL and its header, which
+// is updated with the header of the 304 Not Modified response.
+func (s *validatorStore) notModified(url string, header http.Header) ([]byte, http.Header, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, ok := s.responses[url]
+	if !ok {
+		return nil, nil, false
+	}
+
+	ret := r.header.Clone()
+	for k, v := range header {
+		ret[k] = v
+	}
+	r.header = ret
+	s.responses[url] = r
+
+	return r.data, ret, true
+}
+
+// store remembers the response of the URL if it has a validator.
+func (s *validatorStore) store(url string, data []byte, header http.Header) {
+	if header.Get("ETag") == "" && header.Get("Last-Modified") == "" {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.responses == nil {
+		s.responses = make(map[string]validatedResponse)
+	}
+	s.responses[url] = validatedResponse{data, header}
+}
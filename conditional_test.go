@@ -0,0 +1,161 @@
+package smhi
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// validatingServer returns a test server that responds with the forecast
+// and the header, or with 304 Not Modified when the request has a
+// matching validator, along with the conditional headers of the requests
+// that it has served.
+func validatingServer(header http.Header) (*httptest.Server, *[]http.Header) {
+	var requests []http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, http.Header{
+			"If-None-Match":     r.Header.Values("If-None-Match"),
+			"If-Modified-Since": r.Header.Values("If-Modified-Since"),
+		})
+
+		for k, v := range header {
+			w.Header()[k] = v
+		}
+		if etag := header.Get("ETag"); etag != "" && r.Header.Get("If-None-Match") == etag {
+			w.Header().Set("Expires", "Wed, 01 May 2024 12:00:00 GMT")
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		if lm := header.Get("Last-Modified"); lm != "" && r.Header.Get("If-Modified-Since") == lm {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Write([]byte(testForecastJSON))
+	}))
+	return srv, &requests
+}
+
+func TestConditionalRequests(t *testing.T) {
+	lastModified := "Wed, 01 May 2024 10:00:00 GMT"
+
+	tests := []struct {
+		name   string
+		header http.Header
+		want   http.Header
+	}{
+		{"etag", http.Header{"Etag": {`"v1"`}}, http.Header{"If-None-Match": {`"v1"`}}},
+		{"last modified", http.Header{"Last-Modified": {lastModified}}, http.Header{"If-Modified-Since": {lastModified}}},
+		{"both", http.Header{"Etag": {`"v1"`}, "Last-Modified": {lastModified}}, http.Header{"If-None-Match": {`"v1"`}, "If-Modified-Since": {lastModified}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, requests := validatingServer(tt.header)
+			defer srv.Close()
+
+			var journal bytes.Buffer
+			c := NewClient(WithBaseURL(srv.URL), WithConditionalRequests(), WithJournal(NewJournal(&journal)))
+			first, err := c.GetPointForecast(18.0686, 59.3293)
+			if err != nil {
+				t.Fatal(err)
+			}
+			second, err := c.GetPointForecast(18.0686, 59.3293)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(*requests) != 2 {
+				t.Fatalf("got %d requests, want 2", len(*requests))
+			}
+			if got := (*requests)[0]; got.Get("If-None-Match") != "" || got.Get("If-Modified-Since") != "" {
+				t.Errorf("the first request had validators %v", got)
+			}
+			for k := range tt.want {
+				if got := (*requests)[1].Get(k); got != tt.want.Get(k) {
+					t.Errorf("got %s %q, want %q", k, got, tt.want.Get(k))
+				}
+			}
+
+			if len(second.TimeSeries) != len(first.TimeSeries) || second.TimeSeries[0].AirTemperature != first.TimeSeries[0].AirTemperature {
+				t.Error("the remembered forecast wasn't returned")
+			}
+			if second.Meta.ETag != first.Meta.ETag || !second.Meta.LastModified.Equal(first.Meta.LastModified) {
+				t.Errorf("got ETag %q and Last-Modified %s, want %q and %s", second.Meta.ETag, second.Meta.LastModified, first.Meta.ETag, first.Meta.LastModified)
+			}
+
+			var entries []JournalEntry
+			for dec := json.NewDecoder(&journal); dec.More(); {
+				var e JournalEntry
+				if err = dec.Decode(&e); err != nil {
+					t.Fatal(err)
+				}
+				entries = append(entries, e)
+			}
+			if len(entries) != 2 || entries[1].Cache != CacheRevalidated {
+				t.Errorf("got journal entries %+v, want the second one revalidated", entries)
+			}
+		})
+	}
+}
+
+func TestConditionalRequestsMergeHeader(t *testing.T) {
+	srv, _ := validatingServer(http.Header{"Etag": {`"v1"`}})
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL), WithConditionalRequests())
+	if _, err := c.GetPointForecast(18.0686, 59.3293); err != nil {
+		t.Fatal(err)
+	}
+	pf, err := c.GetPointForecast(18.0686, 59.3293)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The 304 response updates the expiry, but keeps the validator.
+	if want := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC); !pf.Meta.Expires.Equal(want) {
+		t.Errorf("got Expires %s, want %s", pf.Meta.Expires, want)
+	}
+	if pf.Meta.ETag != `"v1"` {
+		t.Errorf("got ETag %q, want %q", pf.Meta.ETag, `"v1"`)
+	}
+}
+
+func TestConditionalRequestsWithoutValidators(t *testing.T) {
+	srv, requests := validatingServer(nil)
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL), WithConditionalRequests())
+	for i := 0; i < 2; i++ {
+		if _, err := c.GetPointForecast(18.0686, 59.3293); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(c.validators.responses) != 0 {
+		t.Errorf("got %d remembered responses, want none", len(c.validators.responses))
+	}
+	for i, r := range *requests {
+		if r.Get("If-None-Match") != "" || r.Get("If-Modified-Since") != "" {
+			t.Errorf("request %d had validators %v", i, r)
+		}
+	}
+}
+
+func TestConditionalRequestsDisabled(t *testing.T) {
+	srv, requests := validatingServer(http.Header{"Etag": {`"v1"`}})
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL))
+	for i := 0; i < 2; i++ {
+		if _, err := c.GetPointForecast(18.0686, 59.3293); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got := (*requests)[1].Get("If-None-Match"); got != "" {
+		t.Errorf("got If-None-Match %q, want none", got)
+	}
+}
@@ -0,0 +1,72 @@
+package smhi
+
+import (
+	"time"
+)
+
+// Change is a parameter of a time step that differs between two forecast
+// runs.
+type Change struct {
+	Timestamp time.Time
+	Parameter string
+	Old       float64
+	New       float64
+}
+
+// Diff returns the parameters that changed from the old to the new
+// forecast run, in the order of the time steps of the new run. Only the
+// time steps that are in both runs are compared, and time steps with
+// equal hashes are skipped.
+func Diff(old, new *PointForecast) []Change {
+	steps := make(map[time.Time]*Forecast)
+	for i := range old.TimeSeries {
+		steps[old.TimeSeries[i].Timestamp] = &old.TimeSeries[i]
+	}
+
+	var ret []Change
+	for i := range new.TimeSeries {
+		n := &new.TimeSeries[i]
+		o, ok := steps[n.Timestamp]
+		if !ok || (n.Hash != "" && n.Hash == o.Hash) {
+			continue
+		}
+
+		for _, name := range parameterNames {
+			ov, _ := o.Value(name)
+			nv, _ := n.Value(name)
+			if ov != nv {
+				ret = append(ret, Change{n.Timestamp, name, ov, nv})
+			}
+		}
+	}
+
+	return ret
+}
+
+// Merge returns a forecast with the time steps of the new forecast run,
+// followed by the time steps of the old run that are later than the last
+// one of the new run. The rest of the forecast is taken from the new run,
+// so a refresh that returns a shorter series doesn't leave a gap at its
+// end. Either run may be nil.
+func Merge(old, new *PointForecast) *PointForecast {
+	if new == nil {
+		return old
+	}
+	ret := *new
+	if old == nil || len(new.TimeSeries) == 0 {
+		if old != nil {
+			ret.TimeSeries = append([]Forecast(nil), old.TimeSeries...)
+		}
+		return &ret
+	}
+
+	last := new.TimeSeries[len(new.TimeSeries)-1].Timestamp
+	ret.TimeSeries = append([]Forecast(nil), new.TimeSeries...)
+	for _, f := range old.TimeSeries {
+		if f.Timestamp.After(last) {
+			ret.TimeSeries = append(ret.TimeSeries, f)
+		}
+	}
+
+	return &ret
+}
@@ -0,0 +1,286 @@
+package smhi
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+const (
+	observationsArchivePath = "%s/parameter/%d/station/%d/period/corrected-archive/data.csv"
+)
+
+// ArchiveDownload identifies a corrected archive to download.
+type ArchiveDownload struct {
+	Parameter int
+	Station   int
+}
+
+// DownloadProgress reports the progress of a download.
+type DownloadProgress struct {
+	Item       ArchiveDownload
+	BytesDone  int64
+	BytesTotal int64
+	ItemsDone  int
+	ItemsTotal int
+}
+
+// Downloader downloads the corrected archive CSV files of observation
+// stations into an archive. Files that have already been downloaded and
+// match their checksum are skipped, and interrupted downloads are resumed
+// where they left off.
+type Downloader struct {
+	// Client is used to download the files, the default client is used
+	// if it's nil.
+	Client *Client
+
+	// Archive is where the files are written.
+	Archive *Archive
+
+	// Concurrency is the number of files that are downloaded at the same
+	// time, one if it's zero.
+	Concurrency int
+
+	// Attempts is the number of times each file is attempted, one if
+	// it's zero.
+	Attempts int
+
+	// Progress is called with the progress of the downloads if it's set,
+	// it may be called concurrently.
+	Progress func(p DownloadProgress)
+}
+
+// Download downloads the corrected archives of the given items, the
+// remaining items are still downloaded if one of them fails.
+func (d *Downloader) Download(ctx context.Context, items []ArchiveDownload) error {
+	c := d.Client
+	if c == nil {
+		c = defaultClient
+	}
+	concurrency := d.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	attempts := d.Attempts
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	var done, failed int
+	var firstErr error
+
+	sem := make(chan struct{}, concurrency)
+	for _, it := range items {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return ctx.Err()
+		}
+
+		wg.Add(1)
+		go func(it ArchiveDownload) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			progress := func(bytesDone, bytesTotal int64) {
+				if d.Progress == nil {
+					return
+				}
+				mu.Lock()
+				p := DownloadProgress{it, bytesDone, bytesTotal, done, len(items)}
+				mu.Unlock()
+				d.Progress(p)
+			}
+
+			url := fmt.Sprintf(observationsArchivePath, metobsURL, it.Parameter, it.Station)
+			path := d.Archive.ObservationsPath(it.Parameter, it.Station)
+
+			var err error
+			for i := 0; i < attempts; i++ {
+				if err = c.download(ctx, url, path, progress); err == nil || ctx.Err() != nil {
+					break
+				}
+			}
+
+			mu.Lock()
+			done++
+			if err != nil {
+				failed++
+				if firstErr == nil {
+					firstErr = err
+				}
+			}
+			mu.Unlock()
+		}(it)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return fmt.Errorf("smhi: %d of %d downloads failed: %w", failed, len(items), firstErr)
+	}
+	return nil
+}
+
+// DownloadFile downloads the file at the URL, such as a GRIB file or a
+// radar image, into the file at path using the default client.
+func DownloadFile(ctx context.Context, url, path string, progress func(done, total int64)) error {
+	return defaultClient.DownloadFile(ctx, url, path, progress)
+}
+
+// DownloadFile downloads the file at the URL, such as a GRIB file or a
+// radar image, into the file at path. Progress is called with the bytes
+// that are done and the total if it's set, the total is -1 when it isn't
+// known. Interrupted downloads are resumed and finished ones are skipped
+// the same way as by the Downloader. The responses other than 200 OK and
+// 206 Partial Content are returned as an APIError.
+func (c *Client) DownloadFile(ctx context.Context, url, path string, progress func(done, total int64)) error {
+	return c.download(ctx, url, path, progress)
+}
+
+// download fetches the URL into the file at path. The data is written to
+// a partial file first, which is resumed with a range request on the next
+// attempt. A SHA-256 checksum is stored next to the finished file, and the
+// download is skipped if the file matches it.
+func (c *Client) download(ctx context.Context, url, path string, progress func(done, total int64)) error {
+	var err error
+
+	if ok, _ := verifyChecksum(path); ok {
+		return nil
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	// Continue from the partial file if there is one.
+	part := path + ".part"
+	var offset int64
+	if fi, err := os.Stat(part); err == nil {
+		offset = fi.Size()
+	}
+
+	var req *http.Request
+	if req, err = http.NewRequest(http.MethodGet, url, nil); err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+
+	var res *http.Response
+	if res, err = c.do(req); err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	// Start over if the server doesn't support the range request.
+	flags := os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	switch res.StatusCode {
+	case http.StatusPartialContent:
+		break
+	case http.StatusOK:
+		offset = 0
+		flags |= os.O_TRUNC
+		break
+	case http.StatusRequestedRangeNotSatisfiable:
+		os.Remove(part)
+		fallthrough
+	default:
+		body, _ := ioutil.ReadAll(res.Body)
+		return newAPIError(url, res, body)
+	}
+
+	var total int64 = -1
+	if res.ContentLength >= 0 {
+		total = offset + res.ContentLength
+	}
+
+	var f *os.File
+	if f, err = os.OpenFile(part, flags, 0644); err != nil {
+		return err
+	}
+
+	w := &progressWriter{w: f, done: offset, total: total, progress: progress}
+	if _, err = io.Copy(w, res.Body); err != nil {
+		f.Close()
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+
+	if err = os.Rename(part, path); err != nil {
+		return err
+	}
+	return writeChecksum(path)
+}
+
+// progressWriter reports the progress of the data written through it.
+type progressWriter struct {
+	w        io.Writer
+	done     int64
+	total    int64
+	progress func(done, total int64)
+}
+
+// Write writes p to the underlying writer and reports the progress.
+func (pw *progressWriter) Write(p []byte) (int, error) {
+	n, err := pw.w.Write(p)
+	pw.done += int64(n)
+	if pw.progress != nil {
+		pw.progress(pw.done, pw.total)
+	}
+	return n, err
+}
+
+// fileChecksum returns the hex encoded SHA-256 checksum of the file.
+func fileChecksum(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err = io.Copy(h, f); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// writeChecksum stores the checksum of the file next to it.
+func writeChecksum(path string) error {
+	sum, err := fileChecksum(path)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path+".sha256", []byte(sum+"\n"), 0644)
+}
+
+// verifyChecksum returns true if the file matches its stored checksum.
+func verifyChecksum(path string) (bool, error) {
+	want, err := ioutil.ReadFile(path + ".sha256")
+	if err != nil {
+		return false, err
+	}
+
+	got, err := fileChecksum(path)
+	if err != nil {
+		return false, err
+	}
+
+	return strings.TrimSpace(string(want)) == got, nil
+}
@@ -0,0 +1,93 @@
+package smhi
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDownloadFile(t *testing.T) {
+	const content = "0123456789"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeContent(w, r, "grid.grib2", time.Time{}, strings.NewReader(content))
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "grid.grib2")
+
+	// The download resumes from the partial file.
+	if err := ioutil.WriteFile(path+".part", []byte(content[:4]), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var done, total int64
+	progress := func(d, t int64) {
+		done, total = d, t
+	}
+	if err := NewClient().DownloadFile(context.Background(), srv.URL, path, progress); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != content {
+		t.Errorf("got %q, want %q", data, content)
+	}
+	if done != int64(len(content)) || total != int64(len(content)) {
+		t.Errorf("got progress %d of %d, want %d of %d", done, total, len(content), len(content))
+	}
+	if ok, err := verifyChecksum(path); !ok || err != nil {
+		t.Errorf("got checksum %v, %v", ok, err)
+	}
+}
+
+func TestDownloadFileAPIError(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		partial bool
+	}{
+		{"not found", http.StatusNotFound, false},
+		{"server error", http.StatusInternalServerError, false},
+		{"invalid range", http.StatusRequestedRangeNotSatisfiable, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte("failed"))
+			}))
+			defer srv.Close()
+
+			path := filepath.Join(t.TempDir(), "grid.grib2")
+			if tt.partial {
+				if err := ioutil.WriteFile(path+".part", []byte("0123"), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			err := NewClient().DownloadFile(context.Background(), srv.URL, path, nil)
+
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("got error %v, want an APIError", err)
+			}
+			if apiErr.StatusCode != tt.status || apiErr.URL != srv.URL || string(apiErr.Body) != "failed" {
+				t.Errorf("got status %d, URL %s and body %q", apiErr.StatusCode, apiErr.URL, apiErr.Body)
+			}
+			if _, err := ioutil.ReadFile(path + ".part"); tt.partial && err == nil {
+				t.Error("the invalid partial file wasn't removed")
+			}
+		})
+	}
+}
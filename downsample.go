@@ -0,0 +1,220 @@
+package smhi
+
+import (
+	"math"
+	"time"
+)
+
+// AggFunc aggregates the values of the SMHI parameter with the given name
+// into a single value.
+type AggFunc func(name string, values []float64) float64
+
+// AggMean aggregates the values into their arithmetic mean.
+func AggMean(name string, values []float64) float64 {
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+// AggMin aggregates the values into their minimum.
+func AggMin(name string, values []float64) float64 {
+	ret := values[0]
+	for _, v := range values[1:] {
+		ret = math.Min(ret, v)
+	}
+	return ret
+}
+
+// AggMax aggregates the values into their maximum.
+func AggMax(name string, values []float64) float64 {
+	ret := values[0]
+	for _, v := range values[1:] {
+		ret = math.Max(ret, v)
+	}
+	return ret
+}
+
+// AggSum aggregates the values into their sum.
+func AggSum(name string, values []float64) float64 {
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum
+}
+
+// AggFirst aggregates the values into the first value.
+func AggFirst(name string, values []float64) float64 {
+	return values[0]
+}
+
+// AggLast aggregates the values into the last value.
+func AggLast(name string, values []float64) float64 {
+	return values[len(values)-1]
+}
+
+// AggMode aggregates the values into the most frequent value, the earliest
+// one wins if there is a tie. It is mainly useful for categorical
+// parameters such as Wsymb2 and pcat.
+func AggMode(name string, values []float64) float64 {
+	counts := make(map[float64]int)
+	ret, max := values[0], 0
+	for _, v := range values {
+		counts[v]++
+		if counts[v] > max {
+			ret, max = v, counts[v]
+		}
+	}
+	return ret
+}
+
+// AggDirection aggregates directions in degrees into their circular mean.
+func AggDirection(name string, values []float64) float64 {
+	var x, y float64
+	for _, v := range values {
+		x += math.Cos(v * math.Pi / 180)
+		y += math.Sin(v * math.Pi / 180)
+	}
+
+	ret := math.Atan2(y, x) * 180 / math.Pi
+	if ret < 0 {
+		ret += 360
+	}
+	return math.Round(ret)
+}
+
+// AggPerParameter returns an AggFunc that uses the aggregation that is
+// defined for the parameter in m, or def for parameters that aren't in m.
+func AggPerParameter(def AggFunc, m map[string]AggFunc) AggFunc {
+	return func(name string, values []float64) float64 {
+		if agg, ok := m[name]; ok {
+			return agg(name, values)
+		}
+		return def(name, values)
+	}
+}
+
+// AggDefault is an AggFunc that picks a sensible aggregation for each
+// parameter, extremes keep their extremes, directions are averaged as
+// angles, categories use the most frequent value and everything else is
+// averaged.
+var AggDefault = AggPerParameter(AggMean, map[string]AggFunc{
+	"wd":     AggDirection,
+	"tstm":   AggMax,
+	"gust":   AggMax,
+	"pmin":   AggMin,
+	"pmax":   AggMax,
+	"pcat":   AggMode,
+	"Wsymb2": AggMode,
+
+	"sea_surface_wave_from_direction": AggDirection,
+})
+
+// Downsample reduces the time series into steps of the given duration, the
+// values within each step are aggregated with agg. The timestamp of each
+// returned forecast is the start of its step. AggDefault is used if agg is
+// nil. The steps are uniform windows in UTC, DownsampleIn aligns them to
+// the clock of a location instead.
+func (pf *PointForecast) Downsample(step time.Duration, agg AggFunc) *PointForecast {
+	return pf.DownsampleIn(nil, step, agg)
+}
+
+// DownsampleIn reduces the time series like Downsample, with steps that are
+// aligned to the clock of the location when the step divides a day, such
+// as 6 hour steps that start at 00, 06, 12 and 18 local time. The steps
+// that span a change to or from daylight saving time are an hour shorter
+// or longer, so a 24 hour step is a calendar day of 23 or 25 hours. The
+// steps are uniform windows in UTC if loc is nil.
+func (pf *PointForecast) DownsampleIn(loc *time.Location, step time.Duration, agg AggFunc) *PointForecast {
+	if agg == nil {
+		agg = AggDefault
+	}
+
+	ret := &PointForecast{
+		ApprovedTime:  pf.ApprovedTime,
+		ReferenceTime: pf.ReferenceTime,
+		Geometry:      pf.Geometry,
+		Warnings:      pf.Warnings,
+		Meta:          pf.Meta,
+	}
+
+	// Aggregate each parameter within each bucket.
+	lon, lat := pf.Geometry.point()
+	for _, b := range bucketIn(pf.TimeSeries, step, loc) {
+		var f Forecast
+		f.Timestamp = windowStart(b[0].Timestamp, step, loc)
+
+		// The absent values are left out, and the parameters whose values
+		// are all absent are left absent.
+		values := make([]float64, 0, len(b))
+		for _, name := range parameterNames {
+			values = values[:0]
+			for i := range b {
+				if v, ok := b[i].Value(name); ok {
+					values = append(values, v)
+				}
+			}
+			if len(values) > 0 {
+				f.setValue(name, agg(name, values))
+			}
+		}
+
+		derive(&f, lon, lat)
+		if b[0].WeatherSymbolDescription != nil {
+			describe(&f)
+		}
+		f.Hash = getHash(&f)
+
+		ret.TimeSeries = append(ret.TimeSeries, f)
+	}
+
+	return ret
+}
+
+// bucket groups the time series into consecutive buckets of the given
+// step.
+func bucket(ts []Forecast, step time.Duration) [][]Forecast {
+	return bucketIn(ts, step, nil)
+}
+
+// bucketIn groups the time series into consecutive buckets of the given
+// step, aligned to the clock of the location as by windowStart.
+func bucketIn(ts []Forecast, step time.Duration, loc *time.Location) [][]Forecast {
+	var ret [][]Forecast
+	var start time.Time
+
+	for _, f := range ts {
+		t := windowStart(f.Timestamp, step, loc)
+		if len(ret) == 0 || !t.Equal(start) {
+			ret = append(ret, nil)
+			start = t
+		}
+		ret[len(ret)-1] = append(ret[len(ret)-1], f)
+	}
+
+	return ret
+}
+
+// windowStart returns the start of the window of the given step that the
+// time is in. The windows are aligned to the wall clock of the location
+// when the step divides a day, so that they follow the changes to and from
+// daylight saving time, and to UTC otherwise.
+func windowStart(t time.Time, step time.Duration, loc *time.Location) time.Time {
+	day := 24 * time.Hour
+	if loc == nil || step <= time.Hour || step > day || day%step != 0 {
+		return t.Truncate(step)
+	}
+
+	// The time since midnight on the wall clock, which time.Date maps back
+	// to the time of the location. It's given as hours, minutes and seconds
+	// since the nanoseconds overflow an int of 32 bits after two seconds.
+	local := t.In(loc)
+	wall := time.Duration(local.Hour())*time.Hour + time.Duration(local.Minute())*time.Minute +
+		time.Duration(local.Second())*time.Second
+	wall -= wall % step
+
+	h, m, sec := int(wall/time.Hour), int(wall%time.Hour/time.Minute), int(wall%time.Minute/time.Second)
+	return time.Date(local.Year(), local.Month(), local.Day(), h, m, sec, 0, loc)
+}
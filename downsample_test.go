@@ -0,0 +1,110 @@
+package smhi
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+// hourly returns an hourly time series of the given number of hours from
+// start, which has an air temperature of one in every step.
+func hourly(start time.Time, hours int) []Forecast {
+	var ret []Forecast
+	for i := 0; i < hours; i++ {
+		ret = append(ret, Forecast{Timestamp: start.Add(time.Duration(i) * time.Hour), AirTemperature: 1})
+	}
+	return ret
+}
+
+func mustParse(t *testing.T, s string) time.Time {
+	t.Helper()
+
+	ret, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ret
+}
+
+func TestWindowStart(t *testing.T) {
+	stockholm, err := time.LoadLocation("Europe/Stockholm")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		loc  *time.Location
+		step time.Duration
+		t    string
+		want string
+	}{
+		{"utc day", nil, 24 * time.Hour, "2024-03-31T12:34:00Z", "2024-03-31T00:00:00Z"},
+		{"utc 6h", nil, 6 * time.Hour, "2024-03-31T12:34:00Z", "2024-03-31T12:00:00Z"},
+		{"utc hour", stockholm, time.Hour, "2024-03-31T12:34:00Z", "2024-03-31T12:00:00Z"},
+		{"step that doesn't divide a day", stockholm, 5 * time.Hour, "2024-03-31T12:34:00Z", "2024-03-31T10:00:00Z"},
+		{"winter day", stockholm, 24 * time.Hour, "2024-01-15T12:00:00Z", "2024-01-14T23:00:00Z"},
+		{"summer day", stockholm, 24 * time.Hour, "2024-07-15T12:00:00Z", "2024-07-14T22:00:00Z"},
+		{"spring forward day", stockholm, 24 * time.Hour, "2024-03-31T12:00:00Z", "2024-03-30T23:00:00Z"},
+		{"day after spring forward", stockholm, 24 * time.Hour, "2024-03-31T22:30:00Z", "2024-03-31T22:00:00Z"},
+		{"spring forward 6h before change", stockholm, 6 * time.Hour, "2024-03-31T00:30:00Z", "2024-03-30T23:00:00Z"},
+		{"spring forward 6h after change", stockholm, 6 * time.Hour, "2024-03-31T03:00:00Z", "2024-03-30T23:00:00Z"},
+		{"spring forward second 6h", stockholm, 6 * time.Hour, "2024-03-31T04:00:00Z", "2024-03-31T04:00:00Z"},
+		{"fall back day", stockholm, 24 * time.Hour, "2024-10-27T12:00:00Z", "2024-10-26T22:00:00Z"},
+		{"day after fall back", stockholm, 24 * time.Hour, "2024-10-27T23:00:00Z", "2024-10-27T23:00:00Z"},
+		{"fall back 6h before change", stockholm, 6 * time.Hour, "2024-10-27T00:30:00Z", "2024-10-26T22:00:00Z"},
+		{"fall back 6h after change", stockholm, 6 * time.Hour, "2024-10-27T04:30:00Z", "2024-10-26T22:00:00Z"},
+		{"fall back second 6h", stockholm, 6 * time.Hour, "2024-10-27T05:00:00Z", "2024-10-27T05:00:00Z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := windowStart(mustParse(t, tt.t), tt.step, tt.loc)
+			if want := mustParse(t, tt.want); !got.Equal(want) {
+				t.Errorf("windowStart(%s, %s) = %s, want %s", tt.t, tt.step, got.UTC().Format(time.RFC3339), tt.want)
+			}
+		})
+	}
+}
+
+func TestDownsampleIn(t *testing.T) {
+	stockholm, err := time.LoadLocation("Europe/Stockholm")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The series start at local midnight of the day before the change and
+	// end at local midnight of the day after it, so there are three full
+	// calendar days.
+	tests := []struct {
+		name  string
+		loc   *time.Location
+		step  time.Duration
+		start string
+		hours int
+		want  []float64
+	}{
+		{"spring forward", stockholm, 24 * time.Hour, "2024-03-29T23:00:00Z", 71, []float64{24, 23, 24}},
+		{"fall back", stockholm, 24 * time.Hour, "2024-10-25T22:00:00Z", 73, []float64{24, 25, 24}},
+		{"spring forward 6h", stockholm, 6 * time.Hour, "2024-03-30T23:00:00Z", 23, []float64{5, 6, 6, 6}},
+		{"fall back 6h", stockholm, 6 * time.Hour, "2024-10-26T22:00:00Z", 25, []float64{7, 6, 6, 6}},
+		{"utc over spring forward", nil, 24 * time.Hour, "2024-03-30T00:00:00Z", 72, []float64{24, 24, 24}},
+		{"utc over fall back", nil, 24 * time.Hour, "2024-10-26T00:00:00Z", 72, []float64{24, 24, 24}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pf := &PointForecast{TimeSeries: hourly(mustParse(t, tt.start), tt.hours)}
+			ds := pf.DownsampleIn(tt.loc, tt.step, AggSum)
+
+			if len(ds.TimeSeries) != len(tt.want) {
+				t.Fatalf("got %d steps, want %d", len(ds.TimeSeries), len(tt.want))
+			}
+			for i, f := range ds.TimeSeries {
+				if f.AirTemperature != tt.want[i] {
+					t.Errorf("step %d at %s sums %v hours, want %v", i, f.Timestamp.UTC().Format(time.RFC3339), f.AirTemperature, tt.want[i])
+				}
+			}
+		})
+	}
+}
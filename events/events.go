@@ -0,0 +1,125 @@
+// Package events watches the SMHI point forecasts of locations and reports
+// when they cross the thresholds of rules.
+package events
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/osm/smhi"
+)
+
+// DefaultInterval is the time between two checks of the forecast unless
+// another interval is given to the Watcher.
+const DefaultInterval = 15 * time.Minute
+
+// Location is a location to watch.
+type Location struct {
+	Lon float64
+	Lat float64
+}
+
+// Rule is a threshold of an SMHI parameter, such as Param "ws", Op ">" and
+// Value 15. Window limits the time steps to the ones that are at most that
+// far ahead, all of the time steps are checked if it's zero.
+type Rule struct {
+	Param  string
+	Op     string
+	Value  float64
+	Window time.Duration
+}
+
+// String returns the rule as a condition expression.
+func (r Rule) String() string {
+	s := fmt.Sprintf("%s%s%g", r.Param, r.Op, r.Value)
+	if r.Window > 0 {
+		s += " within " + r.Window.String()
+	}
+	return s
+}
+
+// Event is sent when the forecast of a location newly crosses a rule,
+// Forecast is the first time step that crosses it.
+type Event struct {
+	Location Location
+	Rule     Rule
+	Time     time.Time
+	Forecast smhi.Forecast
+}
+
+// Watcher checks the forecasts of the subscribed locations periodically.
+type Watcher struct {
+	// Client is used to fetch the forecasts, a new client is used if
+	// it's nil.
+	Client *smhi.Client
+
+	// Interval is the time between two checks, DefaultInterval is used
+	// if it's zero.
+	Interval time.Duration
+
+	// OnError is called with the errors of the checks if it's set, the
+	// watcher keeps on checking after an error.
+	OnError func(err error)
+}
+
+// Subscribe watches the location with the default watcher.
+func Subscribe(ctx context.Context, loc Location, rule Rule) (<-chan Event, error) {
+	return (&Watcher{}).Subscribe(ctx, loc, rule)
+}
+
+// Subscribe watches the location and sends an event whenever the forecast
+// crosses the rule after not having crossed it, which includes the first
+// check. The channel is closed when the context is done.
+func (w *Watcher) Subscribe(ctx context.Context, loc Location, rule Rule) (<-chan Event, error) {
+	var err error
+
+	var cond *smhi.Condition
+	if cond, err = smhi.ParseCondition(rule.String()); err != nil {
+		return nil, err
+	}
+
+	c := w.Client
+	if c == nil {
+		c = smhi.NewClient(smhi.WithoutDescriptions())
+	}
+	interval := w.Interval
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+
+	ch := make(chan Event)
+	go func() {
+		defer close(ch)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		var crossed bool
+		for {
+			if pf, err := c.GetPointForecastContext(ctx, loc.Lon, loc.Lat); err != nil {
+				if w.OnError != nil {
+					w.OnError(err)
+				}
+			} else if f, ok := cond.Match(pf, time.Now()); !ok {
+				crossed = false
+			} else if !crossed {
+				crossed = true
+
+				select {
+				case ch <- Event{loc, rule, time.Now(), *f}:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch, nil
+}
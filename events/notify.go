@@ -0,0 +1,306 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/smtp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Alert is a message that is sent to the notifiers. Key tells what the
+// alert is about, such as a location and a rule, and is used to
+// deduplicate the alerts.
+type Alert struct {
+	Key     string
+	Title   string
+	Message string
+	Time    time.Time
+}
+
+// Notifier sends alerts, such as to a webhook, an MQTT broker, Slack or by
+// email.
+type Notifier interface {
+	Notify(ctx context.Context, a Alert) error
+}
+
+// NotifierFunc is a function that is used as a Notifier.
+type NotifierFunc func(ctx context.Context, a Alert) error
+
+// Notify calls the function.
+func (f NotifierFunc) Notify(ctx context.Context, a Alert) error {
+	return f(ctx, a)
+}
+
+// Dispatcher sends the alerts to all of its notifiers. The alerts with the
+// same key are only sent once per cool-down, so an alert that is raised
+// on every check isn't repeated.
+type Dispatcher struct {
+	Notifiers []Notifier
+
+	// Cooldown is the least time between two alerts with the same key,
+	// the alerts are only deduplicated by their keys and messages if
+	// it's zero.
+	Cooldown time.Duration
+
+	mu   sync.Mutex
+	sent map[string]sentAlert
+}
+
+// sentAlert is the message and the time of the latest alert with a key.
+type sentAlert struct {
+	message string
+	time    time.Time
+}
+
+// Notify sends the alert to all of the notifiers unless an alert with the
+// same key was sent within the cool-down, or with the same message if
+// there is no cool-down. The alert is sent to the rest of the notifiers
+// when one of them fails, and the first error is returned. An alert that
+// none of the notifiers could send isn't counted as sent, so it's sent
+// again the next time that it's raised.
+func (d *Dispatcher) Notify(ctx context.Context, a Alert) error {
+	if a.Time.IsZero() {
+		a.Time = time.Now()
+	}
+
+	d.mu.Lock()
+	if d.sent == nil {
+		d.sent = make(map[string]sentAlert)
+	}
+	prev, ok := d.sent[a.Key]
+	if ok && (a.Time.Sub(prev.time) < d.Cooldown || (d.Cooldown == 0 && prev.message == a.Message)) {
+		d.mu.Unlock()
+		return nil
+	}
+	// The alert is counted as sent while it's being sent, so that it isn't
+	// sent twice by concurrent calls.
+	sent := sentAlert{a.Message, a.Time}
+	d.sent[a.Key] = sent
+	d.mu.Unlock()
+
+	var errs []error
+	for _, n := range d.Notifiers {
+		if err := n.Notify(ctx, a); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+
+	// Roll back to the previous alert if all of the notifiers failed,
+	// unless another alert with the key has been sent since.
+	if len(errs) == len(d.Notifiers) {
+		d.mu.Lock()
+		if d.sent[a.Key] == sent {
+			if ok {
+				d.sent[a.Key] = prev
+			} else {
+				delete(d.sent, a.Key)
+			}
+		}
+		d.mu.Unlock()
+	}
+	return fmt.Errorf("events: %d of %d notifiers failed: %w", len(errs), len(d.Notifiers), errs[0])
+}
+
+// Webhook posts the alerts as JSON objects to a URL.
+type Webhook struct {
+	URL string
+
+	// Client is used for the requests, http.DefaultClient is used if
+	// it's nil.
+	Client *http.Client
+}
+
+// Notify posts the alert to the webhook.
+func (w *Webhook) Notify(ctx context.Context, a Alert) error {
+	return postJSON(ctx, w.Client, w.URL, a)
+}
+
+// Slack posts the alerts to an incoming webhook of Slack.
+type Slack struct {
+	WebhookURL string
+
+	// Client is used for the requests, http.DefaultClient is used if
+	// it's nil.
+	Client *http.Client
+}
+
+// Notify posts the alert to Slack.
+func (s *Slack) Notify(ctx context.Context, a Alert) error {
+	return postJSON(ctx, s.Client, s.WebhookURL, struct {
+		Text string `json:"text"`
+	}{fmt.Sprintf("*%s*\n%s", a.Title, a.Message)})
+}
+
+// postJSON posts the value as JSON to the URL.
+func postJSON(ctx context.Context, client *http.Client, url string, v interface{}) error {
+	var err error
+
+	var data []byte
+	if data, err = json.Marshal(v); err != nil {
+		return err
+	}
+
+	var req *http.Request
+	if req, err = http.NewRequest(http.MethodPost, url, bytes.NewReader(data)); err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	var res *http.Response
+	if res, err = client.Do(req); err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	io.Copy(ioutil.Discard, res.Body)
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("events: %s responded with %s", url, res.Status)
+	}
+	return nil
+}
+
+// Email sends the alerts by email through an SMTP server.
+type Email struct {
+	// Addr is the address of the SMTP server, such as
+	// "smtp.example.com:587".
+	Addr string
+
+	// Auth authenticates with the server if it's set, such as
+	// smtp.PlainAuth.
+	Auth smtp.Auth
+
+	From string
+	To   []string
+}
+
+// Notify sends the alert by email, the context isn't used since
+// net/smtp doesn't support it.
+func (e *Email) Notify(ctx context.Context, a Alert) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", e.From)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(e.To, ", "))
+	fmt.Fprintf(&b, "Subject: %s\r\n", a.Title)
+	fmt.Fprintf(&b, "Date: %s\r\n", a.Time.Format(time.RFC1123Z))
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	b.WriteString(strings.ReplaceAll(a.Message, "\n", "\r\n"))
+	b.WriteString("\r\n")
+
+	return smtp.SendMail(e.Addr, e.Auth, e.From, e.To, []byte(b.String()))
+}
+
+// MQTT publishes the alerts as JSON objects to a topic of an MQTT 3.1.1
+// broker, with QoS 0 and a new connection per alert.
+type MQTT struct {
+	// Broker is the address of the broker, such as
+	// "mqtt.example.com:1883".
+	Broker string
+
+	Topic    string
+	ClientID string
+	Username string
+	Password string
+}
+
+// ErrMQTTRefused is returned when the broker refuses the connection.
+var ErrMQTTRefused = errors.New("events: mqtt connection refused")
+
+// Notify publishes the alert to the topic.
+func (m *MQTT) Notify(ctx context.Context, a Alert) error {
+	var err error
+
+	var payload []byte
+	if payload, err = json.Marshal(a); err != nil {
+		return err
+	}
+
+	var d net.Dialer
+	var conn net.Conn
+	if conn, err = d.DialContext(ctx, "tcp", m.Broker); err != nil {
+		return err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	} else {
+		conn.SetDeadline(time.Now().Add(30 * time.Second))
+	}
+
+	// Connect with a clean session and the credentials, if any.
+	var connect []byte
+	connect = appendMQTTString(connect, "MQTT")
+	flags := byte(0x02)
+	if m.Username != "" {
+		flags |= 0x80
+	}
+	if m.Password != "" {
+		flags |= 0x40
+	}
+	connect = append(connect, 4, flags, 0, 60)
+	connect = appendMQTTString(connect, m.ClientID)
+	if m.Username != "" {
+		connect = appendMQTTString(connect, m.Username)
+	}
+	if m.Password != "" {
+		connect = appendMQTTString(connect, m.Password)
+	}
+	if _, err = conn.Write(mqttPacket(0x10, connect)); err != nil {
+		return err
+	}
+
+	// The broker acknowledges the connection with a return code.
+	ack := make([]byte, 4)
+	if _, err = io.ReadFull(conn, ack); err != nil {
+		return err
+	}
+	if ack[0] != 0x20 || ack[3] != 0 {
+		return ErrMQTTRefused
+	}
+
+	publish := append(appendMQTTString(nil, m.Topic), payload...)
+	if _, err = conn.Write(mqttPacket(0x30, publish)); err != nil {
+		return err
+	}
+
+	_, err = conn.Write([]byte{0xe0, 0})
+	return err
+}
+
+// mqttPacket returns an MQTT packet of the type and the body, with the
+// remaining length encoded in its variable length form.
+func mqttPacket(typ byte, body []byte) []byte {
+	ret := []byte{typ}
+	for n := len(body); ; {
+		b := byte(n % 128)
+		n /= 128
+		if n > 0 {
+			b |= 0x80
+		}
+		ret = append(ret, b)
+		if n == 0 {
+			break
+		}
+	}
+	return append(ret, body...)
+}
+
+// appendMQTTString appends the string with its length prefix.
+func appendMQTTString(b []byte, s string) []byte {
+	return append(append(b, byte(len(s)>>8), byte(len(s))), s...)
+}
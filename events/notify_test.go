@@ -0,0 +1,86 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDispatcherCooldown(t *testing.T) {
+	var sent int
+	d := &Dispatcher{
+		Notifiers: []Notifier{NotifierFunc(func(ctx context.Context, a Alert) error {
+			sent++
+			return nil
+		})},
+		Cooldown: time.Hour,
+	}
+
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	for _, at := range []time.Duration{0, 30 * time.Minute, time.Hour} {
+		if err := d.Notify(context.Background(), Alert{Key: "storm", Time: now.Add(at)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if sent != 2 {
+		t.Errorf("got %d alerts, want 2", sent)
+	}
+}
+
+func TestDispatcherRetriesFailedAlerts(t *testing.T) {
+	down := true
+	var sent int
+	webhook := NotifierFunc(func(ctx context.Context, a Alert) error {
+		if down {
+			return errors.New("webhook is down")
+		}
+		sent++
+		return nil
+	})
+
+	d := &Dispatcher{Notifiers: []Notifier{webhook}, Cooldown: time.Hour}
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	// The alert is sent on the next check when all of the notifiers failed.
+	if err := d.Notify(context.Background(), Alert{Key: "storm", Time: now}); err == nil {
+		t.Fatal("got no error")
+	}
+	down = false
+	if err := d.Notify(context.Background(), Alert{Key: "storm", Time: now.Add(time.Minute)}); err != nil {
+		t.Fatal(err)
+	}
+	if sent != 1 {
+		t.Fatalf("got %d alerts, want 1", sent)
+	}
+
+	// It's in the cool-down once it has been sent.
+	if err := d.Notify(context.Background(), Alert{Key: "storm", Time: now.Add(2 * time.Minute)}); err != nil {
+		t.Fatal(err)
+	}
+	if sent != 1 {
+		t.Errorf("got %d alerts, want 1", sent)
+	}
+}
+
+func TestDispatcherPartialFailure(t *testing.T) {
+	var sent int
+	d := &Dispatcher{
+		Notifiers: []Notifier{
+			NotifierFunc(func(ctx context.Context, a Alert) error { return errors.New("webhook is down") }),
+			NotifierFunc(func(ctx context.Context, a Alert) error { sent++; return nil }),
+		},
+		Cooldown: time.Hour,
+	}
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	// An alert that one of the notifiers sent is in the cool-down.
+	for i := 0; i < 2; i++ {
+		if err := d.Notify(context.Background(), Alert{Key: "storm", Time: now}); i == 0 && err == nil {
+			t.Fatal("got no error")
+		}
+	}
+	if sent != 1 {
+		t.Errorf("got %d alerts, want 1", sent)
+	}
+}
@@ -0,0 +1,102 @@
+package smhi
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+)
+
+// formatFloat formats the value with as few digits as needed.
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// WriteCSV writes the time steps of the forecast as CSV, with a header of
+// the time and the SMHI parameter names.
+func (pf *PointForecast) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write(append([]string{"time"}, parameterNames...)); err != nil {
+		return err
+	}
+	for i := range pf.TimeSeries {
+		f := &pf.TimeSeries[i]
+
+		row := []string{f.Timestamp.UTC().Format(time.RFC3339)}
+		for _, name := range parameterNames {
+			if v, ok := f.Value(name); ok {
+				row = append(row, formatFloat(v))
+			} else {
+				row = append(row, "")
+			}
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteGeoJSON writes the forecast as a GeoJSON feature collection with
+// one point feature per time step, the properties hold the time and the
+// values of the SMHI parameters.
+func (pf *PointForecast) WriteGeoJSON(w io.Writer) error {
+	type feature struct {
+		Type     string `json:"type"`
+		Geometry struct {
+			Type        string     `json:"type"`
+			Coordinates [2]float64 `json:"coordinates"`
+		} `json:"geometry"`
+		Properties map[string]interface{} `json:"properties"`
+	}
+
+	lon, lat := pf.Geometry.point()
+	features := make([]feature, 0, len(pf.TimeSeries))
+	for i := range pf.TimeSeries {
+		f := &pf.TimeSeries[i]
+
+		var ft feature
+		ft.Type = "Feature"
+		ft.Geometry.Type = "Point"
+		ft.Geometry.Coordinates = [2]float64{lon, lat}
+		ft.Properties = map[string]interface{}{
+			"time": f.Timestamp.UTC().Format(time.RFC3339),
+		}
+		for _, name := range parameterNames {
+			if v, ok := f.Value(name); ok {
+				ft.Properties[name] = v
+			} else {
+				ft.Properties[name] = nil
+			}
+		}
+
+		features = append(features, ft)
+	}
+
+	return json.NewEncoder(w).Encode(struct {
+		Type     string    `json:"type"`
+		Features []feature `json:"features"`
+	}{"FeatureCollection", features})
+}
+
+// WriteCSV writes the observations as CSV with the columns time, value and
+// quality.
+func (o *Observations) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write([]string{"time", "value", "quality"}); err != nil {
+		return err
+	}
+	for _, v := range o.Values {
+		if err := cw.Write([]string{v.Timestamp.UTC().Format(time.RFC3339), formatFloat(v.Value), v.Quality}); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
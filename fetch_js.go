@@ -0,0 +1,42 @@
+//go:build js && wasm
+// +build js,wasm
+
+package smhi
+
+import (
+	"net/http"
+)
+
+// FetchTransport sends the requests of browser builds with the Fetch API
+// and the given request options, such as Mode "cors" and Credentials
+// "omit". The empty options are left to the defaults of the browser.
+type FetchTransport struct {
+	Mode        string
+	Credentials string
+	Redirect    string
+
+	// Transport is the transport that sends the requests, the default
+	// transport of net/http, which uses the Fetch API, is used if it's nil.
+	Transport http.RoundTripper
+}
+
+// RoundTrip sends the request with the fetch options, which net/http reads
+// from the js.fetch headers of the request.
+func (t *FetchTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	if t.Mode != "" {
+		req.Header.Set("js.fetch:mode", t.Mode)
+	}
+	if t.Credentials != "" {
+		req.Header.Set("js.fetch:credentials", t.Credentials)
+	}
+	if t.Redirect != "" {
+		req.Header.Set("js.fetch:redirect", t.Redirect)
+	}
+
+	rt := t.Transport
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	return rt.RoundTrip(req)
+}
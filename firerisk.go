@@ -0,0 +1,179 @@
+package smhi
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+const (
+	fireRiskURL = "%s/api/category/fwif1g/version/1/daily/geotype/point/lon/%f/lat/%f/data.json"
+)
+
+// FireRiskLevel is a level of the fire risk scale of SMHI.
+type FireRiskLevel int8
+
+// FireRiskLevel constants, NoFireRiskLevel is used when no level is given,
+// such as outside of the fire season.
+const (
+	NoFireRiskLevel FireRiskLevel = iota
+	VeryLowFireRisk
+	LowFireRisk
+	ModerateFireRisk
+	HighFireRisk
+	VeryHighFireRisk
+	ExtremeFireRisk
+)
+
+// FireRisk holds the fire risk forecast of a day. FWI is the fire weather
+// index of the Canadian Forest Fire Weather Index System, and FFMC, DMC,
+// DC, ISI and BUI are the codes and indices that it's based on.
+type FireRisk struct {
+	Date                      time.Time
+	FWI                       float64
+	FWILevel                  FireRiskLevel
+	FWILevelDescription       map[string]string
+	GrassFireLevel            FireRiskLevel
+	GrassFireLevelDescription map[string]string
+	FFMC                      float64
+	DMC                       float64
+	DC                        float64
+	ISI                       float64
+	BUI                       float64
+}
+
+// FireRiskForecast holds the daily fire risk forecasts of a location.
+type FireRiskForecast struct {
+	ApprovedTime  time.Time
+	ReferenceTime time.Time
+	Geometry      Geometry
+	Days          []FireRisk
+}
+
+// GetFireRisk fetches the daily fire risk forecast for the given longitude
+// and latitude using the default client.
+func GetFireRisk(lon, lat float64) (*FireRiskForecast, error) {
+	return defaultClient.GetFireRisk(lon, lat)
+}
+
+// GetFireRisk fetches the daily fire risk forecast for the given longitude
+// and latitude.
+func (c *Client) GetFireRisk(lon, lat float64) (*FireRiskForecast, error) {
+	var err error
+
+	// The fire risk forecast has the same structure as the point
+	// forecast.
+	var decodedData PointForecastAPI
+	if err = c.getJSON(fmt.Sprintf(fireRiskURL, c.baseURL, lon, lat), &decodedData); err != nil {
+		return nil, err
+	}
+
+	var ret FireRiskForecast
+	if ret.ApprovedTime, err = time.Parse(time.RFC3339, decodedData.ApprovedTime); err != nil {
+		return nil, err
+	}
+	if ret.ReferenceTime, err = time.Parse(time.RFC3339, decodedData.ReferenceTime); err != nil {
+		return nil, err
+	}
+	ret.Geometry = decodedData.Geometry
+
+	for _, t := range decodedData.TimeSeries {
+		var fr FireRisk
+		if fr.Date, err = time.Parse(time.RFC3339, t.ValidTime); err != nil {
+			return nil, err
+		}
+
+		for _, p := range t.Parameters {
+			if len(p.Values) == 0 {
+				continue
+			}
+			v := p.Values[0]
+
+			switch p.Name {
+			case "fwi":
+				fr.FWI = v
+				break
+			case "fwiindex":
+				fr.FWILevel = toFireRiskLevel(v)
+				break
+			case "grassfire":
+				fr.GrassFireLevel = toFireRiskLevel(v)
+				break
+			case "ffmc":
+				fr.FFMC = v
+				break
+			case "dmc":
+				fr.DMC = v
+				break
+			case "dc":
+				fr.DC = v
+				break
+			case "isi":
+				fr.ISI = v
+				break
+			case "bui":
+				fr.BUI = v
+				break
+			}
+		}
+
+		if c.descriptions {
+			fr.FWILevelDescription = getFireRiskLevelDescription(fr.FWILevel)
+			fr.GrassFireLevelDescription = getFireRiskLevelDescription(fr.GrassFireLevel)
+		}
+
+		ret.Days = append(ret.Days, fr)
+	}
+
+	return &ret, nil
+}
+
+// toFireRiskLevel converts a level of the API to a fire risk level, the
+// values outside of the scale, such as -1 outside of the fire season, are
+// converted to NoFireRiskLevel.
+func toFireRiskLevel(v float64) FireRiskLevel {
+	l := FireRiskLevel(math.Round(v))
+	if l < VeryLowFireRisk || l > ExtremeFireRisk {
+		return NoFireRiskLevel
+	}
+	return l
+}
+
+// getFireRiskLevelDescription returns a friendly fire risk level
+// description.
+func getFireRiskLevelDescription(l FireRiskLevel) map[string]string {
+	ret := make(map[string]string)
+
+	switch l {
+	case NoFireRiskLevel:
+		ret["sv-SE"] = "Ingen brandriskprognos"
+		ret["en-US"] = "No fire risk forecast"
+		break
+	case VeryLowFireRisk:
+		ret["sv-SE"] = "Mycket liten brandrisk"
+		ret["en-US"] = "Very low fire risk"
+		break
+	case LowFireRisk:
+		ret["sv-SE"] = "Liten brandrisk"
+		ret["en-US"] = "Low fire risk"
+		break
+	case ModerateFireRisk:
+		ret["sv-SE"] = "Måttlig brandrisk"
+		ret["en-US"] = "Moderate fire risk"
+		break
+	case HighFireRisk:
+		ret["sv-SE"] = "Stor brandrisk"
+		ret["en-US"] = "High fire risk"
+		break
+	case VeryHighFireRisk:
+		ret["sv-SE"] = "Mycket stor brandrisk"
+		ret["en-US"] = "Very high fire risk"
+		break
+	case ExtremeFireRisk:
+		ret["sv-SE"] = "Extremt stor brandrisk"
+		ret["en-US"] = "Extreme fire risk"
+		break
+	}
+
+	return ret
+}
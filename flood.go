@@ -0,0 +1,223 @@
+package smhi
+
+import (
+	"fmt"
+	"time"
+)
+
+// FloodThresholds holds the discharges in m³/s of a river at a gauging
+// station that are exceeded on average once per given return period, such
+// as the ones published by SMHI Vattenwebb.
+type FloodThresholds struct {
+	MeanHigh    float64
+	TenYear     float64
+	FiftyYear   float64
+	HundredYear float64
+}
+
+// ReturnPeriodClass classifies a discharge by its return period.
+type ReturnPeriodClass int
+
+// ReturnPeriodClass constants.
+const (
+	BelowMeanHighFlow ReturnPeriodClass = iota
+	AboveMeanHighFlow
+	TenYearFlow
+	FiftyYearFlow
+	HundredYearFlow
+)
+
+// classify returns the return period class of the discharge, thresholds
+// that are zero are skipped.
+func (th FloodThresholds) classify(discharge float64) ReturnPeriodClass {
+	if th.HundredYear > 0 && discharge >= th.HundredYear {
+		return HundredYearFlow
+	} else if th.FiftyYear > 0 && discharge >= th.FiftyYear {
+		return FiftyYearFlow
+	} else if th.TenYear > 0 && discharge >= th.TenYear {
+		return TenYearFlow
+	} else if th.MeanHigh > 0 && discharge >= th.MeanHigh {
+		return AboveMeanHighFlow
+	}
+	return BelowMeanHighFlow
+}
+
+// FloodRisk summarizes the flood risk of a river at a gauging station or
+// of an S-HYPE sub-basin. Discharge is the current discharge and Peak is
+// the highest discharge from now onwards, both in m³/s, so Peak is the
+// current discharge unless the series has a forecast. Trend is the change
+// in m³/s per hour over the day before and the day after now.
+type FloodRisk struct {
+	Station     *Station
+	SubBasin    *SubBasin
+	Timestamp   time.Time
+	Discharge   float64
+	Peak        Observation
+	Trend       float64
+	Class       ReturnPeriodClass
+	Warnings    []Warning
+	Description map[string]string
+}
+
+// AssessFloodRisk summarizes the flood risk at the given time of a
+// discharge series, which may be observed, forecasted or both, against
+// the thresholds of the river. The current discharge is the latest one at
+// or before now, or the first one if the series starts after it, and the
+// class is the one of the peak from then onwards, so floods that have
+// passed don't count. The warnings are included as they are, so the
+// caller decides which ones concern the basin.
+func AssessFloodRisk(discharge []Observation, now time.Time, th FloodThresholds, warnings []Warning) (*FloodRisk, error) {
+	if len(discharge) == 0 {
+		return nil, ErrNoObservations
+	}
+
+	current := 0
+	for i, o := range discharge {
+		if o.Timestamp.After(now) {
+			break
+		}
+		current = i
+	}
+
+	cur := discharge[current]
+	ret := &FloodRisk{
+		Timestamp: cur.Timestamp,
+		Discharge: cur.Value,
+		Peak:      cur,
+		Warnings:  warnings,
+	}
+
+	var ts []time.Time
+	var vs []float64
+	for i, o := range discharge {
+		if i > current && o.Value > ret.Peak.Value {
+			ret.Peak = o
+		}
+		if d := o.Timestamp.Sub(cur.Timestamp); d >= -24*time.Hour && d <= 24*time.Hour {
+			ts, vs = append(ts, o.Timestamp), append(vs, o.Value)
+		}
+	}
+	ret.Trend = hourlyTrend(ts, vs)
+	ret.Class = th.classify(ret.Peak.Value)
+	ret.Description = getFloodRiskDescription(ret)
+
+	return ret, nil
+}
+
+// GetFloodRisk fetches the discharge of the latest months at the gauging
+// station and the warnings at its position, and summarizes the current
+// flood risk using the default client.
+func GetFloodRisk(s *Station, th FloodThresholds) (*FloodRisk, error) {
+	return defaultClient.GetFloodRisk(s, th)
+}
+
+// GetFloodRisk fetches the discharge of the latest months at the gauging
+// station and the warnings at its position, and summarizes the current
+// flood risk from the latest observed discharge.
+func (c *Client) GetFloodRisk(s *Station, th FloodThresholds) (*FloodRisk, error) {
+	var err error
+
+	var p int
+	if p, err = c.HydroParameter("vattenföring"); err != nil {
+		return nil, err
+	}
+
+	var o *Observations
+	if o, err = c.GetHydroObservations(p, s.ID, PeriodLatestMonths); err != nil {
+		return nil, err
+	}
+
+	var warnings []Warning
+	if warnings, err = c.GetWarningsAt(s.Longitude, s.Latitude); err != nil {
+		return nil, err
+	}
+
+	var ret *FloodRisk
+	if ret, err = AssessFloodRisk(o.Values, time.Now(), th, warnings); err != nil {
+		return nil, err
+	}
+	ret.Station = s
+
+	return ret, nil
+}
+
+// GetBasinFloodRisk fetches the S-HYPE discharge forecast of the sub-basin
+// with the given SUBID and the warnings at its position, and summarizes
+// its flood risk using the default client.
+func GetBasinFloodRisk(subBasin int, th FloodThresholds) (*FloodRisk, error) {
+	return defaultClient.GetBasinFloodRisk(subBasin, th)
+}
+
+// GetBasinFloodRisk fetches the S-HYPE discharge forecast of the sub-basin
+// with the given SUBID and the warnings at its position, and summarizes
+// its flood risk at the reference time of the forecast, with the peak of
+// the coming days.
+func (c *Client) GetBasinFloodRisk(subBasin int, th FloodThresholds) (*FloodRisk, error) {
+	var err error
+
+	var hf *HydroForecast
+	if hf, err = c.GetHydroForecast(subBasin); err != nil {
+		return nil, err
+	}
+
+	var warnings []Warning
+	if warnings, err = c.GetWarningsAt(hf.SubBasin.Longitude, hf.SubBasin.Latitude); err != nil {
+		return nil, err
+	}
+
+	var ret *FloodRisk
+	if ret, err = AssessFloodRisk(hf.Discharge, hf.ReferenceTime, th, warnings); err != nil {
+		return nil, err
+	}
+	ret.SubBasin = &hf.SubBasin
+
+	return ret, nil
+}
+
+// getFloodRiskDescription returns a friendly description of the flood
+// risk, trends within 1% of the discharge per hour are considered steady.
+func getFloodRiskDescription(fr *FloodRisk) map[string]string {
+	ret := make(map[string]string)
+
+	switch fr.Class {
+	case BelowMeanHighFlow:
+		ret["sv-SE"] = "Normalt flöde"
+		ret["en-US"] = "Normal flow"
+		break
+	case AboveMeanHighFlow:
+		ret["sv-SE"] = "Högt flöde"
+		ret["en-US"] = "High flow"
+		break
+	case TenYearFlow:
+		ret["sv-SE"] = "Mycket högt flöde, minst ett tioårsflöde"
+		ret["en-US"] = "Very high flow, at least a 10-year flow"
+		break
+	case FiftyYearFlow:
+		ret["sv-SE"] = "Risk för översvämning, minst ett femtioårsflöde"
+		ret["en-US"] = "Risk of flooding, at least a 50-year flow"
+		break
+	case HundredYearFlow:
+		ret["sv-SE"] = "Stor risk för översvämning, minst ett hundraårsflöde"
+		ret["en-US"] = "High risk of flooding, at least a 100-year flow"
+		break
+	}
+
+	steady := fr.Discharge / 100
+	if fr.Trend > steady {
+		ret["sv-SE"] += ", stigande"
+		ret["en-US"] += ", rising"
+	} else if fr.Trend < -steady {
+		ret["sv-SE"] += ", sjunkande"
+		ret["en-US"] += ", falling"
+	} else {
+		ret["sv-SE"] += ", oförändrat"
+		ret["en-US"] += ", steady"
+	}
+
+	if n := len(fr.Warnings); n > 0 {
+		ret["sv-SE"] += fmt.Sprintf(" (%d aktiva varningar)", n)
+		ret["en-US"] += fmt.Sprintf(" (%d active warnings)", n)
+	}
+
+	return ret
+}
@@ -0,0 +1,101 @@
+package smhi
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+// dailyDischarge returns a daily discharge series with the values from
+// start.
+func dailyDischarge(start time.Time, values ...float64) []Observation {
+	var ret []Observation
+	for i, v := range values {
+		ret = append(ret, Observation{Timestamp: start.AddDate(0, 0, i), Value: v})
+	}
+	return ret
+}
+
+func TestAssessFloodRisk(t *testing.T) {
+	th := FloodThresholds{MeanHigh: 100, TenYear: 150, FiftyYear: 200, HundredYear: 250}
+	start := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	day := func(n int) time.Time { return start.AddDate(0, 0, n) }
+
+	tests := []struct {
+		name      string
+		series    []Observation
+		now       time.Time
+		discharge float64
+		peak      float64
+		class     ReturnPeriodClass
+	}{
+		{"past flood", dailyDischarge(start, 80, 260, 180, 90, 60), day(4), 60, 60, BelowMeanHighFlow},
+		{"current flood", dailyDischarge(start, 80, 120, 160), day(2), 160, 160, TenYearFlow},
+		{"forecasted flood", dailyDischarge(start, 80, 90, 120, 210, 170), day(1), 90, 210, FiftyYearFlow},
+		{"past flood with forecast", dailyDischarge(start, 260, 90, 80, 110), day(2), 80, 110, AboveMeanHighFlow},
+		{"now between steps", dailyDischarge(start, 80, 90, 120), day(1).Add(12 * time.Hour), 90, 120, AboveMeanHighFlow},
+		{"now after the series", dailyDischarge(start, 260, 90), day(10), 90, 90, BelowMeanHighFlow},
+		{"now before the series", dailyDischarge(start, 110, 90), day(-1), 110, 110, AboveMeanHighFlow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fr, err := AssessFloodRisk(tt.series, tt.now, th, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if fr.Discharge != tt.discharge || fr.Peak.Value != tt.peak || fr.Class != tt.class {
+				t.Errorf("got discharge %v, peak %v and class %v, want %v, %v and %v", fr.Discharge, fr.Peak.Value, fr.Class, tt.discharge, tt.peak, tt.class)
+			}
+		})
+	}
+
+	if _, err := AssessFloodRisk(nil, start, th, nil); err != ErrNoObservations {
+		t.Errorf("got error %v, want %v", err, ErrNoObservations)
+	}
+}
+
+func TestAssessFloodRiskTrend(t *testing.T) {
+	start := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	th := FloodThresholds{MeanHigh: 100}
+
+	// The trend follows the forecast of the next day, not the fall of the
+	// days before.
+	fr, err := AssessFloodRisk(dailyDischarge(start, 200, 100, 100, 172), start.AddDate(0, 0, 2), th, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fr.Trend != 1.5 {
+		t.Errorf("got trend %v, want 1.5", fr.Trend)
+	}
+	if want := "High flow, rising"; fr.Description["en-US"] != want {
+		t.Errorf("got description %q, want %q", fr.Description["en-US"], want)
+	}
+}
+
+func TestGetBasinFloodRisk(t *testing.T) {
+	srv := hypeServer()
+	defer srv.Close()
+
+	// The warnings are served by the transport, since their URL can't be
+	// changed.
+	rt := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Host == "opendata-download-warnings.smhi.se" {
+			return jsonResponse(req, `[]`), nil
+		}
+		return http.DefaultTransport.RoundTrip(req)
+	})
+
+	c := NewClient(WithHydroForecastURL(srv.URL), WithTransport(rt))
+	fr, err := c.GetBasinFloodRisk(12345, FloodThresholds{MeanHigh: 125, TenYear: 135})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if fr.SubBasin == nil || fr.SubBasin.ID != 12345 || fr.Station != nil {
+		t.Errorf("got sub-basin %+v and station %+v", fr.SubBasin, fr.Station)
+	}
+	if fr.Discharge != 131.5 || fr.Peak.Value != 140 || fr.Class != TenYearFlow {
+		t.Errorf("got discharge %v, peak %v and class %v", fr.Discharge, fr.Peak.Value, fr.Class)
+	}
+}
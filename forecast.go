@@ -1,54 +1,13 @@
 package smhi
 
 import (
-	"encoding/json"
 	"fmt"
-	"io/ioutil"
-	"net/http"
 	"time"
 )
 
-const (
-	forecastURL = "https://opendata-download-metfcst.smhi.se/api/category/pmp3g/version/2/geotype/point/lon/%f/lat/%f/data.json"
-)
-
-// GetPointForecast fetches a forecast from the SMHI API for the given
-// longitude and latitude.
-func GetPointForecast(lon, lat float64) (*PointForecast, error) {
-	var err error
-
-	// Fetch the forecast for the given longitude and latitude.
-	var res *http.Response
-	if res, err = http.Get(fmt.Sprintf(forecastURL, lon, lat)); err != nil {
-		return nil, err
-	}
-	defer res.Body.Close()
-
-	// Read all of the data into a buffer.
-	var data []byte
-	if data, err = ioutil.ReadAll(res.Body); err != nil {
-		return nil, err
-	}
-
-	// Decode the data into the data structure that's defined by SMHI.
-	var decodedData PointForecastAPI
-	if err = json.Unmarshal(data, &decodedData); err != nil {
-		return nil, err
-	}
-
-	// Create a new copy of the data in a structure that is defined by us,
-	// which makes it easier to find the given temperature etc.
-	var ret *PointForecast
-	if ret, err = toPointForecast(&decodedData); err != nil {
-		return nil, err
-	}
-
-	return ret, nil
-}
-
 // toPointForecast convers the PointForecastAPI object to a PointForecase
 // object.
-func toPointForecast(d *PointForecastAPI) (*PointForecast, error) {
+func (c *Client) toPointForecast(d *PointForecastAPI) (*PointForecast, error) {
 	var ret PointForecast
 	var err error
 
@@ -66,72 +25,22 @@ func toPointForecast(d *PointForecastAPI) (*PointForecast, error) {
 
 	// Iterate over the time series and construct a Forecast map for each
 	// timestamp.
+	lon, lat := ret.Geometry.point()
 	for _, t := range d.TimeSeries {
 		var f Forecast
 		f.Timestamp, err = time.Parse(time.RFC3339, t.ValidTime)
 
 		for _, p := range t.Parameters {
-			switch p.Name {
-			case "msl":
-				f.AirPressure = p.Values[0]
-				break
-			case "t":
-				f.AirTemperature = p.Values[0]
-				break
-			case "vis":
-				f.HorizontalVisibility = p.Values[0]
-				break
-			case "wd":
-				f.WindDirection = uint8(p.Values[0])
-				break
-			case "ws":
-				f.WindSpeed = p.Values[0]
-				f.WindSpeedDescription = getWindSpeedDescription(f.WindSpeed)
-				break
-			case "r":
-				f.RelativeHumidity = uint8(p.Values[0])
-				break
-			case "tstm":
-				f.ThunderProbability = uint8(p.Values[0])
-				break
-			case "tcc_mean":
-				f.MeanValueOfTotalCloudCover = uint8(p.Values[0])
-				break
-			case "lcc_mean":
-				f.MeanValueOfLowLevelCloudCover = uint8(p.Values[0])
-				break
-			case "mcc_mean":
-				f.MeanValueOfMediumLevelCloudCover = uint8(p.Values[0])
-				break
-			case "hcc_mean":
-				f.MeanValueOfHighLevelCloudCover = uint8(p.Values[0])
-				break
-			case "gust":
-				f.WindGustSpeed = p.Values[0]
-				break
-			case "pmin":
-				f.MinimumPrecipitationIntensity = p.Values[0]
-				break
-			case "pmax":
-				f.MaximumPrecipitationIntensity = p.Values[0]
-				break
-			case "spp":
-				f.PercentOfPrecipitationInFrozenForm = int8(p.Values[0])
-				break
-			case "pcat":
-				f.PrecipitationCategory = PrecipitationCategory(p.Values[0])
-				f.PrecipitationCategoryDescription = getPrecipitationCategoryDescriptions(f.PrecipitationCategory)
-				break
-			case "pmean":
-				f.MeanPrecipitationIntensity = p.Values[0]
-				break
-			case "pmedian":
-				f.MedianPrecipitationIntensity = p.Values[0]
-				break
-			case "Wsymb2":
-				f.WeatherSymbol = WeatherSymbol(p.Values[0])
-				f.WeatherSymbolDescription = getWeatherSymbolDescription(f.WeatherSymbol)
-				break
+			if c.parameters != nil && !c.parameters[p.Name] {
+				continue
+			}
+			f.decode(p.Name, p.Values)
+		}
+
+		if c.parameters == nil {
+			derive(&f, lon, lat)
+			if c.descriptions {
+				describe(&f)
 			}
 		}
 
@@ -143,6 +52,22 @@ func toPointForecast(d *PointForecastAPI) (*PointForecast, error) {
 	return &ret, nil
 }
 
+// derive populates the fields of the forecast that are derived from the
+// parameters returned by the API.
+func derive(f *Forecast, lon, lat float64) {
+	f.ApparentTemperature = ApparentTemperature(f, lon, lat)
+}
+
+// describe populates the localized descriptions of the forecast.
+func describe(f *Forecast) {
+	f.WindSpeedDescription = getWindSpeedDescription(f.WindSpeed)
+	f.PrecipitationCategoryDescription = getPrecipitationCategoryDescriptions(f.PrecipitationCategory)
+	f.WeatherSymbolDescription = getWeatherSymbolDescription(f.WeatherSymbol)
+	f.HorizontalVisibilityDescription = getHorizontalVisibilityDescription(f.HorizontalVisibility)
+	f.MeanValueOfTotalCloudCoverDescription = CloudCoverDescription(f.MeanValueOfTotalCloudCover)
+	f.ComfortDescription = getComfortDescription(f.ApparentTemperature)
+}
+
 // getPrecipitationCategoryDescriptions returns a friendly precipitation
 // category description.
 func getPrecipitationCategoryDescriptions(pc PrecipitationCategory) map[string]string {
@@ -300,47 +225,85 @@ func getWeatherSymbolDescription(ws WeatherSymbol) map[string]string {
 	return ret
 }
 
+// getHorizontalVisibilityDescription returns a friendly name for the
+// horizontal visibility in km. Dense fog is below 200 m, fog below 1 km,
+// mist below 5 km and haze below 10 km.
+func getHorizontalVisibilityDescription(vis float64) map[string]string {
+	ret := make(map[string]string)
+
+	if vis < 0.2 {
+		ret["sv-SE"] = "Tät dimma"
+		ret["en-US"] = "Dense fog"
+	} else if vis < 1 {
+		ret["sv-SE"] = "Dimma"
+		ret["en-US"] = "Fog"
+	} else if vis < 5 {
+		ret["sv-SE"] = "Fuktdis"
+		ret["en-US"] = "Mist"
+	} else if vis < 10 {
+		ret["sv-SE"] = "Dis"
+		ret["en-US"] = "Haze"
+	} else {
+		ret["sv-SE"] = "God sikt"
+		ret["en-US"] = "Good visibility"
+	}
+	return ret
+}
+
+// Beaufort returns the Beaufort number for the given wind speed in m/s.
+func Beaufort(windSpeed float64) int {
+	limits := []float64{0.3, 1.6, 3.4, 5.5, 8.0, 10.8, 13.9, 17.2, 20.8, 24.5, 28.5, 32.7}
+
+	for i, l := range limits {
+		if windSpeed < l {
+			return i
+		}
+	}
+	return len(limits)
+}
+
 // getWindSpeedDescription returns a friendly name for the wind speed.
 func getWindSpeedDescription(windSpeed float64) map[string]string {
 	ret := make(map[string]string)
 
-	if windSpeed <= 0.2 {
+	switch Beaufort(windSpeed) {
+	case 0:
 		ret["sv-SE"] = "Stiltje"
 		ret["en-US"] = "Calm"
-	} else if windSpeed >= 0.3 && windSpeed <= 1.5 {
+	case 1:
 		ret["sv-SE"] = "Nästan stiltje"
 		ret["en-US"] = "Light air"
-	} else if windSpeed >= 1.6 && windSpeed <= 3.3 {
+	case 2:
 		ret["sv-SE"] = "Lätt bris"
 		ret["en-US"] = "Light breeze"
-	} else if windSpeed >= 3.4 && windSpeed <= 5.4 {
+	case 3:
 		ret["sv-SE"] = "God bris"
 		ret["en-US"] = "Gentle breeze"
-	} else if windSpeed >= 5.5 && windSpeed <= 7.9 {
+	case 4:
 		ret["sv-SE"] = "Frisk bris"
 		ret["en-US"] = "Moderate breeze"
-	} else if windSpeed >= 8.0 && windSpeed <= 10.7 {
+	case 5:
 		ret["sv-SE"] = "Styv bris"
 		ret["en-US"] = "Fresh breeze"
-	} else if windSpeed >= 10.8 && windSpeed <= 13.8 {
+	case 6:
 		ret["sv-SE"] = "Hård bris"
 		ret["en-US"] = "Strong breeze"
-	} else if windSpeed >= 13.9 && windSpeed <= 17.1 {
+	case 7:
 		ret["sv-SE"] = "Styv kuling"
 		ret["en-US"] = "Moderate gale"
-	} else if windSpeed >= 17.2 && windSpeed <= 20.7 {
+	case 8:
 		ret["sv-SE"] = "Hård kuling"
 		ret["en-US"] = "Fresh gate"
-	} else if windSpeed >= 20.8 && windSpeed <= 24.4 {
+	case 9:
 		ret["sv-SE"] = "Halv storm"
 		ret["en-US"] = "Strong gale"
-	} else if windSpeed >= 24.5 && windSpeed <= 28.4 {
+	case 10:
 		ret["sv-SE"] = "Storm"
 		ret["en-US"] = "Storm"
-	} else if windSpeed >= 28.5 && windSpeed <= 32.6 {
+	case 11:
 		ret["sv-SE"] = "Svår storm"
 		ret["en-US"] = "Violent storm"
-	} else {
+	default:
 		ret["sv-SE"] = "Orkan"
 		ret["en-US"] = "Hurricane"
 	}
@@ -348,7 +311,7 @@ func getWindSpeedDescription(windSpeed float64) map[string]string {
 }
 
 func getHash(f *Forecast) string {
-	return fmt.Sprintf("%v|%v|%v|%v|%v|%v|%v|%v|%v|%v|%v|%v|%v|%v|%v|%v|%v|%v|%v",
+	return fmt.Sprintf("%v|%v|%v|%v|%v|%v|%v|%v|%v|%v|%v|%v|%v|%v|%v|%v|%v|%v|%v|%v|%v|%v",
 		f.AirPressure,
 		f.AirTemperature,
 		f.HorizontalVisibility,
@@ -363,7 +326,10 @@ func getHash(f *Forecast) string {
 		f.PercentOfPrecipitationInFrozenForm,
 		f.PrecipitationCategory,
 		f.RelativeHumidity,
+		f.SignificantWaveHeight,
 		f.ThunderProbability,
+		f.WaveDirection,
+		f.WavePeriod,
 		f.WeatherSymbol,
 		f.WindDirection,
 		f.WindGustSpeed,
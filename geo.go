@@ -0,0 +1,21 @@
+package smhi
+
+import (
+	"math"
+)
+
+// earthRadius is the mean radius of the earth in km.
+const earthRadius = 6371.0
+
+// distance returns the great-circle distance in km between two points.
+func distance(lon1, lat1, lon2, lat2 float64) float64 {
+	phi1 := lat1 * math.Pi / 180
+	phi2 := lat2 * math.Pi / 180
+	dphi := (lat2 - lat1) * math.Pi / 180
+	dlambda := (lon2 - lon1) * math.Pi / 180
+
+	a := math.Sin(dphi/2)*math.Sin(dphi/2) +
+		math.Cos(phi1)*math.Cos(phi2)*math.Sin(dlambda/2)*math.Sin(dlambda/2)
+
+	return 2 * earthRadius * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+}
@@ -0,0 +1,110 @@
+package smhi
+
+import (
+	"errors"
+	"strings"
+)
+
+// geohashAlphabet is the base 32 alphabet of geohashes.
+const geohashAlphabet = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// ErrInvalidGeohash is returned for geohashes with characters outside of
+// the geohash alphabet.
+var ErrInvalidGeohash = errors.New("smhi: invalid geohash")
+
+// EncodeGeohash returns the geohash of the given longitude and latitude
+// with the given number of characters, such as 7 for cells of about 150 m.
+func EncodeGeohash(lon, lat float64, precision int) string {
+	lonRange := [2]float64{-180, 180}
+	latRange := [2]float64{-90, 90}
+
+	var b strings.Builder
+	var ch, bit int
+	even := true
+	for b.Len() < precision {
+		// Even bits halve the longitude and odd bits halve the latitude.
+		r, v := &latRange, lat
+		if even {
+			r, v = &lonRange, lon
+		}
+
+		mid := (r[0] + r[1]) / 2
+		ch <<= 1
+		if v >= mid {
+			ch |= 1
+			r[0] = mid
+		} else {
+			r[1] = mid
+		}
+		even = !even
+
+		if bit++; bit == 5 {
+			b.WriteByte(geohashAlphabet[ch])
+			ch, bit = 0, 0
+		}
+	}
+
+	return b.String()
+}
+
+// DecodeGeohash returns the longitude and latitude of the center of the
+// cell of the geohash.
+func DecodeGeohash(gh string) (lon, lat float64, err error) {
+	lonRange := [2]float64{-180, 180}
+	latRange := [2]float64{-90, 90}
+
+	if gh == "" {
+		return 0, 0, ErrInvalidGeohash
+	}
+
+	even := true
+	for _, c := range strings.ToLower(gh) {
+		ch := strings.IndexRune(geohashAlphabet, c)
+		if ch < 0 {
+			return 0, 0, ErrInvalidGeohash
+		}
+
+		for bit := 4; bit >= 0; bit-- {
+			r := &latRange
+			if even {
+				r = &lonRange
+			}
+
+			mid := (r[0] + r[1]) / 2
+			if ch&(1<<uint(bit)) != 0 {
+				r[0] = mid
+			} else {
+				r[1] = mid
+			}
+			even = !even
+		}
+	}
+
+	return (lonRange[0] + lonRange[1]) / 2, (latRange[0] + latRange[1]) / 2, nil
+}
+
+// Geohash returns the geohash of the grid point of the forecast with the
+// given number of characters.
+func (pf *PointForecast) Geohash(precision int) string {
+	lon, lat := pf.Geometry.point()
+	return EncodeGeohash(lon, lat, precision)
+}
+
+// GetPointForecastByGeohash fetches the forecast for the center of the
+// geohash using the default client.
+func GetPointForecastByGeohash(gh string) (*PointForecast, error) {
+	return defaultClient.GetPointForecastByGeohash(gh)
+}
+
+// GetPointForecastByGeohash fetches the forecast for the center of the
+// geohash.
+func (c *Client) GetPointForecastByGeohash(gh string) (*PointForecast, error) {
+	var err error
+
+	var lon, lat float64
+	if lon, lat, err = DecodeGeohash(gh); err != nil {
+		return nil, err
+	}
+
+	return c.GetPointForecast(lon, lat)
+}
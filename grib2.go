@@ -0,0 +1,443 @@
+package smhi
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"time"
+)
+
+// ErrUnsupportedGRIB is returned for the GRIB features that the decoder
+// doesn't support.
+var ErrUnsupportedGRIB = errors.New("smhi: unsupported GRIB2 feature")
+
+// ErrNoGridData is returned when a grid doesn't cover a location.
+var ErrNoGridData = errors.New("smhi: no grid data for the location")
+
+// GridDecoder decodes the fields of a gridded file, other decoders such as
+// wrappers of external GRIB libraries can be used in place of the GRIB2
+// decoder of the package.
+type GridDecoder interface {
+	Decode(r io.Reader) ([]*GridField, error)
+}
+
+// GridField is a field of a gridded file, such as the air temperature at
+// 2 m for a valid time. The parameter is identified by its WMO discipline,
+// category and number.
+type GridField struct {
+	Discipline    int
+	Category      int
+	Number        int
+	SurfaceType   int
+	SurfaceValue  float64
+	ReferenceTime time.Time
+	ValidTime     time.Time
+	Grid          Grid
+	Values        []float64
+}
+
+// Grid maps between coordinates and the indices of the values of a field.
+type Grid interface {
+	// Index returns the index of the grid point nearest to the given
+	// longitude and latitude, false is returned outside of the grid.
+	Index(lon, lat float64) (int, bool)
+
+	// Point returns the longitude and latitude of the grid point at the
+	// index.
+	Point(i int) (lon, lat float64)
+
+	// Len returns the number of grid points.
+	Len() int
+}
+
+// ValueAt returns the value of the grid point nearest to the given
+// longitude and latitude.
+func (f *GridField) ValueAt(lon, lat float64) (float64, error) {
+	i, ok := f.Grid.Index(lon, lat)
+	if !ok || i >= len(f.Values) || math.IsNaN(f.Values[i]) {
+		return 0, ErrNoGridData
+	}
+
+	return f.Values[i], nil
+}
+
+// AreaMean returns the mean of the values of the grid points within the
+// bounding box, the points without data are skipped.
+func (f *GridField) AreaMean(minLon, minLat, maxLon, maxLat float64) (float64, error) {
+	var sum float64
+	var n int
+	for i := 0; i < f.Grid.Len() && i < len(f.Values); i++ {
+		lon, lat := f.Grid.Point(i)
+		if lon < minLon || lon > maxLon || lat < minLat || lat > maxLat || math.IsNaN(f.Values[i]) {
+			continue
+		}
+		sum += f.Values[i]
+		n++
+	}
+	if n == 0 {
+		return 0, ErrNoGridData
+	}
+
+	return sum / float64(n), nil
+}
+
+// GridTimeSeries returns the values of the parameter at the grid point
+// nearest to the given longitude and latitude, ordered by the valid time.
+// Fields of other parameters and fields that don't cover the location are
+// skipped.
+func GridTimeSeries(fields []*GridField, discipline, category, number int, lon, lat float64) []Observation {
+	var ret []Observation
+	for _, f := range fields {
+		if f.Discipline != discipline || f.Category != category || f.Number != number {
+			continue
+		}
+		if v, err := f.ValueAt(lon, lat); err == nil {
+			ret = append(ret, Observation{Timestamp: f.ValidTime, Value: v})
+		}
+	}
+	sort.SliceStable(ret, func(i, j int) bool {
+		return ret[i].Timestamp.Before(ret[j].Timestamp)
+	})
+
+	return ret
+}
+
+// latLonGrid is a regular longitude and latitude grid, the GRIB2 grid
+// definition template 3.0. The steps are negative when the coordinates
+// decrease along the scan.
+type latLonGrid struct {
+	ni, nj     int
+	lon1, lat1 float64
+	di, dj     float64
+}
+
+// Index returns the index of the grid point nearest to the longitude and
+// latitude.
+func (g *latLonGrid) Index(lon, lat float64) (int, bool) {
+	d := math.Mod(lon-g.lon1, 360)
+	if g.di > 0 && d < 0 {
+		d += 360
+	} else if g.di < 0 && d > 0 {
+		d -= 360
+	}
+
+	i := int(math.Round(d / g.di))
+	j := int(math.Round((lat - g.lat1) / g.dj))
+	if i < 0 || i >= g.ni || j < 0 || j >= g.nj {
+		return 0, false
+	}
+
+	return j*g.ni + i, true
+}
+
+// Point returns the longitude and latitude of the grid point at the index.
+func (g *latLonGrid) Point(i int) (float64, float64) {
+	lon := math.Mod(g.lon1+float64(i%g.ni)*g.di+540, 360) - 180
+	return lon, g.lat1 + float64(i/g.ni)*g.dj
+}
+
+// Len returns the number of grid points.
+func (g *latLonGrid) Len() int {
+	return g.ni * g.nj
+}
+
+// GRIB2Decoder decodes GRIB edition 2 files with regular longitude and
+// latitude grids (template 3.0), analysis or forecast products at a point
+// in time (template 4.0) and simple packing (template 5.0), with or without
+// a bitmap. Other templates are reported as ErrUnsupportedGRIB.
+type GRIB2Decoder struct{}
+
+// grib2Field holds the state of the field that is being decoded, since
+// the sections of a message may be repeated for several fields.
+type grib2Field struct {
+	GridField
+	npoints   int
+	reference float64
+	binary    int
+	decimal   int
+	bits      int
+	bitmap    []byte
+}
+
+// Decode decodes all of the fields of all of the messages of the file.
+func (GRIB2Decoder) Decode(r io.Reader) ([]*GridField, error) {
+	var ret []*GridField
+
+	br := bufio.NewReader(r)
+	for {
+		fields, err := decodeGRIB2Message(br)
+		if err == io.EOF {
+			return ret, nil
+		} else if err != nil {
+			return nil, err
+		}
+		ret = append(ret, fields...)
+	}
+}
+
+// decodeGRIB2Message decodes the fields of the next message of the file.
+func decodeGRIB2Message(r *bufio.Reader) ([]*GridField, error) {
+	var err error
+
+	// Section 0, the indicator section.
+	indicator := make([]byte, 16)
+	if _, err = io.ReadFull(r, indicator); err != nil {
+		return nil, err
+	}
+	if string(indicator[:4]) != "GRIB" {
+		return nil, errors.New("smhi: not a GRIB file")
+	}
+	if indicator[7] != 2 {
+		return nil, fmt.Errorf("%w: edition %d", ErrUnsupportedGRIB, indicator[7])
+	}
+
+	var ret []*GridField
+	f := &grib2Field{}
+	f.Discipline = int(indicator[6])
+
+	for {
+		head := make([]byte, 4)
+		if _, err = io.ReadFull(r, head); err != nil {
+			return nil, err
+		}
+		if string(head) == "7777" {
+			return ret, nil
+		}
+
+		length := int(binary.BigEndian.Uint32(head))
+		if length < 5 {
+			return nil, errors.New("smhi: invalid GRIB2 section")
+		}
+		sec := make([]byte, length)
+		copy(sec, head)
+		if _, err = io.ReadFull(r, sec[4:]); err != nil {
+			return nil, err
+		}
+
+		switch sec[4] {
+		case 1:
+			err = f.identification(sec)
+			break
+		case 3:
+			err = f.gridDefinition(sec)
+			break
+		case 4:
+			err = f.productDefinition(sec)
+			break
+		case 5:
+			err = f.dataRepresentation(sec)
+			break
+		case 6:
+			err = f.bitmapSection(sec)
+			break
+		case 7:
+			var gf *GridField
+			if gf, err = f.data(sec); err == nil {
+				ret = append(ret, gf)
+			}
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// checkLength returns an error if the section is shorter than n bytes.
+func checkLength(sec []byte, n int) error {
+	if len(sec) < n {
+		return fmt.Errorf("smhi: GRIB2 section %d is too short", sec[4])
+	}
+	return nil
+}
+
+// signed returns the sign and magnitude encoded integer of the bytes.
+func signed(b []byte) int {
+	var v uint64
+	for _, c := range b {
+		v = v<<8 | uint64(c)
+	}
+
+	sign := uint64(1) << (uint(len(b))*8 - 1)
+	if v&sign != 0 {
+		return -int(v &^ sign)
+	}
+	return int(v)
+}
+
+// identification decodes section 1, which holds the reference time.
+func (f *grib2Field) identification(sec []byte) error {
+	if err := checkLength(sec, 19); err != nil {
+		return err
+	}
+
+	f.ReferenceTime = time.Date(int(binary.BigEndian.Uint16(sec[12:14])), time.Month(sec[14]), int(sec[15]),
+		int(sec[16]), int(sec[17]), int(sec[18]), 0, time.UTC)
+	return nil
+}
+
+// gridDefinition decodes section 3, which holds the grid.
+func (f *grib2Field) gridDefinition(sec []byte) error {
+	if err := checkLength(sec, 14); err != nil {
+		return err
+	}
+	if template := binary.BigEndian.Uint16(sec[12:14]); template != 0 {
+		return fmt.Errorf("%w: grid definition template 3.%d", ErrUnsupportedGRIB, template)
+	}
+	if err := checkLength(sec, 72); err != nil {
+		return err
+	}
+
+	// The coordinates are in micro degrees unless the basic angle says
+	// otherwise.
+	unit := 1e-6
+	if basic, sub := binary.BigEndian.Uint32(sec[38:42]), binary.BigEndian.Uint32(sec[42:46]); basic != 0 && basic != math.MaxUint32 {
+		unit = float64(basic) / float64(sub)
+	}
+
+	mode := sec[71]
+	if mode&0x30 != 0 {
+		return fmt.Errorf("%w: scanning mode %#x", ErrUnsupportedGRIB, mode)
+	}
+
+	g := &latLonGrid{
+		ni:   int(binary.BigEndian.Uint32(sec[30:34])),
+		nj:   int(binary.BigEndian.Uint32(sec[34:38])),
+		lat1: float64(signed(sec[46:50])) * unit,
+		lon1: float64(signed(sec[50:54])) * unit,
+		di:   float64(binary.BigEndian.Uint32(sec[63:67])) * unit,
+		dj:   float64(binary.BigEndian.Uint32(sec[67:71])) * unit,
+	}
+	if mode&0x80 != 0 {
+		g.di = -g.di
+	}
+	if mode&0x40 == 0 {
+		g.dj = -g.dj
+	}
+	f.Grid = g
+
+	return nil
+}
+
+// gribTimeUnits maps the GRIB2 units of time to durations.
+var gribTimeUnits = map[byte]time.Duration{
+	0:  time.Minute,
+	1:  time.Hour,
+	2:  24 * time.Hour,
+	10: 3 * time.Hour,
+	11: 6 * time.Hour,
+	12: 12 * time.Hour,
+	13: time.Second,
+}
+
+// productDefinition decodes section 4, which holds the parameter, the
+// level and the valid time.
+func (f *grib2Field) productDefinition(sec []byte) error {
+	if err := checkLength(sec, 9); err != nil {
+		return err
+	}
+	if template := binary.BigEndian.Uint16(sec[7:9]); template != 0 {
+		return fmt.Errorf("%w: product definition template 4.%d", ErrUnsupportedGRIB, template)
+	}
+	if err := checkLength(sec, 28); err != nil {
+		return err
+	}
+
+	f.Category = int(sec[9])
+	f.Number = int(sec[10])
+
+	unit, ok := gribTimeUnits[sec[17]]
+	if !ok {
+		return fmt.Errorf("%w: unit of time %d", ErrUnsupportedGRIB, sec[17])
+	}
+	f.ValidTime = f.ReferenceTime.Add(time.Duration(signed(sec[18:22])) * unit)
+
+	f.SurfaceType = int(sec[22])
+	f.SurfaceValue = float64(signed(sec[24:28])) * math.Pow(10, -float64(int8(sec[23])))
+
+	return nil
+}
+
+// dataRepresentation decodes section 5, which holds the packing.
+func (f *grib2Field) dataRepresentation(sec []byte) error {
+	if err := checkLength(sec, 11); err != nil {
+		return err
+	}
+	if template := binary.BigEndian.Uint16(sec[9:11]); template != 0 {
+		return fmt.Errorf("%w: data representation template 5.%d", ErrUnsupportedGRIB, template)
+	}
+	if err := checkLength(sec, 21); err != nil {
+		return err
+	}
+
+	f.npoints = int(binary.BigEndian.Uint32(sec[5:9]))
+	f.reference = float64(math.Float32frombits(binary.BigEndian.Uint32(sec[11:15])))
+	f.binary = signed(sec[15:17])
+	f.decimal = signed(sec[17:19])
+	f.bits = int(sec[19])
+
+	return nil
+}
+
+// bitmapSection decodes section 6, which tells which grid points have
+// data.
+func (f *grib2Field) bitmapSection(sec []byte) error {
+	if err := checkLength(sec, 6); err != nil {
+		return err
+	}
+
+	switch sec[5] {
+	case 0:
+		f.bitmap = sec[6:]
+		break
+	case 254:
+		// The previous bitmap applies.
+		break
+	case 255:
+		f.bitmap = nil
+		break
+	default:
+		return fmt.Errorf("%w: predefined bitmap %d", ErrUnsupportedGRIB, sec[5])
+	}
+
+	return nil
+}
+
+// data decodes section 7 and returns the completed field.
+func (f *grib2Field) data(sec []byte) (*GridField, error) {
+	if f.Grid == nil {
+		return nil, errors.New("smhi: GRIB2 data without a grid")
+	}
+
+	packed := sec[5:]
+	scale := math.Pow(2, float64(f.binary))
+	decimal := math.Pow(10, float64(f.decimal))
+
+	values := make([]float64, f.Grid.Len())
+	var bit uint
+	for i := range values {
+		if f.bitmap != nil && (i/8 >= len(f.bitmap) || f.bitmap[i/8]&(0x80>>uint(i%8)) == 0) {
+			values[i] = math.NaN()
+			continue
+		}
+
+		var x uint64
+		for b := 0; b < f.bits; b++ {
+			byteIndex := bit / 8
+			if int(byteIndex) >= len(packed) {
+				return nil, errors.New("smhi: GRIB2 data is too short")
+			}
+			x = x<<1 | uint64(packed[byteIndex]>>(7-bit%8)&1)
+			bit++
+		}
+		values[i] = (f.reference + float64(x)*scale) / decimal
+	}
+
+	gf := f.GridField
+	gf.Values = values
+	return &gf, nil
+}
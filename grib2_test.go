@@ -0,0 +1,288 @@
+package smhi
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+// grib2Section returns a GRIB2 section of the given number and length,
+// which is filled by the function.
+func grib2Section(number byte, length int, fill func(sec []byte)) []byte {
+	sec := make([]byte, length)
+	binary.BigEndian.PutUint32(sec, uint32(length))
+	sec[4] = number
+	if fill != nil {
+		fill(sec)
+	}
+	return sec
+}
+
+// putSigned puts the sign and magnitude encoded integer into the bytes.
+func putSigned(b []byte, v int) {
+	neg := v < 0
+	if neg {
+		v = -v
+	}
+	for i := len(b) - 1; i >= 0; i-- {
+		b[i] = byte(v)
+		v >>= 8
+	}
+	if neg {
+		b[0] |= 0x80
+	}
+}
+
+// grib2Grid is the grid definition of a test message, the coordinates are
+// in degrees.
+type grib2Grid struct {
+	ni, nj     int
+	lat1, lon1 float64
+	di, dj     float64
+	mode       byte
+}
+
+// section returns the grid definition section of the grid.
+func (g grib2Grid) section() []byte {
+	return grib2Section(3, 72, func(sec []byte) {
+		binary.BigEndian.PutUint32(sec[30:34], uint32(g.ni))
+		binary.BigEndian.PutUint32(sec[34:38], uint32(g.nj))
+		putSigned(sec[46:50], int(math.Round(g.lat1*1e6)))
+		putSigned(sec[50:54], int(math.Round(g.lon1*1e6)))
+		binary.BigEndian.PutUint32(sec[63:67], uint32(math.Round(g.di*1e6)))
+		binary.BigEndian.PutUint32(sec[67:71], uint32(math.Round(g.dj*1e6)))
+		sec[71] = g.mode
+	})
+}
+
+// grib2TestField is a field of a test message. The values that are NaN
+// are left out of the data by a bitmap, and reuseBitmap reuses the bitmap
+// of the previous field instead.
+type grib2TestField struct {
+	category, number byte
+	hours            int
+	values           []float64
+	reuseBitmap      bool
+}
+
+// sections returns the product definition, data representation, bitmap
+// and data sections of the field, which is packed with one decimal and
+// 12 bits per value.
+func (f grib2TestField) sections() []byte {
+	var ret []byte
+
+	ret = append(ret, grib2Section(4, 34, func(sec []byte) {
+		sec[9], sec[10] = f.category, f.number
+		sec[17] = 1
+		putSigned(sec[18:22], f.hours)
+		sec[22] = 103
+		putSigned(sec[24:28], 2)
+	})...)
+
+	var bitmap []byte
+	var present []float64
+	reference := math.Inf(1)
+	for i, v := range f.values {
+		if i%8 == 0 {
+			bitmap = append(bitmap, 0)
+		}
+		if math.IsNaN(v) {
+			continue
+		}
+		bitmap[i/8] |= 0x80 >> uint(i%8)
+		present = append(present, v)
+		reference = math.Min(reference, math.Round(v*10))
+	}
+
+	const bits = 12
+	ret = append(ret, grib2Section(5, 21, func(sec []byte) {
+		binary.BigEndian.PutUint32(sec[5:9], uint32(len(present)))
+		binary.BigEndian.PutUint32(sec[11:15], math.Float32bits(float32(reference)))
+		putSigned(sec[17:19], 1)
+		sec[19] = bits
+	})...)
+
+	switch {
+	case f.reuseBitmap:
+		ret = append(ret, grib2Section(6, 6, func(sec []byte) { sec[5] = 254 })...)
+		break
+	case len(present) < len(f.values):
+		ret = append(ret, grib2Section(6, 6+len(bitmap), func(sec []byte) { copy(sec[6:], bitmap) })...)
+		break
+	default:
+		ret = append(ret, grib2Section(6, 6, func(sec []byte) { sec[5] = 255 })...)
+		break
+	}
+
+	packed := make([]byte, (len(present)*bits+7)/8)
+	var bit uint
+	for _, v := range present {
+		x := uint64(math.Round(v*10) - reference)
+		for b := bits - 1; b >= 0; b-- {
+			if x>>uint(b)&1 != 0 {
+				packed[bit/8] |= 0x80 >> (bit % 8)
+			}
+			bit++
+		}
+	}
+	ret = append(ret, grib2Section(7, 5+len(packed), func(sec []byte) { copy(sec[5:], packed) })...)
+
+	return ret
+}
+
+// grib2Message returns a GRIB2 message of the meteorological discipline
+// with the reference time 2024-05-01 00:00 UTC, the grid and the fields.
+func grib2Message(g grib2Grid, fields ...grib2TestField) []byte {
+	body := grib2Section(1, 21, func(sec []byte) {
+		binary.BigEndian.PutUint16(sec[12:14], 2024)
+		sec[14], sec[15] = 5, 1
+	})
+	body = append(body, g.section()...)
+	for _, f := range fields {
+		body = append(body, f.sections()...)
+	}
+	body = append(body, "7777"...)
+
+	indicator := make([]byte, 16)
+	copy(indicator, "GRIB")
+	indicator[7] = 2
+	binary.BigEndian.PutUint64(indicator[8:], uint64(16+len(body)))
+
+	return append(indicator, body...)
+}
+
+func TestGRIB2Decoder(t *testing.T) {
+	nan := math.NaN()
+
+	// The first message scans from north to south, so its latitude step is
+	// negative, and its fields leave out the point at 12, 56 by bitmaps.
+	// The second message scans from south to north without a bitmap.
+	var file bytes.Buffer
+	file.Write(grib2Message(grib2Grid{ni: 3, nj: 2, lat1: 56, lon1: 10, di: 1, dj: 1, mode: 0x00},
+		grib2TestField{category: 0, number: 0, hours: 2, values: []float64{5, 6, nan, 8, 9, 10}},
+		grib2TestField{category: 2, number: 1, hours: 2, values: []float64{1.5, 2.5, nan, 3.5, 4.5, 5.5}, reuseBitmap: true},
+	))
+	file.Write(grib2Message(grib2Grid{ni: 3, nj: 2, lat1: 55, lon1: 10, di: 1, dj: 1, mode: 0x40},
+		grib2TestField{category: 0, number: 0, hours: 1, values: []float64{-1.5, 2, 2.5, 3, 3.5, 4}},
+	))
+
+	fields, err := GRIB2Decoder{}.Decode(&file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fields) != 3 {
+		t.Fatalf("got %d fields, want 3", len(fields))
+	}
+
+	f := fields[0]
+	reference := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	if !f.ReferenceTime.Equal(reference) || !f.ValidTime.Equal(reference.Add(2*time.Hour)) {
+		t.Errorf("got reference time %s and valid time %s", f.ReferenceTime, f.ValidTime)
+	}
+	if f.SurfaceType != 103 || f.SurfaceValue != 2 {
+		t.Errorf("got surface %d at %v", f.SurfaceType, f.SurfaceValue)
+	}
+	if lon, lat := f.Grid.Point(3); lon != 10 || lat != 55 {
+		t.Errorf("got point %v, %v, want 10, 55", lon, lat)
+	}
+
+	tests := []struct {
+		name     string
+		field    int
+		lon, lat float64
+		want     float64
+		err      error
+	}{
+		{"negative dj first row", 0, 11, 56, 6, nil},
+		{"negative dj second row", 0, 10, 55, 8, nil},
+		{"bitmapped point", 0, 12, 56, 0, ErrNoGridData},
+		{"point after the bitmapped one", 0, 12, 55, 10, nil},
+		{"reused bitmap", 1, 12, 56, 0, ErrNoGridData},
+		{"reused bitmap value", 1, 11, 55, 4.5, nil},
+		{"positive dj first row", 2, 10, 55, -1.5, nil},
+		{"positive dj second row", 2, 11, 56, 3.5, nil},
+		{"nearest point", 2, 11.4, 55.6, 3.5, nil},
+		{"outside of the grid", 2, 14, 56, 0, ErrNoGridData},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := fields[tt.field].ValueAt(tt.lon, tt.lat)
+			if err != tt.err {
+				t.Fatalf("got error %v, want %v", err, tt.err)
+			}
+			if math.Abs(v-tt.want) > 1e-9 {
+				t.Errorf("got %v, want %v", v, tt.want)
+			}
+		})
+	}
+
+	// The series is ordered by the valid time, and the fields of other
+	// parameters are skipped.
+	series := GridTimeSeries(fields, 0, 0, 0, 11, 56)
+	if len(series) != 2 {
+		t.Fatalf("got %d values, want 2", len(series))
+	}
+	if !series[0].Timestamp.Equal(reference.Add(time.Hour)) || series[0].Value != 3.5 || series[1].Value != 6 {
+		t.Errorf("got series %+v", series)
+	}
+	if series := GridTimeSeries(fields, 0, 0, 0, 12, 56); len(series) != 1 || series[0].Value != 4 {
+		t.Errorf("got series %+v at the bitmapped point", series)
+	}
+}
+
+func TestGRIB2DecoderErrors(t *testing.T) {
+	grid := grib2Grid{ni: 1, nj: 1, lat1: 55, lon1: 10, di: 1, dj: 1}
+	field := grib2TestField{values: []float64{1}}
+
+	unsupportedGrid := grib2Message(grid, field)
+	binary.BigEndian.PutUint16(unsupportedGrid[16+21+12:], 30)
+
+	edition1 := grib2Message(grid, field)
+	edition1[7] = 1
+
+	tests := []struct {
+		name string
+		data []byte
+		err  error
+	}{
+		{"unsupported grid", unsupportedGrid, ErrUnsupportedGRIB},
+		{"edition 1", edition1, ErrUnsupportedGRIB},
+		{"not GRIB", []byte("GRIDxxxxxxxxxxxx"), nil},
+		{"truncated", grib2Message(grid, field)[:60], nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := GRIB2Decoder{}.Decode(bytes.NewReader(tt.data))
+			if err == nil {
+				t.Fatal("got no error")
+			}
+			if tt.err != nil && !errors.Is(err, tt.err) {
+				t.Errorf("got error %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestSigned(t *testing.T) {
+	tests := []struct {
+		b    []byte
+		want int
+	}{
+		{[]byte{0x00, 0x01}, 1},
+		{[]byte{0x80, 0x01}, -1},
+		{[]byte{0x80, 0x00}, 0},
+		{[]byte{0x83, 0x48, 0x5b, 0x00}, -55073536},
+		{[]byte{0x03, 0x48, 0x5b, 0x00}, 55073536},
+	}
+
+	for _, tt := range tests {
+		if got := signed(tt.b); got != tt.want {
+			t.Errorf("signed(% x) = %d, want %d", tt.b, got, tt.want)
+		}
+	}
+}
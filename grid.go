@@ -0,0 +1,95 @@
+package smhi
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// GridPath returns the path of the grid file with the given name.
+func (a *Archive) GridPath(name string) string {
+	return filepath.Join(a.dir, "grids", filepath.Base(name))
+}
+
+// DownloadGrid downloads the gridded forecast file at the URL into the
+// archive under the given name and returns its path. The file is skipped
+// if it has already been downloaded and matches its checksum, and an
+// interrupted download is resumed where it left off. Progress is called
+// with the bytes done and the total, which is -1 if it's unknown.
+func (c *Client) DownloadGrid(ctx context.Context, a *Archive, url, name string, progress func(done, total int64)) (string, error) {
+	path := a.GridPath(name)
+	if err := c.download(ctx, url, path, progress); err != nil {
+		return "", err
+	}
+
+	return path, nil
+}
+
+// PruneGrids removes the grid files that are older than maxAge, and then
+// the oldest files until the rest take up at most maxBytes. The partial
+// files of interrupted downloads that are older than maxAge are removed as
+// well. A limit that is zero isn't applied.
+func (a *Archive) PruneGrids(maxAge time.Duration, maxBytes int64) error {
+	var err error
+
+	dir := filepath.Join(a.dir, "grids")
+	var f *os.File
+	if f, err = os.Open(dir); os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	infos, err := f.Readdir(-1)
+	f.Close()
+	if err != nil {
+		return err
+	}
+
+	// Only the finished files count, the partial files and the checksums
+	// are removed along with them. The partial files of downloads that
+	// never finished are removed once they are older than maxAge.
+	var files []os.FileInfo
+	for _, fi := range infos {
+		if fi.IsDir() || strings.HasSuffix(fi.Name(), ".sha256") {
+			continue
+		}
+		if strings.HasSuffix(fi.Name(), ".part") {
+			if maxAge > 0 && time.Since(fi.ModTime()) > maxAge {
+				if err = os.Remove(filepath.Join(dir, fi.Name())); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		files = append(files, fi)
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].ModTime().Before(files[j].ModTime())
+	})
+
+	var total int64
+	for _, fi := range files {
+		total += fi.Size()
+	}
+
+	for _, fi := range files {
+		old := maxAge > 0 && time.Since(fi.ModTime()) > maxAge
+		big := maxBytes > 0 && total > maxBytes
+		if !old && !big {
+			continue
+		}
+
+		path := filepath.Join(dir, fi.Name())
+		if err = os.Remove(path); err != nil {
+			return err
+		}
+		os.Remove(path + ".part")
+		os.Remove(path + ".sha256")
+		total -= fi.Size()
+	}
+
+	return nil
+}
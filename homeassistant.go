@@ -0,0 +1,95 @@
+package smhi
+
+import (
+	"time"
+)
+
+// HomeAssistantForecast is a time step in the shape of the forecasts of the
+// Home Assistant weather entities.
+type HomeAssistantForecast struct {
+	Datetime            time.Time `json:"datetime"`
+	Condition           string    `json:"condition"`
+	Temperature         float64   `json:"temperature"`
+	ApparentTemperature float64   `json:"apparent_temperature"`
+	Humidity            uint8     `json:"humidity"`
+	Pressure            float64   `json:"pressure"`
+	Precipitation       float64   `json:"precipitation"`
+	CloudCoverage       float64   `json:"cloud_coverage"`
+	WindBearing         uint16    `json:"wind_bearing"`
+	WindSpeed           float64   `json:"wind_speed"`
+	WindGustSpeed       float64   `json:"wind_gust_speed"`
+	Visibility          float64   `json:"visibility"`
+}
+
+// HomeAssistantWeather is the current weather and the hourly forecast in
+// the JSON shape that the Home Assistant REST and template weather
+// platforms read. The units are C, hPa, mm/h, m/s and km, the cloud
+// coverage is in percent.
+type HomeAssistantWeather struct {
+	HomeAssistantForecast
+	Forecast []HomeAssistantForecast `json:"forecast"`
+}
+
+// HomeAssistant returns the forecast in the Home Assistant shape, the
+// current weather is the time step of the current hour.
+func (pf *PointForecast) HomeAssistant(now time.Time) *HomeAssistantWeather {
+	ret := &HomeAssistantWeather{Forecast: []HomeAssistantForecast{}}
+	lon, lat := pf.Geometry.point()
+
+	for i := range pf.TimeSeries {
+		f := &pf.TimeSeries[i]
+		if f.Timestamp.Add(time.Hour).Before(now) {
+			continue
+		}
+
+		hf := HomeAssistantForecast{
+			Datetime:            f.Timestamp,
+			Condition:           getHomeAssistantCondition(f, lon, lat),
+			Temperature:         f.AirTemperature,
+			ApparentTemperature: f.ApparentTemperature,
+			Humidity:            f.RelativeHumidity,
+			Pressure:            f.AirPressure,
+			Precipitation:       f.MeanPrecipitationIntensity,
+			CloudCoverage:       float64(f.MeanValueOfTotalCloudCover) * 12.5,
+			WindBearing:         f.WindDirection,
+			WindSpeed:           f.WindSpeed,
+			WindGustSpeed:       f.WindGustSpeed,
+			Visibility:          f.HorizontalVisibility,
+		}
+		if len(ret.Forecast) == 0 {
+			ret.HomeAssistantForecast = hf
+		}
+		ret.Forecast = append(ret.Forecast, hf)
+	}
+
+	return ret
+}
+
+// getHomeAssistantCondition returns the Home Assistant condition of the
+// time step, clear sky is "clear-night" when the sun is down.
+func getHomeAssistantCondition(f *Forecast, lon, lat float64) string {
+	switch f.WeatherSymbol {
+	case ClearSky, NearlyClearSky:
+		if solarElevation(f.Timestamp, lon, lat) < sunHorizon {
+			return "clear-night"
+		}
+		return "sunny"
+	case VariableCloudiness, HalfclearSky:
+		return "partlycloudy"
+	case CloudySky, Overcast:
+		return "cloudy"
+	case Fog:
+		return "fog"
+	case Thunderstorm, Thunder:
+		return "lightning-rainy"
+	case HeavyRainShowers, HeavyRain:
+		return "pouring"
+	case LightRainShowers, ModerateRainShowers, LightRain, ModerateRain:
+		return "rainy"
+	case LightSleetShowers, ModerateSleetShowers, HeavySleetShowers, LightSleet, ModerateSleet, HeavySleet:
+		return "snowy-rainy"
+	case LightSnowShowers, ModerateSnowShowers, HeavySnowShowers, LightSnowfall, ModerateSnowfall, HeavySnowfall:
+		return "snowy"
+	}
+	return "exceptional"
+}
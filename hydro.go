@@ -0,0 +1,114 @@
+package smhi
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	hydrobsURL = "https://opendata-download-hydrobs.smhi.se/api/version/1.0"
+)
+
+// GetHydroParameters fetches the parameters of the hydrological
+// observations API using the default client.
+func GetHydroParameters() ([]ObservationParameter, error) {
+	return defaultClient.GetHydroParameters()
+}
+
+// GetHydroParameters fetches the parameters of the hydrological
+// observations API, such as discharge and water levels.
+func (c *Client) GetHydroParameters() ([]ObservationParameter, error) {
+	return c.getParameters(hydrobsURL)
+}
+
+// HydroParameter returns the first hydrological parameter whose title
+// contains the given text, such as "vattenföring" for the discharge, using
+// the default client.
+func HydroParameter(title string) (int, error) {
+	return defaultClient.HydroParameter(title)
+}
+
+// HydroParameter returns the first hydrological parameter whose title
+// contains the given lower case text, such as "vattenföring" for the
+// discharge, "vattenstånd" for the water level and "temperatur" for the
+// water temperature.
+func (c *Client) HydroParameter(title string) (int, error) {
+	var err error
+
+	var params []ObservationParameter
+	if params, err = c.GetHydroParameters(); err != nil {
+		return 0, err
+	}
+
+	for _, p := range params {
+		if strings.Contains(strings.ToLower(p.Title), title) {
+			return strconv.Atoi(p.Key)
+		}
+	}
+	return 0, fmt.Errorf("smhi: no hydrological parameter matching %q found", title)
+}
+
+// GetHydroStations fetches all gauging stations that observe the given
+// hydrological parameter using the default client.
+func GetHydroStations(parameter int) ([]Station, error) {
+	return defaultClient.GetHydroStations(parameter)
+}
+
+// GetHydroStations fetches all gauging stations that observe the given
+// hydrological parameter, the catchment of each station is included.
+func (c *Client) GetHydroStations(parameter int) ([]Station, error) {
+	var err error
+
+	var decodedData *ParameterAPI
+	if decodedData, err = c.getParameter(context.Background(), hydrobsURL, parameter); err != nil {
+		return nil, err
+	}
+
+	return toStations(decodedData), nil
+}
+
+// NearestHydroStation returns the gauging station of the hydrological
+// parameter that matches all of the filters and is nearest to the given
+// longitude and latitude, along with its distance in km, using the default
+// client.
+func NearestHydroStation(parameter int, lon, lat float64, filters ...StationFilter) (*Station, float64, error) {
+	return defaultClient.NearestHydroStation(parameter, lon, lat, filters...)
+}
+
+// NearestHydroStation returns the gauging station of the hydrological
+// parameter that matches all of the filters and is nearest to the given
+// longitude and latitude, along with its distance in km.
+func (c *Client) NearestHydroStation(parameter int, lon, lat float64, filters ...StationFilter) (*Station, float64, error) {
+	var err error
+
+	var stations []Station
+	if stations, err = c.GetHydroStations(parameter); err != nil {
+		return nil, 0, err
+	}
+
+	return nearestStation(FilterStations(stations, filters...), lon, lat)
+}
+
+// GetHydroObservations fetches the observations of the hydrological
+// parameter at the gauging station for the given period using the default
+// client.
+func GetHydroObservations(parameter, station int, period ObservationPeriod) (*Observations, error) {
+	return defaultClient.GetHydroObservations(parameter, station, period)
+}
+
+// GetHydroObservations fetches the observations of the hydrological
+// parameter at the gauging station for the given period, such as the
+// daily discharge in m³/s or the water level.
+func (c *Client) GetHydroObservations(parameter, station int, period ObservationPeriod) (*Observations, error) {
+	return c.getObservations(context.Background(), hydrobsURL, parameter, station, period)
+}
+
+// CatchmentStations returns the stations that are located in the
+// catchment with the given number.
+func CatchmentStations(stations []Station, catchment int) []Station {
+	return FilterStations(stations, func(s *Station) bool {
+		return s.CatchmentNumber == catchment
+	})
+}
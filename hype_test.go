@@ -0,0 +1,93 @@
+package smhi
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// testHydroForecastJSON is an S-HYPE forecast of a sub-basin with two days
+// before the reference time and two after it, the last day has no lake
+// water level.
+const testHydroForecastJSON = `{
+	"subid": 12345,
+	"name": "Göta älv",
+	"area": 512.5,
+	"lon": 12.0,
+	"lat": 57.9,
+	"downstream": 12344,
+	"referenceTime": "2024-05-03T06:00:00Z",
+	"timeSeries": [
+		{"date": "2024-05-01", "cout": 120.5, "wcom": 210},
+		{"date": "2024-05-02", "cout": 125, "wcom": 212},
+		{"date": "2024-05-03", "cout": 131.5, "wcom": 215},
+		{"date": "2024-05-04", "cout": 140}
+	]
+}`
+
+// hypeServer returns a test server of the sub-basin lookup and the S-HYPE
+// forecasts that knows the sub-basin 12345 only.
+func hypeServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/subbasin":
+			if r.URL.Query().Get("lon") != "12.000000" {
+				http.NotFound(w, r)
+				return
+			}
+			w.Write([]byte(`{"subid": 12345, "name": "Göta älv", "area": 512.5, "lon": 12.0, "lat": 57.9, "downstream": 12344}`))
+			break
+		case "/subbasin/12345/forecast":
+			w.Write([]byte(testHydroForecastJSON))
+			break
+		default:
+			http.NotFound(w, r)
+			break
+		}
+	}))
+}
+
+func TestGetSubBasin(t *testing.T) {
+	srv := hypeServer()
+	defer srv.Close()
+
+	c := NewClient(WithHydroForecastURL(srv.URL + "/"))
+	sb, err := c.GetSubBasin(12.0, 57.9)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (SubBasin{ID: 12345, Name: "Göta älv", Area: 512.5, Longitude: 12, Latitude: 57.9, Downstream: 12344}); *sb != want {
+		t.Errorf("got %+v, want %+v", *sb, want)
+	}
+
+	if _, err = c.GetSubBasin(5.0, 57.9); !errors.Is(err, ErrNoBasin) {
+		t.Errorf("got error %v, want %v", err, ErrNoBasin)
+	}
+}
+
+func TestGetHydroForecast(t *testing.T) {
+	srv := hypeServer()
+	defer srv.Close()
+
+	c := NewClient(WithHydroForecastURL(srv.URL))
+	hf, err := c.GetHydroForecast(12345)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if hf.SubBasin.ID != 12345 || !hf.ReferenceTime.Equal(time.Date(2024, 5, 3, 6, 0, 0, 0, time.UTC)) {
+		t.Errorf("got sub-basin %d and reference time %s", hf.SubBasin.ID, hf.ReferenceTime)
+	}
+	if len(hf.Discharge) != 4 || hf.Discharge[3].Value != 140 || !hf.Discharge[3].Timestamp.Equal(time.Date(2024, 5, 4, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("got discharge %+v", hf.Discharge)
+	}
+	if len(hf.WaterLevel) != 3 || hf.WaterLevel[2].Value != 215 {
+		t.Errorf("got water level %+v", hf.WaterLevel)
+	}
+
+	if _, err = c.GetHydroForecast(1); !errors.Is(err, ErrNoBasin) {
+		t.Errorf("got error %v, want %v", err, ErrNoBasin)
+	}
+}
@@ -0,0 +1,216 @@
+package smhi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// ErrNoIceChart is returned when there is no ice chart for a date.
+var ErrNoIceChart = errors.New("smhi: no ice chart for the date")
+
+// ErrNoIceData is returned when the ice chart doesn't cover a location.
+var ErrNoIceData = errors.New("smhi: no ice data for the location")
+
+// IceThickness is a class of ice thickness, which is based on the stage of
+// development of the ice.
+type IceThickness struct {
+	// Min and Max is the range of the thickness in cm, Max is zero if
+	// there is no upper limit.
+	Min int
+	Max int
+}
+
+// iceStages maps the SIGRID-3 stage of development codes to their
+// thickness.
+var iceStages = map[string]IceThickness{
+	"81": {0, 10},
+	"82": {0, 10},
+	"83": {10, 30},
+	"84": {10, 15},
+	"85": {15, 30},
+	"86": {30, 200},
+	"87": {30, 70},
+	"88": {30, 50},
+	"89": {50, 70},
+	"91": {70, 120},
+	"93": {120, 0},
+	"95": {200, 0},
+	"96": {200, 0},
+	"97": {200, 0},
+}
+
+// IceConditions holds the ice conditions at a location. Concentration is
+// the share of the sea surface that is covered by ice in percent.
+type IceConditions struct {
+	Date          time.Time
+	Concentration float64
+	Thickness     IceThickness
+	Description   map[string]string
+}
+
+// iceFeature is a polygon of an ice chart.
+type iceFeature struct {
+	concentration float64
+	thickness     IceThickness
+	polygons      [][][][2]float64
+	min, max      [2]float64
+}
+
+// IceChart holds the ice conditions of a date.
+type IceChart struct {
+	Date     time.Time
+	features []iceFeature
+}
+
+// LoadIceChart loads an ice chart for the given date from a GeoJSON feature
+// collection in longitude and latitude, with the SIGRID-3 attributes CT
+// for the total concentration and SA for the stage of development of the
+// thickest ice.
+func LoadIceChart(r io.Reader, date time.Time) (*IceChart, error) {
+	var err error
+
+	var fc geoJSONFeatureCollection
+	if err = json.NewDecoder(r).Decode(&fc); err != nil {
+		return nil, err
+	}
+
+	ret := &IceChart{Date: date}
+	for _, f := range fc.Features {
+		var ice iceFeature
+		if ice.polygons, err = f.Geometry.polygons(); err != nil {
+			return nil, err
+		}
+		if ice.polygons == nil {
+			continue
+		}
+		ice.min, ice.max = boundingBox(ice.polygons)
+
+		if ct, ok := f.Properties["CT"]; ok {
+			ice.concentration = iceConcentration(fmt.Sprint(ct))
+		}
+		if sa, ok := f.Properties["SA"]; ok {
+			ice.thickness = iceStages[fmt.Sprint(sa)]
+		}
+
+		ret.features = append(ret.features, ice)
+	}
+
+	return ret, nil
+}
+
+// iceConcentration converts a SIGRID-3 concentration code to percent,
+// ranges such as 7-9 tenths are converted to their mean.
+func iceConcentration(code string) float64 {
+	switch code {
+	case "00", "02":
+		return 0
+	case "01":
+		return 5
+	case "91":
+		return 95
+	case "92":
+		return 100
+	}
+
+	n, err := strconv.Atoi(code)
+	if err != nil || n < 0 || n > 99 {
+		return 0
+	}
+
+	lo, hi := n/10, n%10
+	if hi == 0 {
+		return float64(lo) * 10
+	}
+	if hi < lo {
+		hi = 10
+	}
+	return float64(lo+hi) * 5
+}
+
+// IceAt returns the ice conditions at the given longitude and latitude.
+func (ic *IceChart) IceAt(lon, lat float64) (*IceConditions, error) {
+	for _, f := range ic.features {
+		if !boxContains(f.min, f.max, lon, lat) {
+			continue
+		}
+
+		for _, p := range f.polygons {
+			if polygonContains(p, lon, lat) {
+				ret := &IceConditions{
+					Date:          ic.Date,
+					Concentration: f.concentration,
+					Thickness:     f.thickness,
+				}
+				ret.Description = getIceDescription(ret)
+
+				return ret, nil
+			}
+		}
+	}
+
+	return nil, ErrNoIceData
+}
+
+// IceCharts holds ice charts of several dates.
+type IceCharts []*IceChart
+
+// IceAt returns the ice conditions at the given longitude and latitude
+// from the latest chart on or before the given date.
+func (ics IceCharts) IceAt(lon, lat float64, date time.Time) (*IceConditions, error) {
+	charts := make(IceCharts, len(ics))
+	copy(charts, ics)
+	sort.Slice(charts, func(i, j int) bool {
+		return charts[i].Date.After(charts[j].Date)
+	})
+
+	for _, c := range charts {
+		if !c.Date.After(date) {
+			return c.IceAt(lon, lat)
+		}
+	}
+
+	return nil, ErrNoIceChart
+}
+
+// getIceDescription returns a friendly description of the ice conditions.
+func getIceDescription(ic *IceConditions) map[string]string {
+	ret := make(map[string]string)
+
+	c := ic.Concentration
+	if c < 10 {
+		ret["sv-SE"] = "Öppet vatten"
+		ret["en-US"] = "Open water"
+		return ret
+	} else if c < 40 {
+		ret["sv-SE"] = "Mycket spridd drivis"
+		ret["en-US"] = "Very open drift ice"
+	} else if c < 70 {
+		ret["sv-SE"] = "Spridd drivis"
+		ret["en-US"] = "Open drift ice"
+	} else if c < 90 {
+		ret["sv-SE"] = "Tät drivis"
+		ret["en-US"] = "Close drift ice"
+	} else if c < 100 {
+		ret["sv-SE"] = "Mycket tät drivis"
+		ret["en-US"] = "Very close drift ice"
+	} else {
+		ret["sv-SE"] = "Sammanhängande is"
+		ret["en-US"] = "Compact ice"
+	}
+
+	t := ic.Thickness
+	if t.Max > 0 {
+		ret["sv-SE"] += fmt.Sprintf(", %d-%d cm tjock", t.Min, t.Max)
+		ret["en-US"] += fmt.Sprintf(", %d-%d cm thick", t.Min, t.Max)
+	} else if t.Min > 0 {
+		ret["sv-SE"] += fmt.Sprintf(", över %d cm tjock", t.Min)
+		ret["en-US"] += fmt.Sprintf(", over %d cm thick", t.Min)
+	}
+
+	return ret
+}
@@ -0,0 +1,145 @@
+package smhi
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+)
+
+// gridSpacing is the approximate distance in km between the grid points of
+// the point forecasts.
+const gridSpacing = 2.5
+
+// interpolatedParameters holds the continuous parameters that are
+// interpolated, the ones that are true are rounded since their fields are
+// integers. The wind direction, the precipitation category and the weather
+// symbol are kept from the nearest grid point.
+var interpolatedParameters = map[string]bool{
+	"msl":      false,
+	"t":        false,
+	"vis":      false,
+	"ws":       false,
+	"r":        true,
+	"tstm":     true,
+	"tcc_mean": true,
+	"lcc_mean": true,
+	"mcc_mean": true,
+	"hcc_mean": true,
+	"gust":     false,
+	"pmin":     false,
+	"pmax":     false,
+	"spp":      true,
+	"pmean":    false,
+	"pmedian":  false,
+}
+
+// WithInterpolation makes the client fetch the grid points around the
+// requested location as well, and bilinearly interpolate the continuous
+// parameters to the location. The geometry of the forecast is the
+// requested location. Four forecasts are fetched for each point forecast.
+func WithInterpolation() Option {
+	return func(c *Client) {
+		c.interpolation = true
+	}
+}
+
+// interpolate interpolates the forecast of the nearest grid point to the
+// given longitude and latitude. The neighbours are the grid points one grid
+// spacing towards the location along each axis and diagonally, the ones that
+// are outside of the grid are left out of the interpolation.
+func (c *Client) interpolate(ctx context.Context, pf *PointForecast, lon, lat float64) error {
+	var err error
+
+	glon, glat := pf.Geometry.point()
+	dlat := gridSpacing / (earthRadius * math.Pi / 180)
+	dlon := dlat / math.Cos(glat*math.Pi/180)
+	if lon < glon {
+		dlon = -dlon
+	}
+	if lat < glat {
+		dlat = -dlat
+	}
+
+	// The neighbours along the longitude, the latitude and the diagonal.
+	var neighbours [3]*PointForecast
+	for i, d := range [3][2]float64{{dlon, 0}, {0, dlat}, {dlon, dlat}} {
+		if neighbours[i], _, _, err = c.fetchPointForecast(ctx, glon+d[0], glat+d[1]); errors.Is(err, ErrOutsideGrid) {
+			neighbours[i] = nil
+		} else if err != nil {
+			return err
+		}
+	}
+
+	// The fractions of the way to the neighbours, the axes whose neighbour
+	// is missing or is the same grid point aren't interpolated.
+	fraction := func(n *PointForecast, v, v0 float64, axis int) float64 {
+		if n == nil {
+			return 0
+		}
+		p := n.Geometry.Coordinates
+		if len(p) == 0 || len(p[0]) < 2 || p[0][axis] == v0 {
+			return 0
+		}
+		return math.Max(0, math.Min(1, (v-v0)/(p[0][axis]-v0)))
+	}
+	tx := fraction(neighbours[0], lon, glon, 0)
+	ty := fraction(neighbours[1], lat, glat, 1)
+
+	steps := make([]map[time.Time]*Forecast, len(neighbours))
+	for i, n := range neighbours {
+		steps[i] = make(map[time.Time]*Forecast)
+		if n == nil {
+			continue
+		}
+		for j := range n.TimeSeries {
+			steps[i][n.TimeSeries[j].Timestamp] = &n.TimeSeries[j]
+		}
+	}
+
+	for i := range pf.TimeSeries {
+		f := &pf.TimeSeries[i]
+		x, y, xy := steps[0][f.Timestamp], steps[1][f.Timestamp], steps[2][f.Timestamp]
+
+		for name, round := range interpolatedParameters {
+			if c.parameters != nil && !c.parameters[name] {
+				continue
+			}
+
+			v00, ok := f.Value(name)
+			if !ok {
+				continue
+			}
+			value := func(n *Forecast, def float64) float64 {
+				if n == nil {
+					return def
+				}
+				if v, ok := n.Value(name); ok {
+					return v
+				}
+				return def
+			}
+			v10 := value(x, v00)
+			v01 := value(y, v00)
+			v11 := value(xy, (v10+v01)/2)
+
+			v := v00*(1-tx)*(1-ty) + v10*tx*(1-ty) + v01*(1-tx)*ty + v11*tx*ty
+			if round {
+				v = math.Round(v)
+			}
+			f.setValue(name, v)
+		}
+
+		if c.parameters == nil {
+			derive(f, lon, lat)
+			if c.descriptions {
+				describe(f)
+			}
+		}
+		f.Hash = getHash(f)
+	}
+
+	pf.Geometry = Geometry{Type: "Point", Coordinates: []Coordinate{{lon, lat}}}
+
+	return nil
+}
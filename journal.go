@@ -0,0 +1,156 @@
+package smhi
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// Cache outcomes of journal entries.
+const (
+	CacheHit         = "hit"
+	CacheMiss        = "miss"
+	CacheRevalidated = "revalidated"
+)
+
+// JournalEntry is a request that is logged by a Journal. Cache is the
+// outcome of the cache of the client, it's empty when the request didn't
+// go through a cache.
+type JournalEntry struct {
+	Time     time.Time
+	Method   string
+	URL      string
+	Status   int           `json:",omitempty"`
+	Bytes    int64         `json:",omitempty"`
+	Duration time.Duration `json:",omitempty"`
+	Cache    string        `json:",omitempty"`
+	Err      string        `json:",omitempty"`
+}
+
+// Journal logs the requests of a client as JSON lines, such as to prove
+// that the fair use terms of SMHI are kept or to find the source of spikes
+// in the usage.
+type Journal struct {
+	mu  sync.Mutex
+	w   io.Writer
+	c   io.Closer
+	err error
+}
+
+// NewJournal returns a journal that writes to w.
+func NewJournal(w io.Writer) *Journal {
+	return &Journal{w: w}
+}
+
+// OpenJournal returns a journal that appends to the file at path, which is
+// created if it doesn't exist.
+func OpenJournal(path string) (*Journal, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &Journal{w: f, c: f}, nil
+}
+
+// WithJournal makes the client log its requests to the journal.
+func WithJournal(j *Journal) Option {
+	return func(c *Client) {
+		c.journal = j
+	}
+}
+
+// Record logs the entry, the first error of writing to the journal is kept
+// and returned by Close as well.
+func (j *Journal) Record(e JournalEntry) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if _, err = j.w.Write(append(data, '\n')); err != nil && j.err == nil {
+		j.err = err
+	}
+	return err
+}
+
+// Close closes the file of the journal, if it was opened by OpenJournal,
+// and returns the first error of writing to it.
+func (j *Journal) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.c != nil {
+		if err := j.c.Close(); err != nil && j.err == nil {
+			j.err = err
+		}
+		j.c = nil
+	}
+	return j.err
+}
+
+// journalBody counts the bytes that are read from a response body, and
+// records the request when the body is closed.
+type journalBody struct {
+	io.ReadCloser
+	j     *Journal
+	e     JournalEntry
+	start time.Time
+	once  sync.Once
+}
+
+// Read reads from the body and counts the bytes.
+func (b *journalBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.e.Bytes += int64(n)
+	return n, err
+}
+
+// Close closes the body and records the request.
+func (b *journalBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(func() {
+		b.e.Duration = time.Since(b.start)
+		b.j.Record(b.e)
+	})
+	return err
+}
+
+// journalRequest records the request with the journal of the client, if it
+// has one. The response body is wrapped so that the request is recorded
+// with its size when the body is closed.
+func (c *Client) journalRequest(req *http.Request, res *http.Response, err error, start time.Time) {
+	if c.journal == nil {
+		return
+	}
+
+	e := JournalEntry{Time: start, Method: req.Method, URL: req.URL.String()}
+	e.Cache, _ = req.Context().Value(cacheOutcomeKey{}).(string)
+	if err != nil {
+		e.Err = err.Error()
+		e.Duration = time.Since(start)
+		c.journal.Record(e)
+		return
+	}
+
+	e.Status = res.StatusCode
+	if res.StatusCode == http.StatusNotModified {
+		e.Cache = CacheRevalidated
+	}
+	res.Body = &journalBody{ReadCloser: res.Body, j: c.journal, e: e, start: start}
+}
+
+// journalCacheHit records a forecast that is returned from the cache with
+// the journal of the client, if it has one.
+func (c *Client) journalCacheHit(url string) {
+	if c.journal == nil {
+		return
+	}
+
+	c.journal.Record(JournalEntry{Time: time.Now(), Method: http.MethodGet, URL: url, Cache: CacheHit})
+}
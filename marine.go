@@ -0,0 +1,132 @@
+package smhi
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SmallCraftThresholds defines the wind speeds in m/s at which a small
+// craft warning is raised, a zero value disables that threshold.
+type SmallCraftThresholds struct {
+	WindSpeed     float64
+	WindGustSpeed float64
+}
+
+// DefaultSmallCraftThresholds raises a small craft warning from a strong
+// breeze or from gusts of moderate gale strength.
+var DefaultSmallCraftThresholds = SmallCraftThresholds{
+	WindSpeed:     10.8,
+	WindGustSpeed: 13.9,
+}
+
+// SailingBrief summarizes the wind conditions of a period for sailors.
+type SailingBrief struct {
+	From              time.Time
+	To                time.Time
+	MinWindSpeed      float64
+	MaxWindSpeed      float64
+	MaxWindGustSpeed  float64
+	WindDirection     uint16
+	Beaufort          int
+	SmallCraftWarning bool
+	Description       map[string]string
+}
+
+// SailingBriefs summarizes the wind conditions of the time series in
+// periods of the given duration, a small craft warning is raised for the
+// periods that reach any of the given thresholds.
+func (pf *PointForecast) SailingBriefs(period time.Duration, th SmallCraftThresholds) []SailingBrief {
+	var ret []SailingBrief
+
+	for _, b := range bucket(pf.TimeSeries, period) {
+		var s SailingBrief
+		s.From = b[0].Timestamp.Truncate(period)
+		s.To = s.From.Add(period)
+
+		speeds := make([]float64, len(b))
+		gusts := make([]float64, len(b))
+		directions := make([]float64, len(b))
+		for i, f := range b {
+			speeds[i] = f.WindSpeed
+			gusts[i] = f.WindGustSpeed
+			directions[i] = float64(f.WindDirection)
+		}
+
+		s.MinWindSpeed = AggMin("ws", speeds)
+		s.MaxWindSpeed = AggMax("ws", speeds)
+		s.MaxWindGustSpeed = AggMax("gust", gusts)
+		s.WindDirection = uint16(AggDirection("wd", directions))
+		s.Beaufort = Beaufort(s.MaxWindSpeed)
+		s.SmallCraftWarning = (th.WindSpeed > 0 && s.MaxWindSpeed >= th.WindSpeed) ||
+			(th.WindGustSpeed > 0 && s.MaxWindGustSpeed >= th.WindGustSpeed)
+		s.Description = getSailingBriefDescription(&s)
+
+		ret = append(ret, s)
+	}
+
+	return ret
+}
+
+// getSailingBriefDescription returns a friendly description of the sailing
+// brief.
+func getSailingBriefDescription(s *SailingBrief) map[string]string {
+	ret := make(map[string]string)
+	dir := getCompassDirectionDescription(float64(s.WindDirection))
+	wind := getWindSpeedDescription(s.MaxWindSpeed)
+
+	ret["sv-SE"] = fmt.Sprintf("%s %s, %.0f-%.0f m/s, byar %.0f m/s.",
+		dir["sv-SE"], strings.ToLower(wind["sv-SE"]), s.MinWindSpeed, s.MaxWindSpeed, s.MaxWindGustSpeed)
+	ret["en-US"] = fmt.Sprintf("%s %s, %.0f-%.0f m/s, gusts %.0f m/s.",
+		dir["en-US"], strings.ToLower(wind["en-US"]), s.MinWindSpeed, s.MaxWindSpeed, s.MaxWindGustSpeed)
+
+	if s.SmallCraftWarning {
+		ret["sv-SE"] += " Varning för småbåtar."
+		ret["en-US"] += " Small craft warning."
+	}
+
+	return ret
+}
+
+// getCompassDirectionDescription returns a friendly name for the direction
+// the wind is blowing from.
+func getCompassDirectionDescription(degrees float64) map[string]string {
+	ret := make(map[string]string)
+
+	switch int((degrees+22.5)/45) % 8 {
+	case 0:
+		ret["sv-SE"] = "Nordlig"
+		ret["en-US"] = "Northerly"
+		break
+	case 1:
+		ret["sv-SE"] = "Nordostlig"
+		ret["en-US"] = "Northeasterly"
+		break
+	case 2:
+		ret["sv-SE"] = "Ostlig"
+		ret["en-US"] = "Easterly"
+		break
+	case 3:
+		ret["sv-SE"] = "Sydostlig"
+		ret["en-US"] = "Southeasterly"
+		break
+	case 4:
+		ret["sv-SE"] = "Sydlig"
+		ret["en-US"] = "Southerly"
+		break
+	case 5:
+		ret["sv-SE"] = "Sydvästlig"
+		ret["en-US"] = "Southwesterly"
+		break
+	case 6:
+		ret["sv-SE"] = "Västlig"
+		ret["en-US"] = "Westerly"
+		break
+	case 7:
+		ret["sv-SE"] = "Nordvästlig"
+		ret["en-US"] = "Northwesterly"
+		break
+	}
+
+	return ret
+}
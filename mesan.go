@@ -0,0 +1,170 @@
+package smhi
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	mesanURL = "https://opendata-download-metanalys.smhi.se/api/category/mesan2g/version/1/geotype/point/lon/%f/lat/%f/data.json"
+)
+
+// mesanParameterNames maps the names of the MESAN parameters that differ
+// from the point forecast to their point forecast names.
+var mesanParameterNames = map[string]string{
+	"tcc":    "tcc_mean",
+	"lcc":    "lcc_mean",
+	"mcc":    "mcc_mean",
+	"hcc":    "hcc_mean",
+	"prec1h": "pmean",
+}
+
+// getMesanAPI fetches the MESAN analysis for the given longitude and
+// latitude, the parameters are renamed to their point forecast names.
+func (c *Client) getMesanAPI(lon, lat float64) (*PointForecastAPI, error) {
+	var err error
+
+	var decodedData PointForecastAPI
+	if err = c.getJSON(fmt.Sprintf(mesanURL, lon, lat), &decodedData); err != nil {
+		return nil, err
+	}
+
+	for _, t := range decodedData.TimeSeries {
+		for i, p := range t.Parameters {
+			if name, ok := mesanParameterNames[p.Name]; ok {
+				t.Parameters[i].Name = name
+			}
+		}
+	}
+
+	return &decodedData, nil
+}
+
+// GetMesanAnalysis fetches the MESAN analyses of the latest hours for the
+// given longitude and latitude using the default client.
+func GetMesanAnalysis(lon, lat float64) (*PointForecast, error) {
+	return defaultClient.GetMesanAnalysis(lon, lat)
+}
+
+// GetMesanAnalysis fetches the MESAN analyses of the latest hours for the
+// given longitude and latitude. Parameters that are not analysed by MESAN
+// are left at their zero values.
+func (c *Client) GetMesanAnalysis(lon, lat float64) (*PointForecast, error) {
+	var err error
+
+	var d *PointForecastAPI
+	if d, err = c.getMesanAPI(lon, lat); err != nil {
+		return nil, err
+	}
+
+	return c.toPointForecast(d)
+}
+
+// GetCurrentConditions fetches the forecast for the given longitude and
+// latitude, where the first time step is the latest MESAN analysis using
+// the default client.
+func GetCurrentConditions(lon, lat float64) (*PointForecast, error) {
+	return defaultClient.GetCurrentConditions(lon, lat)
+}
+
+// GetCurrentConditions fetches the forecast for the given longitude and
+// latitude, where the first time step is the latest MESAN analysis. The
+// parameters that are not analysed by MESAN are taken from the forecast
+// time step nearest to the analysis, and the forecast time steps up to
+// the analysis are dropped.
+func (c *Client) GetCurrentConditions(lon, lat float64) (*PointForecast, error) {
+	var pf *PointForecast
+	var analysis *PointForecastAPI
+	var pfErr, analysisErr error
+
+	// Fetch the forecast and the analysis at the same time.
+	done := make(chan struct{})
+	go func() {
+		analysis, analysisErr = c.getMesanAPI(lon, lat)
+		close(done)
+	}()
+	pf, pfErr = c.GetPointForecast(lon, lat)
+	<-done
+
+	if pfErr != nil {
+		return nil, pfErr
+	}
+	if analysisErr != nil {
+		return nil, analysisErr
+	}
+
+	return c.blendAnalysis(pf, analysis)
+}
+
+// blendAnalysis returns a copy of the forecast that starts with the latest
+// time step of the analysis.
+func (c *Client) blendAnalysis(pf *PointForecast, analysis *PointForecastAPI) (*PointForecast, error) {
+	var err error
+
+	if len(analysis.TimeSeries) == 0 || len(pf.TimeSeries) == 0 {
+		return nil, errors.New("smhi: empty analysis or forecast")
+	}
+
+	// Find the latest analysis.
+	latest := 0
+	var now time.Time
+	for i, t := range analysis.TimeSeries {
+		var ts time.Time
+		if ts, err = time.Parse(time.RFC3339, t.ValidTime); err != nil {
+			return nil, err
+		}
+		if i == 0 || ts.After(now) {
+			latest, now = i, ts
+		}
+	}
+
+	// Start from the forecast time step that is nearest to the analysis
+	// and overwrite it with the analysed parameters.
+	nearest := 0
+	for i, f := range pf.TimeSeries {
+		if absDuration(f.Timestamp.Sub(now)) < absDuration(pf.TimeSeries[nearest].Timestamp.Sub(now)) {
+			nearest = i
+		}
+	}
+
+	// Copy the extra values so that the decoders don't change the
+	// forecast time step.
+	f := pf.TimeSeries[nearest]
+	f.Timestamp = now
+	if f.Extra != nil {
+		extra := make(map[string]float64, len(f.Extra))
+		for k, v := range f.Extra {
+			extra[k] = v
+		}
+		f.Extra = extra
+	}
+	for _, p := range analysis.TimeSeries[latest].Parameters {
+		f.decode(p.Name, p.Values)
+	}
+
+	lon, lat := pf.Geometry.point()
+	derive(&f, lon, lat)
+	if c.descriptions {
+		describe(&f)
+	}
+	f.Hash = getHash(&f)
+
+	ret := *pf
+	ret.TimeSeries = []Forecast{f}
+	for _, t := range pf.TimeSeries {
+		if t.Timestamp.After(now) {
+			ret.TimeSeries = append(ret.TimeSeries, t)
+		}
+	}
+
+	return &ret, nil
+}
+
+// absDuration returns the absolute value of the duration.
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
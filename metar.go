@@ -0,0 +1,150 @@
+package smhi
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// knotsPerMeterPerSecond converts wind speeds from m/s to knots.
+const knotsPerMeterPerSecond = 1.943844
+
+// METAR returns the time step in an abbreviated syntax that is modelled
+// after METAR, such as "ESGG 011200Z 24008KT 9999 -SHRA BKN/// 14/09 Q1013"
+// for a forecast or an analysis. The station is left out if it's empty. The
+// string isn't an observation and the cloud base is unknown, so it isn't a
+// valid METAR report.
+func (f *Forecast) METAR(station string) string {
+	var parts []string
+
+	if station != "" {
+		parts = append(parts, station)
+	}
+	parts = append(parts, f.Timestamp.UTC().Format("021504Z"))
+
+	// Wind, as the direction in tens of degrees and the speed in knots. The
+	// gusts are only given when they are at least 10 knots stronger.
+	speed := math.Round(f.WindSpeed * knotsPerMeterPerSecond)
+	gust := math.Round(f.WindGustSpeed * knotsPerMeterPerSecond)
+	if speed < 1 {
+		parts = append(parts, "00000KT")
+	} else {
+		dir := int(math.Round(float64(f.WindDirection)/10)) * 10
+		if dir == 0 {
+			dir = 360
+		}
+		wind := fmt.Sprintf("%03d%02.0f", dir, speed)
+		if gust-speed >= 10 {
+			wind += fmt.Sprintf("G%02.0f", gust)
+		}
+		parts = append(parts, wind+"KT")
+	}
+
+	// Visibility in meters, 9999 means 10 km or more.
+	if f.HorizontalVisibility >= 10 {
+		parts = append(parts, "9999")
+	} else {
+		parts = append(parts, fmt.Sprintf("%04d", int(f.HorizontalVisibility*10)*100))
+	}
+
+	if w := getMETARWeather(f.WeatherSymbol); w != "" {
+		parts = append(parts, w)
+	}
+	parts = append(parts, getMETARClouds(f.MeanValueOfTotalCloudCover))
+
+	// The dew point is unknown when the humidity is missing.
+	dp := "//"
+	if f.RelativeHumidity > 0 {
+		dp = metarTemperature(dewPoint(f.AirTemperature, float64(f.RelativeHumidity)))
+	}
+	parts = append(parts, metarTemperature(f.AirTemperature)+"/"+dp)
+
+	if f.AirPressure > 0 {
+		parts = append(parts, fmt.Sprintf("Q%04.0f", f.AirPressure))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// getMETARWeather returns the present weather code of a weather symbol.
+func getMETARWeather(ws WeatherSymbol) string {
+	switch ws {
+	case Fog:
+		return "FG"
+	case LightRainShowers:
+		return "-SHRA"
+	case ModerateRainShowers:
+		return "SHRA"
+	case HeavyRainShowers:
+		return "+SHRA"
+	case Thunderstorm:
+		return "TSRA"
+	case LightSleetShowers:
+		return "-SHRASN"
+	case ModerateSleetShowers:
+		return "SHRASN"
+	case HeavySleetShowers:
+		return "+SHRASN"
+	case LightSnowShowers:
+		return "-SHSN"
+	case ModerateSnowShowers:
+		return "SHSN"
+	case HeavySnowShowers:
+		return "+SHSN"
+	case LightRain:
+		return "-RA"
+	case ModerateRain:
+		return "RA"
+	case HeavyRain:
+		return "+RA"
+	case Thunder:
+		return "TS"
+	case LightSleet:
+		return "-RASN"
+	case ModerateSleet:
+		return "RASN"
+	case HeavySleet:
+		return "+RASN"
+	case LightSnowfall:
+		return "-SN"
+	case ModerateSnowfall:
+		return "SN"
+	case HeavySnowfall:
+		return "+SN"
+	}
+	return ""
+}
+
+// getMETARClouds returns the cloud amount of the total cloud cover in
+// octas, with an unknown cloud base.
+func getMETARClouds(octas uint8) string {
+	if octas == 0 {
+		return "NSC"
+	} else if octas <= 2 {
+		return "FEW///"
+	} else if octas <= 4 {
+		return "SCT///"
+	} else if octas <= 7 {
+		return "BKN///"
+	}
+	return "OVC///"
+}
+
+// metarTemperature formats a temperature in whole degrees, with an M for
+// the negative ones.
+func metarTemperature(t float64) string {
+	v := int(math.Round(t))
+	if v < 0 {
+		return fmt.Sprintf("M%02d", -v)
+	}
+	return fmt.Sprintf("%02d", v)
+}
+
+// dewPoint returns the dew point in C of the temperature in C and the
+// relative humidity in percent, using the Magnus formula.
+func dewPoint(t, rh float64) float64 {
+	const b, c = 17.62, 243.12
+
+	g := math.Log(rh/100) + b*t/(c+t)
+	return c * g / (b - g)
+}
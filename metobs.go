@@ -0,0 +1,445 @@
+package smhi
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	metobsURL = "https://opendata-download-metobs.smhi.se/api/version/1.0"
+
+	// The paths below are shared by all of the SMHI observation APIs.
+	observationsParametersPath = "%s.json"
+	observationsParameterPath  = "%s/parameter/%d.json"
+	observationsStationPath    = "%s/parameter/%d/station/%d.json"
+	observationsDataPath       = "%s/parameter/%d/station/%d/period/%s/data.json"
+)
+
+// ObservationPeriod is a period of the SMHI meteorological observations
+// API.
+type ObservationPeriod string
+
+// ObservationPeriod constants.
+const (
+	PeriodLatestHour       ObservationPeriod = "latest-hour"
+	PeriodLatestDay        ObservationPeriod = "latest-day"
+	PeriodLatestMonths     ObservationPeriod = "latest-months"
+	PeriodCorrectedArchive ObservationPeriod = "corrected-archive"
+)
+
+// ErrInvalidPeriod is returned when an unknown observation period is used.
+var ErrInvalidPeriod = errors.New("smhi: invalid observation period")
+
+// ErrUnsupportedPeriod is returned when the station doesn't provide the
+// observation period for the parameter.
+var ErrUnsupportedPeriod = errors.New("smhi: observation period is not supported by the station")
+
+// ErrCSVOnlyPeriod is returned when JSON data is requested for the
+// corrected archive, which is only available as CSV.
+var ErrCSVOnlyPeriod = errors.New("smhi: observation period is only available as CSV")
+
+// Valid returns true if the observation period is known.
+func (p ObservationPeriod) Valid() bool {
+	switch p {
+	case PeriodLatestHour, PeriodLatestDay, PeriodLatestMonths, PeriodCorrectedArchive:
+		return true
+	}
+	return false
+}
+
+// Station holds the metadata of an observation station.
+type Station struct {
+	ID                int
+	Name              string
+	Owner             string
+	OwnerCategory     string
+	MeasuringStations string
+	Height            float64
+	Latitude          float64
+	Longitude         float64
+	Active            bool
+	From              time.Time
+	To                time.Time
+	CatchmentName     string
+	CatchmentNumber   int
+	CatchmentSize     float64
+}
+
+// Observation holds an observed value.
+type Observation struct {
+	Timestamp time.Time
+	Value     float64
+	Quality   string
+}
+
+// Observations holds the observations of a parameter at a station for a
+// period.
+type Observations struct {
+	Parameter     int
+	ParameterName string
+	Unit          string
+	Station       int
+	StationName   string
+	Period        ObservationPeriod
+	Values        []Observation
+}
+
+// ParameterAPI defines the data structure that is returned by the SMHI
+// meteorological observations API for a parameter.
+type ParameterAPI struct {
+	Key     string
+	Title   string
+	Summary string
+	Station []struct {
+		ID                int
+		Key               string
+		Name              string
+		Owner             string
+		OwnerCategory     string
+		MeasuringStations string
+		Height            float64
+		Latitude          float64
+		Longitude         float64
+		Active            bool
+		From              int64
+		To                int64
+		CatchmentName     string
+		CatchmentNumber   int
+		CatchmentSize     float64
+	}
+}
+
+// StationAPI defines the data structure that is returned by the SMHI
+// meteorological observations API for a station.
+type StationAPI struct {
+	Key    string
+	Title  string
+	Active bool
+	Period []struct {
+		Key string
+	}
+}
+
+// ObservationsAPI defines the data structure that is returned by the SMHI
+// meteorological observations API for a period.
+type ObservationsAPI struct {
+	Value []struct {
+		Date    int64
+		Value   string
+		Quality string
+	}
+	Parameter struct {
+		Key  string
+		Name string
+		Unit string
+	}
+	Station struct {
+		Key  string
+		Name string
+	}
+	Period struct {
+		Key string
+	}
+}
+
+// fromMillis converts a timestamp in milliseconds since the epoch to a
+// time.
+func fromMillis(ms int64) time.Time {
+	return time.Unix(0, ms*int64(time.Millisecond)).UTC()
+}
+
+// ObservationParameter describes a parameter of an observation API.
+type ObservationParameter struct {
+	Key     string
+	Title   string
+	Summary string
+	Unit    string
+}
+
+// ParametersAPI defines the data structure that is returned by the SMHI
+// observation APIs for the list of parameters.
+type ParametersAPI struct {
+	Resource []ObservationParameter
+}
+
+// getParameters fetches the parameters of the observation API at the given
+// base URL.
+func (c *Client) getParameters(base string) ([]ObservationParameter, error) {
+	var err error
+
+	var decodedData ParametersAPI
+	if err = c.getJSON(fmt.Sprintf(observationsParametersPath, base), &decodedData); err != nil {
+		return nil, err
+	}
+
+	return decodedData.Resource, nil
+}
+
+// getParameter fetches the parameter of the observation API at the given
+// base URL, which includes its stations.
+func (c *Client) getParameter(ctx context.Context, base string, parameter int) (*ParameterAPI, error) {
+	var err error
+
+	var decodedData ParameterAPI
+	if err = c.getJSONContext(ctx, fmt.Sprintf(observationsParameterPath, base, parameter), &decodedData); err != nil {
+		return nil, err
+	}
+
+	return &decodedData, nil
+}
+
+// GetParameters fetches the parameters of the meteorological observations
+// API using the default client.
+func GetParameters() ([]ObservationParameter, error) {
+	return defaultClient.GetParameters()
+}
+
+// GetParameters fetches the parameters of the meteorological observations
+// API.
+func (c *Client) GetParameters() ([]ObservationParameter, error) {
+	return c.getParameters(metobsURL)
+}
+
+// GetStations fetches all stations that observe the given parameter
+// using the default client.
+func GetStations(parameter int) ([]Station, error) {
+	return defaultClient.GetStations(parameter)
+}
+
+// GetStations fetches all stations that observe the given parameter.
+func (c *Client) GetStations(parameter int) ([]Station, error) {
+	return c.GetStationsContext(context.Background(), parameter)
+}
+
+// GetStationsContext fetches all stations that observe the given parameter
+// using the default client, the request is canceled when the context is
+// done.
+func GetStationsContext(ctx context.Context, parameter int) ([]Station, error) {
+	return defaultClient.GetStationsContext(ctx, parameter)
+}
+
+// GetStationsContext fetches all stations that observe the given
+// parameter, the request is canceled when the context is done.
+func (c *Client) GetStationsContext(ctx context.Context, parameter int) ([]Station, error) {
+	var err error
+
+	var decodedData *ParameterAPI
+	if decodedData, err = c.getParameter(ctx, metobsURL, parameter); err != nil {
+		return nil, err
+	}
+
+	return toStations(decodedData), nil
+}
+
+// toStations converts the stations of the ParameterAPI object to Station
+// objects.
+func toStations(d *ParameterAPI) []Station {
+	ret := make([]Station, 0, len(d.Station))
+	for _, s := range d.Station {
+		ret = append(ret, Station{
+			ID:                s.ID,
+			Name:              s.Name,
+			Owner:             s.Owner,
+			OwnerCategory:     s.OwnerCategory,
+			MeasuringStations: s.MeasuringStations,
+			Height:            s.Height,
+			Latitude:          s.Latitude,
+			Longitude:         s.Longitude,
+			Active:            s.Active,
+			From:              fromMillis(s.From),
+			To:                fromMillis(s.To),
+			CatchmentName:     s.CatchmentName,
+			CatchmentNumber:   s.CatchmentNumber,
+			CatchmentSize:     s.CatchmentSize,
+		})
+	}
+
+	return ret
+}
+
+// GetStationPeriods fetches the observation periods that the station
+// provides for the given parameter using the default client.
+func GetStationPeriods(parameter, station int) ([]ObservationPeriod, error) {
+	return defaultClient.GetStationPeriods(parameter, station)
+}
+
+// GetStationPeriods fetches the observation periods that the station
+// provides for the given parameter.
+func (c *Client) GetStationPeriods(parameter, station int) ([]ObservationPeriod, error) {
+	return c.getStationPeriods(context.Background(), metobsURL, parameter, station)
+}
+
+// getStationPeriods fetches the observation periods that the station of
+// the observation API at the given base URL provides for the parameter.
+func (c *Client) getStationPeriods(ctx context.Context, base string, parameter, station int) ([]ObservationPeriod, error) {
+	var err error
+
+	var decodedData StationAPI
+	if err = c.getJSONContext(ctx, fmt.Sprintf(observationsStationPath, base, parameter, station), &decodedData); err != nil {
+		return nil, err
+	}
+
+	var ret []ObservationPeriod
+	for _, p := range decodedData.Period {
+		if period := ObservationPeriod(p.Key); period.Valid() {
+			ret = append(ret, period)
+		}
+	}
+
+	return ret, nil
+}
+
+// SupportsPeriod returns true if the station provides the observation
+// period for the given parameter.
+func (c *Client) SupportsPeriod(parameter, station int, period ObservationPeriod) (bool, error) {
+	return c.supportsPeriod(context.Background(), metobsURL, parameter, station, period)
+}
+
+// supportsPeriod returns true if the station of the observation API at
+// the given base URL provides the observation period for the parameter.
+func (c *Client) supportsPeriod(ctx context.Context, base string, parameter, station int, period ObservationPeriod) (bool, error) {
+	if !period.Valid() {
+		return false, ErrInvalidPeriod
+	}
+
+	periods, err := c.getStationPeriods(ctx, base, parameter, station)
+	if err != nil {
+		return false, err
+	}
+
+	for _, p := range periods {
+		if p == period {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// GetObservations fetches the observations of the parameter at the station
+// for the given period using the default client.
+func GetObservations(parameter, station int, period ObservationPeriod) (*Observations, error) {
+	return defaultClient.GetObservations(parameter, station, period)
+}
+
+// GetObservations fetches the observations of the parameter at the station
+// for the given period. The corrected archive is only available as CSV
+// and ErrCSVOnlyPeriod is returned for it.
+func (c *Client) GetObservations(parameter, station int, period ObservationPeriod) (*Observations, error) {
+	return c.GetObservationsContext(context.Background(), parameter, station, period)
+}
+
+// GetObservationsContext fetches the observations of the parameter at the
+// station for the given period using the default client, the requests are
+// canceled when the context is done.
+func GetObservationsContext(ctx context.Context, parameter, station int, period ObservationPeriod) (*Observations, error) {
+	return defaultClient.GetObservationsContext(ctx, parameter, station, period)
+}
+
+// GetObservationsContext fetches the observations of the parameter at the
+// station for the given period like GetObservations, the requests are
+// canceled when the context is done.
+func (c *Client) GetObservationsContext(ctx context.Context, parameter, station int, period ObservationPeriod) (*Observations, error) {
+	return c.getObservations(ctx, metobsURL, parameter, station, period)
+}
+
+// getObservations fetches the observations of the parameter at the station
+// for the given period from the observation API at the given base URL.
+func (c *Client) getObservations(ctx context.Context, base string, parameter, station int, period ObservationPeriod) (*Observations, error) {
+	var err error
+
+	if !period.Valid() {
+		return nil, ErrInvalidPeriod
+	}
+	if period == PeriodCorrectedArchive {
+		return nil, ErrCSVOnlyPeriod
+	}
+
+	// Make sure that the station provides the period, since the API
+	// doesn't give a useful error otherwise.
+	var ok bool
+	if ok, err = c.supportsPeriod(ctx, base, parameter, station, period); err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrUnsupportedPeriod
+	}
+
+	var decodedData ObservationsAPI
+	if err = c.getJSONContext(ctx, fmt.Sprintf(observationsDataPath, base, parameter, station, period), &decodedData); err != nil {
+		return nil, err
+	}
+
+	ret := &Observations{
+		Parameter:     parameter,
+		ParameterName: decodedData.Parameter.Name,
+		Unit:          decodedData.Parameter.Unit,
+		Station:       station,
+		StationName:   decodedData.Station.Name,
+		Period:        period,
+	}
+	for _, v := range decodedData.Value {
+		var o Observation
+		o.Timestamp = fromMillis(v.Date)
+		o.Quality = v.Quality
+		if o.Value, err = strconv.ParseFloat(v.Value, 64); err != nil {
+			return nil, err
+		}
+
+		ret.Values = append(ret.Values, o)
+	}
+
+	return ret, nil
+}
+
+// ParseCorrectedArchive parses the observations of a corrected archive CSV
+// file. Instantaneous values are timestamped with their observation time,
+// while values for an interval, such as daily precipitation, are
+// timestamped with their representative day or month.
+func ParseCorrectedArchive(r io.Reader) ([]Observation, error) {
+	var ret []Observation
+	var err error
+
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		fields := strings.Split(s.Text(), ";")
+		if len(fields) < 4 {
+			continue
+		}
+
+		// The metadata in the beginning of the file is skipped by only
+		// considering rows that start with a timestamp.
+		var o Observation
+		var value, quality string
+		if t, err := time.Parse("2006-01-02 15:04:05", fields[0]+" "+fields[1]); err == nil {
+			o.Timestamp = t
+			value, quality = fields[2], fields[3]
+		} else if _, err := time.Parse("2006-01-02 15:04:05", fields[0]); err == nil && len(fields) >= 5 {
+			if o.Timestamp, err = time.Parse("2006-01-02", fields[2]); err != nil {
+				if o.Timestamp, err = time.Parse("2006-01", fields[2]); err != nil {
+					continue
+				}
+			}
+			value, quality = fields[3], fields[4]
+		} else {
+			continue
+		}
+
+		if o.Value, err = strconv.ParseFloat(value, 64); err != nil {
+			continue
+		}
+		o.Quality = quality
+
+		ret = append(ret, o)
+	}
+	if err = s.Err(); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,99 @@
+package smhi
+
+import (
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Metrics is notified of the requests of a client, such as to count them
+// and their durations with Prometheus or statsd. The methods are called
+// concurrently by concurrent requests, and for each attempt of a retried
+// request.
+type Metrics interface {
+	// OnRequestStart is called before the request is sent.
+	OnRequestStart(method, url string)
+
+	// OnRequestDone is called when the body of the response is closed,
+	// or when the request fails.
+	OnRequestDone(r RequestMetrics)
+}
+
+// RequestMetrics describes a finished request. Duration is the time from
+// the start of the request until its body was closed, and Bytes is the
+// number of bytes that were read from the body, which are the compressed
+// bytes of compressed responses. Status is zero and Err is set when the
+// request failed.
+type RequestMetrics struct {
+	Method   string
+	URL      string
+	Status   int
+	Bytes    int64
+	Duration time.Duration
+	Err      error
+}
+
+// WithMetrics makes the client notify m of its requests.
+func WithMetrics(m Metrics) Option {
+	return func(c *Client) {
+		c.metrics = m
+	}
+}
+
+// metricsBody counts the bytes that are read from a response body, and
+// notifies the metrics when the body is closed.
+type metricsBody struct {
+	io.ReadCloser
+	m     Metrics
+	r     RequestMetrics
+	start time.Time
+	once  sync.Once
+}
+
+// Read reads from the body and counts the bytes.
+func (b *metricsBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.r.Bytes += int64(n)
+	return n, err
+}
+
+// Close closes the body and notifies the metrics.
+func (b *metricsBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(func() {
+		b.r.Duration = time.Since(b.start)
+		b.m.OnRequestDone(b.r)
+	})
+	return err
+}
+
+// startMetrics notifies the metrics of the client, if it has any, that the
+// request is started.
+func (c *Client) startMetrics(req *http.Request) {
+	if c.metrics == nil {
+		return
+	}
+
+	c.metrics.OnRequestStart(req.Method, req.URL.String())
+}
+
+// doneMetrics notifies the metrics of the client, if it has any, that the
+// request failed, or wraps the response body so that they are notified
+// when it's closed.
+func (c *Client) doneMetrics(req *http.Request, res *http.Response, err error, start time.Time) {
+	if c.metrics == nil {
+		return
+	}
+
+	r := RequestMetrics{Method: req.Method, URL: req.URL.String()}
+	if err != nil {
+		r.Err = err
+		r.Duration = time.Since(start)
+		c.metrics.OnRequestDone(r)
+		return
+	}
+
+	r.Status = res.StatusCode
+	res.Body = &metricsBody{ReadCloser: res.Body, m: c.metrics, r: r, start: start}
+}
@@ -0,0 +1,66 @@
+package smhi
+
+import (
+	"sort"
+	"time"
+)
+
+// Gap is a step between two consecutive time steps that is longer than
+// expected, such as a missing hour.
+type Gap struct {
+	From time.Time
+	To   time.Time
+}
+
+// NormalizeReport tells what Normalize found in the time series.
+type NormalizeReport struct {
+	// Sorted is true if the time steps weren't in order.
+	Sorted bool
+
+	// Duplicates holds the timestamps of the removed duplicates.
+	Duplicates []time.Time
+
+	// Gaps holds the steps that are longer than expected.
+	Gaps []Gap
+
+	// Irregular holds the time steps that aren't on a whole hour.
+	Irregular []time.Time
+}
+
+// Normalize sorts the time series by timestamp and removes the time steps
+// with duplicate timestamps, keeping the first one. The forecasts get
+// coarser with the lead time, from hourly steps to steps of several hours,
+// so a step that is longer than the step after it is reported as a gap, as
+// is any step that is longer than maxStep if it isn't zero.
+func (pf *PointForecast) Normalize(maxStep time.Duration) NormalizeReport {
+	var r NormalizeReport
+
+	ts := pf.TimeSeries
+	if !sort.SliceIsSorted(ts, func(i, j int) bool { return ts[i].Timestamp.Before(ts[j].Timestamp) }) {
+		sort.SliceStable(ts, func(i, j int) bool { return ts[i].Timestamp.Before(ts[j].Timestamp) })
+		r.Sorted = true
+	}
+
+	var ret []Forecast
+	for _, f := range ts {
+		if len(ret) > 0 && ret[len(ret)-1].Timestamp.Equal(f.Timestamp) {
+			r.Duplicates = append(r.Duplicates, f.Timestamp)
+			continue
+		}
+		if !f.Timestamp.Truncate(time.Hour).Equal(f.Timestamp) {
+			r.Irregular = append(r.Irregular, f.Timestamp)
+		}
+		ret = append(ret, f)
+	}
+	pf.TimeSeries = ret
+
+	for i := 1; i < len(ret); i++ {
+		step := ret[i].Timestamp.Sub(ret[i-1].Timestamp)
+		coarser := i+1 < len(ret) && step > ret[i+1].Timestamp.Sub(ret[i].Timestamp)
+		if coarser || (maxStep > 0 && step > maxStep) {
+			r.Gaps = append(r.Gaps, Gap{ret[i-1].Timestamp, ret[i].Timestamp})
+		}
+	}
+
+	return r
+}
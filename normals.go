@@ -0,0 +1,221 @@
+package smhi
+
+import (
+	"context"
+	"math"
+	"os"
+	"time"
+)
+
+// Metobs parameters that are used to compute the normals.
+const (
+	normalsTemperatureParameter   = 2
+	normalsPrecipitationParameter = 5
+)
+
+// MonthlyNormal holds the normal daily mean temperature in °C and the
+// normal daily precipitation in mm of a month, along with their standard
+// deviations.
+type MonthlyNormal struct {
+	Temperature         float64
+	TemperatureStdDev   float64
+	Precipitation       float64
+	PrecipitationStdDev float64
+}
+
+// Normals holds the climatological normals of a location for each month,
+// January is the first month.
+type Normals struct {
+	FromYear int
+	ToYear   int
+	Months   [12]MonthlyNormal
+}
+
+// Month returns the normal of the given month.
+func (n *Normals) Month(m time.Month) MonthlyNormal {
+	return n.Months[m-1]
+}
+
+// meanStdDev returns the mean and the standard deviation of the values.
+func meanStdDev(values []float64) (float64, float64) {
+	if len(values) == 0 {
+		return 0, 0
+	}
+
+	var sum, sq float64
+	for _, v := range values {
+		sum += v
+	}
+	mean := sum / float64(len(values))
+	for _, v := range values {
+		sq += (v - mean) * (v - mean)
+	}
+
+	return mean, math.Sqrt(sq / float64(len(values)))
+}
+
+// NormalsFromObservations computes the normals for the years fromYear to
+// toYear, both inclusive, from observations of the daily mean temperature
+// and the daily precipitation.
+func NormalsFromObservations(temperature, precipitation []Observation, fromYear, toYear int) *Normals {
+	ret := &Normals{FromYear: fromYear, ToYear: toYear}
+
+	var temps, precs [12][]float64
+	for _, o := range temperature {
+		if y := o.Timestamp.Year(); y >= fromYear && y <= toYear {
+			temps[o.Timestamp.Month()-1] = append(temps[o.Timestamp.Month()-1], o.Value)
+		}
+	}
+	for _, o := range precipitation {
+		if y := o.Timestamp.Year(); y >= fromYear && y <= toYear {
+			precs[o.Timestamp.Month()-1] = append(precs[o.Timestamp.Month()-1], o.Value)
+		}
+	}
+
+	for m := range ret.Months {
+		n := &ret.Months[m]
+		n.Temperature, n.TemperatureStdDev = meanStdDev(temps[m])
+		n.Precipitation, n.PrecipitationStdDev = meanStdDev(precs[m])
+	}
+
+	return ret
+}
+
+// GetNormals computes the 1991-2020 normals for the given longitude and
+// latitude from the corrected archives of the nearest active stations that
+// cover the whole period. The archives are downloaded into the archive
+// unless they are already there.
+func (c *Client) GetNormals(ctx context.Context, a *Archive, dir *StationDirectory, lon, lat float64) (*Normals, error) {
+	var err error
+
+	covers := func(s *Station) bool {
+		return !s.From.After(time.Date(1991, 1, 1, 0, 0, 0, 0, time.UTC)) &&
+			!s.To.Before(time.Date(2020, 12, 31, 0, 0, 0, 0, time.UTC))
+	}
+
+	var observations [2][]Observation
+	for i, p := range []int{normalsTemperatureParameter, normalsPrecipitationParameter} {
+		var s *Station
+		if s, _, err = dir.NearestStation(p, lon, lat, ActiveStations(), covers); err != nil {
+			return nil, err
+		}
+
+		d := Downloader{Client: c, Archive: a}
+		if err = d.Download(ctx, []ArchiveDownload{{Parameter: p, Station: s.ID}}); err != nil {
+			return nil, err
+		}
+
+		var f *os.File
+		if f, err = os.Open(a.ObservationsPath(p, s.ID)); err != nil {
+			return nil, err
+		}
+		observations[i], err = ParseCorrectedArchive(f)
+		f.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return NormalsFromObservations(observations[0], observations[1], 1991, 2020), nil
+}
+
+// NormalComparison tells how a value compares to its normal.
+type NormalComparison int
+
+// NormalComparison constants.
+const (
+	BelowNormal NormalComparison = iota - 1
+	Normal
+	AboveNormal
+)
+
+// compareToNormal compares the value to the normal, values within half a
+// standard deviation of the normal are considered normal.
+func compareToNormal(v, normal, stddev float64) NormalComparison {
+	if v > normal+stddev/2 {
+		return AboveNormal
+	} else if v < normal-stddev/2 {
+		return BelowNormal
+	}
+	return Normal
+}
+
+// DayComparison compares the summary of a day to the normals.
+type DayComparison struct {
+	Summary                  Summary
+	Temperature              NormalComparison
+	TemperatureAnomaly       float64
+	Precipitation            NormalComparison
+	PrecipitationAnomaly     float64
+	TemperatureDescription   map[string]string
+	PrecipitationDescription map[string]string
+}
+
+// CompareToNormals compares the mean temperature and the precipitation of
+// each day of the forecast in the given location to the normals.
+func (pf *PointForecast) CompareToNormals(n *Normals, loc *time.Location) []DayComparison {
+	var ret []DayComparison
+
+	for _, s := range pf.Days(loc) {
+		m := n.Month(s.From.Month())
+		dc := DayComparison{
+			Summary:              s,
+			Temperature:          compareToNormal(s.MeanAirTemperature, m.Temperature, m.TemperatureStdDev),
+			TemperatureAnomaly:   s.MeanAirTemperature - m.Temperature,
+			Precipitation:        compareToNormal(s.Precipitation, m.Precipitation, m.PrecipitationStdDev),
+			PrecipitationAnomaly: s.Precipitation - m.Precipitation,
+		}
+		dc.TemperatureDescription = getTemperatureComparisonDescription(dc.Temperature)
+		dc.PrecipitationDescription = getPrecipitationComparisonDescription(dc.Precipitation)
+
+		ret = append(ret, dc)
+	}
+
+	return ret
+}
+
+// getTemperatureComparisonDescription returns a friendly description of
+// the temperature compared to the normal.
+func getTemperatureComparisonDescription(c NormalComparison) map[string]string {
+	ret := make(map[string]string)
+
+	switch c {
+	case BelowNormal:
+		ret["sv-SE"] = "Kallare än normalt"
+		ret["en-US"] = "Colder than normal"
+		break
+	case Normal:
+		ret["sv-SE"] = "Normal temperatur"
+		ret["en-US"] = "Normal temperature"
+		break
+	case AboveNormal:
+		ret["sv-SE"] = "Varmare än normalt"
+		ret["en-US"] = "Warmer than normal"
+		break
+	}
+
+	return ret
+}
+
+// getPrecipitationComparisonDescription returns a friendly description of
+// the precipitation compared to the normal.
+func getPrecipitationComparisonDescription(c NormalComparison) map[string]string {
+	ret := make(map[string]string)
+
+	switch c {
+	case BelowNormal:
+		ret["sv-SE"] = "Torrare än normalt"
+		ret["en-US"] = "Drier than normal"
+		break
+	case Normal:
+		ret["sv-SE"] = "Normal nederbörd"
+		ret["en-US"] = "Normal precipitation"
+		break
+	case AboveNormal:
+		ret["sv-SE"] = "Blötare än normalt"
+		ret["en-US"] = "Wetter than normal"
+		break
+	}
+
+	return ret
+}
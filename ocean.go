@@ -0,0 +1,134 @@
+package smhi
+
+import (
+	"strconv"
+	"sync"
+	"time"
+)
+
+// OceanStation is an oceanographic station along with the parameters that
+// it measures.
+type OceanStation struct {
+	Station
+	Parameters []int
+}
+
+// OceanStationDirectory caches the oceanographic stations and the
+// parameters they measure, in the same way as the StationDirectory does for
+// the meteorological stations.
+type OceanStationDirectory struct {
+	stations *StationDirectory
+	mu       sync.Mutex
+	params   []int
+	fetched  time.Time
+}
+
+// NewOceanStationDirectory returns an oceanographic station directory that
+// fetches the stations with the given client and caches them for ttl. The
+// station lists are also cached in dir if it isn't empty.
+func NewOceanStationDirectory(c *Client, ttl time.Duration, dir string) *OceanStationDirectory {
+	return &OceanStationDirectory{
+		stations: newStationDirectory(c, ocobsURL, "ocean-stations", ttl, dir),
+	}
+}
+
+// parameters returns the cached list of oceanographic parameters.
+func (d *OceanStationDirectory) parameters() ([]int, error) {
+	var err error
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.params != nil && time.Since(d.fetched) < d.stations.ttl {
+		return d.params, nil
+	}
+
+	var params []ObservationParameter
+	if params, err = d.stations.client.getParameters(ocobsURL); err != nil {
+		return nil, err
+	}
+
+	ret := make([]int, 0, len(params))
+	for _, p := range params {
+		var key int
+		if key, err = strconv.Atoi(p.Key); err != nil {
+			continue
+		}
+		ret = append(ret, key)
+	}
+	d.params, d.fetched = ret, time.Now()
+
+	return ret, nil
+}
+
+// Stations returns the oceanographic stations that match all of the
+// filters, with the parameters that each of them measures.
+func (d *OceanStationDirectory) Stations(filters ...StationFilter) ([]OceanStation, error) {
+	var err error
+
+	var params []int
+	if params, err = d.parameters(); err != nil {
+		return nil, err
+	}
+
+	// Merge the station lists of the parameters by station id.
+	var ret []OceanStation
+	index := make(map[int]int)
+	for _, p := range params {
+		var stations []Station
+		if stations, err = d.stations.Stations(p, filters...); err != nil {
+			return nil, err
+		}
+
+		for _, s := range stations {
+			i, ok := index[s.ID]
+			if !ok {
+				i = len(ret)
+				index[s.ID] = i
+				ret = append(ret, OceanStation{Station: s})
+			}
+			ret[i].Parameters = append(ret[i].Parameters, p)
+		}
+	}
+
+	return ret, nil
+}
+
+// NearestStation returns the oceanographic station that measures the
+// parameter, matches all of the filters and is nearest to the given
+// longitude and latitude, along with its distance in km.
+func (d *OceanStationDirectory) NearestStation(parameter int, lon, lat float64, filters ...StationFilter) (*OceanStation, float64, error) {
+	var err error
+
+	var stations []OceanStation
+	if stations, err = d.Stations(filters...); err != nil {
+		return nil, 0, err
+	}
+
+	var ret *OceanStation
+	var min float64
+	for _, s := range stations {
+		if !s.Measures(parameter) {
+			continue
+		}
+		if dist := distance(lon, lat, s.Longitude, s.Latitude); ret == nil || dist < min {
+			s := s
+			ret, min = &s, dist
+		}
+	}
+	if ret == nil {
+		return nil, 0, ErrNoStation
+	}
+
+	return ret, min, nil
+}
+
+// Measures returns true if the station measures the parameter.
+func (s *OceanStation) Measures(parameter int) bool {
+	for _, p := range s.Parameters {
+		if p == parameter {
+			return true
+		}
+	}
+	return false
+}
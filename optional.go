@@ -0,0 +1,68 @@
+package smhi
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Optional is a value that may legitimately be absent, such as the wave
+// parameters of points over land. It's marshaled to JSON as null when it's
+// absent.
+type Optional[T any] struct {
+	value T
+	set   bool
+}
+
+// Some returns an Optional that holds the value.
+func Some[T any](v T) Optional[T] {
+	return Optional[T]{value: v, set: true}
+}
+
+// IsSet returns true if the Optional holds a value.
+func (o Optional[T]) IsSet() bool {
+	return o.set
+}
+
+// Get returns the value, the second return value is false if it's absent.
+func (o Optional[T]) Get() (T, bool) {
+	return o.value, o.set
+}
+
+// Or returns the value, or def if it's absent.
+func (o Optional[T]) Or(def T) T {
+	if !o.set {
+		return def
+	}
+	return o.value
+}
+
+// String returns the value, or an empty string if it's absent.
+func (o Optional[T]) String() string {
+	if !o.set {
+		return ""
+	}
+	return fmt.Sprint(o.value)
+}
+
+// MarshalJSON marshals the value, or null if it's absent.
+func (o Optional[T]) MarshalJSON() ([]byte, error) {
+	if !o.set {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.value)
+}
+
+// UnmarshalJSON unmarshals the value, null makes it absent.
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*o = Optional[T]{}
+		return nil
+	}
+
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*o = Some(v)
+	return nil
+}
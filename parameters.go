@@ -0,0 +1,240 @@
+package smhi
+
+// Names of the SMHI parameters that are known by the Forecast structure,
+// for use with Value, ParameterAccessor and RegisterParameter.
+const (
+	ParamAirPressure                   = "msl"
+	ParamAirTemperature                = "t"
+	ParamHorizontalVisibility          = "vis"
+	ParamWindDirection                 = "wd"
+	ParamWindSpeed                     = "ws"
+	ParamRelativeHumidity              = "r"
+	ParamThunderProbability            = "tstm"
+	ParamTotalCloudCover               = "tcc_mean"
+	ParamLowLevelCloudCover            = "lcc_mean"
+	ParamMediumLevelCloudCover         = "mcc_mean"
+	ParamHighLevelCloudCover           = "hcc_mean"
+	ParamWindGustSpeed                 = "gust"
+	ParamMinimumPrecipitationIntensity = "pmin"
+	ParamMaximumPrecipitationIntensity = "pmax"
+	ParamFrozenPrecipitation           = "spp"
+	ParamPrecipitationCategory         = "pcat"
+	ParamMeanPrecipitationIntensity    = "pmean"
+	ParamMedianPrecipitationIntensity  = "pmedian"
+	ParamWeatherSymbol                 = "Wsymb2"
+	ParamSignificantWaveHeight         = "sea_surface_wave_significant_height"
+	ParamWaveDirection                 = "sea_surface_wave_from_direction"
+	ParamWavePeriod                    = "sea_surface_wave_mean_period"
+)
+
+// ParameterInfo describes an SMHI parameter, the unit is empty for the
+// categorical parameters.
+type ParameterInfo struct {
+	Name        string
+	Unit        Unit
+	Description map[string]string
+}
+
+// parameterInfo holds the descriptions of the known SMHI parameters.
+var parameterInfo = map[string]ParameterInfo{
+	ParamAirPressure:                   {ParamAirPressure, Hectopascal, map[string]string{"sv-SE": "Lufttryck vid havsytans nivå", "en-US": "Air pressure at mean sea level"}},
+	ParamAirTemperature:                {ParamAirTemperature, Celsius, map[string]string{"sv-SE": "Lufttemperatur", "en-US": "Air temperature"}},
+	ParamHorizontalVisibility:          {ParamHorizontalVisibility, Kilometers, map[string]string{"sv-SE": "Horisontell sikt", "en-US": "Horizontal visibility"}},
+	ParamWindDirection:                 {ParamWindDirection, Degrees, map[string]string{"sv-SE": "Vindriktning", "en-US": "Wind direction"}},
+	ParamWindSpeed:                     {ParamWindSpeed, MetersPerSecond, map[string]string{"sv-SE": "Vindhastighet", "en-US": "Wind speed"}},
+	ParamRelativeHumidity:              {ParamRelativeHumidity, Percent, map[string]string{"sv-SE": "Relativ luftfuktighet", "en-US": "Relative humidity"}},
+	ParamThunderProbability:            {ParamThunderProbability, Percent, map[string]string{"sv-SE": "Sannolikhet för åska", "en-US": "Thunder probability"}},
+	ParamTotalCloudCover:               {ParamTotalCloudCover, Octas, map[string]string{"sv-SE": "Total molnmängd", "en-US": "Total cloud cover"}},
+	ParamLowLevelCloudCover:            {ParamLowLevelCloudCover, Octas, map[string]string{"sv-SE": "Molnmängd låga moln", "en-US": "Low level cloud cover"}},
+	ParamMediumLevelCloudCover:         {ParamMediumLevelCloudCover, Octas, map[string]string{"sv-SE": "Molnmängd medelhöga moln", "en-US": "Medium level cloud cover"}},
+	ParamHighLevelCloudCover:           {ParamHighLevelCloudCover, Octas, map[string]string{"sv-SE": "Molnmängd höga moln", "en-US": "High level cloud cover"}},
+	ParamWindGustSpeed:                 {ParamWindGustSpeed, MetersPerSecond, map[string]string{"sv-SE": "Byvind", "en-US": "Wind gust speed"}},
+	ParamMinimumPrecipitationIntensity: {ParamMinimumPrecipitationIntensity, MillimetersPerHour, map[string]string{"sv-SE": "Minsta nederbördsintensitet", "en-US": "Minimum precipitation intensity"}},
+	ParamMaximumPrecipitationIntensity: {ParamMaximumPrecipitationIntensity, MillimetersPerHour, map[string]string{"sv-SE": "Största nederbördsintensitet", "en-US": "Maximum precipitation intensity"}},
+	ParamFrozenPrecipitation:           {ParamFrozenPrecipitation, Percent, map[string]string{"sv-SE": "Andel nederbörd i fast form", "en-US": "Percent of precipitation in frozen form"}},
+	ParamPrecipitationCategory:         {ParamPrecipitationCategory, "", map[string]string{"sv-SE": "Nederbördsform", "en-US": "Precipitation category"}},
+	ParamMeanPrecipitationIntensity:    {ParamMeanPrecipitationIntensity, MillimetersPerHour, map[string]string{"sv-SE": "Medelintensitet för nederbörd", "en-US": "Mean precipitation intensity"}},
+	ParamMedianPrecipitationIntensity:  {ParamMedianPrecipitationIntensity, MillimetersPerHour, map[string]string{"sv-SE": "Medianintensitet för nederbörd", "en-US": "Median precipitation intensity"}},
+	ParamWeatherSymbol:                 {ParamWeatherSymbol, "", map[string]string{"sv-SE": "Vädersymbol", "en-US": "Weather symbol"}},
+	ParamSignificantWaveHeight:         {ParamSignificantWaveHeight, Meters, map[string]string{"sv-SE": "Signifikant våghöjd", "en-US": "Significant wave height"}},
+	ParamWaveDirection:                 {ParamWaveDirection, Degrees, map[string]string{"sv-SE": "Vågriktning", "en-US": "Wave direction"}},
+	ParamWavePeriod:                    {ParamWavePeriod, Seconds, map[string]string{"sv-SE": "Vågperiod", "en-US": "Wave mean period"}},
+}
+
+// GetParameterInfo returns the description of the SMHI parameter with the
+// given name, the second return value is false if it's unknown.
+func GetParameterInfo(name string) (ParameterInfo, bool) {
+	info, ok := parameterInfo[name]
+	return info, ok
+}
+
+// parameterNames holds the names of all SMHI parameters that are known by
+// the Forecast structure.
+var parameterNames = []string{
+	ParamAirPressure,
+	ParamAirTemperature,
+	ParamHorizontalVisibility,
+	ParamWindDirection,
+	ParamWindSpeed,
+	ParamRelativeHumidity,
+	ParamThunderProbability,
+	ParamTotalCloudCover,
+	ParamLowLevelCloudCover,
+	ParamMediumLevelCloudCover,
+	ParamHighLevelCloudCover,
+	ParamWindGustSpeed,
+	ParamMinimumPrecipitationIntensity,
+	ParamMaximumPrecipitationIntensity,
+	ParamFrozenPrecipitation,
+	ParamPrecipitationCategory,
+	ParamMeanPrecipitationIntensity,
+	ParamMedianPrecipitationIntensity,
+	ParamWeatherSymbol,
+	ParamSignificantWaveHeight,
+	ParamWaveDirection,
+	ParamWavePeriod,
+}
+
+// ParameterNames returns the names of the SMHI parameters that are known
+// by the Forecast structure.
+func ParameterNames() []string {
+	return append([]string(nil), parameterNames...)
+}
+
+// Value returns the value of the SMHI parameter with the given name, the
+// second return value is false if the parameter is unknown or absent, such
+// as the waves of points over land.
+func (f *Forecast) Value(name string) (float64, bool) {
+	switch name {
+	case ParamAirPressure:
+		return f.AirPressure, true
+	case ParamAirTemperature:
+		return f.AirTemperature, true
+	case ParamHorizontalVisibility:
+		return f.HorizontalVisibility, true
+	case ParamWindDirection:
+		return float64(f.WindDirection), true
+	case ParamWindSpeed:
+		return f.WindSpeed, true
+	case ParamRelativeHumidity:
+		return float64(f.RelativeHumidity), true
+	case ParamThunderProbability:
+		return float64(f.ThunderProbability), true
+	case ParamTotalCloudCover:
+		return float64(f.MeanValueOfTotalCloudCover), true
+	case ParamLowLevelCloudCover:
+		return float64(f.MeanValueOfLowLevelCloudCover), true
+	case ParamMediumLevelCloudCover:
+		return float64(f.MeanValueOfMediumLevelCloudCover), true
+	case ParamHighLevelCloudCover:
+		return float64(f.MeanValueOfHighLevelCloudCover), true
+	case ParamWindGustSpeed:
+		return f.WindGustSpeed, true
+	case ParamMinimumPrecipitationIntensity:
+		return f.MinimumPrecipitationIntensity, true
+	case ParamMaximumPrecipitationIntensity:
+		return f.MaximumPrecipitationIntensity, true
+	case ParamFrozenPrecipitation:
+		v, ok := f.PercentOfPrecipitationInFrozenForm.Get()
+		return float64(v), ok
+	case ParamPrecipitationCategory:
+		return float64(f.PrecipitationCategory), true
+	case ParamMeanPrecipitationIntensity:
+		return f.MeanPrecipitationIntensity, true
+	case ParamMedianPrecipitationIntensity:
+		return f.MedianPrecipitationIntensity, true
+	case ParamWeatherSymbol:
+		return float64(f.WeatherSymbol), true
+	case ParamSignificantWaveHeight:
+		return f.SignificantWaveHeight.Get()
+	case ParamWaveDirection:
+		v, ok := f.WaveDirection.Get()
+		return float64(v), ok
+	case ParamWavePeriod:
+		return f.WavePeriod.Get()
+	}
+
+	return 0, false
+}
+
+// setValue sets the field that corresponds to the SMHI parameter with the
+// given name, false is returned if the parameter is unknown.
+func (f *Forecast) setValue(name string, v float64) bool {
+	switch name {
+	case ParamAirPressure:
+		f.AirPressure = v
+		break
+	case ParamAirTemperature:
+		f.AirTemperature = v
+		break
+	case ParamHorizontalVisibility:
+		f.HorizontalVisibility = v
+		break
+	case ParamWindDirection:
+		f.WindDirection = uint16(v)
+		break
+	case ParamWindSpeed:
+		f.WindSpeed = v
+		break
+	case ParamRelativeHumidity:
+		f.RelativeHumidity = uint8(v)
+		break
+	case ParamThunderProbability:
+		f.ThunderProbability = uint8(v)
+		break
+	case ParamTotalCloudCover:
+		f.MeanValueOfTotalCloudCover = uint8(v)
+		break
+	case ParamLowLevelCloudCover:
+		f.MeanValueOfLowLevelCloudCover = uint8(v)
+		break
+	case ParamMediumLevelCloudCover:
+		f.MeanValueOfMediumLevelCloudCover = uint8(v)
+		break
+	case ParamHighLevelCloudCover:
+		f.MeanValueOfHighLevelCloudCover = uint8(v)
+		break
+	case ParamWindGustSpeed:
+		f.WindGustSpeed = v
+		break
+	case ParamMinimumPrecipitationIntensity:
+		f.MinimumPrecipitationIntensity = v
+		break
+	case ParamMaximumPrecipitationIntensity:
+		f.MaximumPrecipitationIntensity = v
+		break
+	case ParamFrozenPrecipitation:
+		// The frozen part is -9 when there is no precipitation.
+		if v == -9 {
+			f.PercentOfPrecipitationInFrozenForm = Optional[int8]{}
+		} else {
+			f.PercentOfPrecipitationInFrozenForm = Some(int8(v))
+		}
+		break
+	case ParamPrecipitationCategory:
+		f.PrecipitationCategory = PrecipitationCategory(v)
+		break
+	case ParamMeanPrecipitationIntensity:
+		f.MeanPrecipitationIntensity = v
+		break
+	case ParamMedianPrecipitationIntensity:
+		f.MedianPrecipitationIntensity = v
+		break
+	case ParamWeatherSymbol:
+		f.WeatherSymbol = WeatherSymbol(v)
+		break
+	case ParamSignificantWaveHeight:
+		f.SignificantWaveHeight = Some(v)
+		break
+	case ParamWaveDirection:
+		f.WaveDirection = Some(uint16(v))
+		break
+	case ParamWavePeriod:
+		f.WavePeriod = Some(v)
+		break
+	default:
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,256 @@
+package smhi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+)
+
+const (
+	pollenURL = "https://api.pollenrapporten.se/v1"
+)
+
+// ErrNoPollenRegions is returned when there are no pollen regions to
+// choose from.
+var ErrNoPollenRegions = errors.New("smhi: no pollen regions")
+
+// PollenLevel is a level of the pollen scale, from NoPollen to
+// VeryHighPollen.
+type PollenLevel int8
+
+// PollenLevel constants.
+const (
+	NoPollen PollenLevel = iota
+	LowPollen
+	LowModeratePollen
+	ModeratePollen
+	ModerateHighPollen
+	HighPollen
+	VeryHighPollen
+)
+
+// PollenRegion is a region of the pollen forecasts.
+type PollenRegion struct {
+	ID   string
+	Name string
+	Lon  float64
+	Lat  float64
+}
+
+// PollenLevelValue is the forecasted level of a species on a day.
+type PollenLevelValue struct {
+	Species     string
+	Date        time.Time
+	Level       PollenLevel
+	Description map[string]string
+}
+
+// PollenForecast is the pollen forecast of a region, Text is the written
+// forecast in Swedish.
+type PollenForecast struct {
+	Region PollenRegion
+	Start  time.Time
+	End    time.Time
+	Text   string
+	Levels []PollenLevelValue
+}
+
+// pollenRegionsAPI defines the data structure of the regions that is
+// returned by the pollen API, the coordinates are accepted both as
+// numbers and as strings.
+type pollenRegionsAPI struct {
+	Items []struct {
+		ID        string
+		Name      string
+		Latitude  json.Number
+		Longitude json.Number
+	}
+}
+
+// pollenTypesAPI defines the data structure of the pollen types that is
+// returned by the pollen API.
+type pollenTypesAPI struct {
+	Items []struct {
+		ID   string
+		Name string
+	}
+}
+
+// PollenForecastAPI defines the data structure of the forecasts that is
+// returned by the pollen API.
+type PollenForecastAPI struct {
+	Items []struct {
+		RegionID    string
+		StartDate   string
+		EndDate     string
+		Text        string
+		LevelSeries []struct {
+			PollenID string
+			Level    int8
+			Time     string
+		}
+	}
+}
+
+// GetPollenRegions fetches the regions of the pollen forecasts using the
+// default client.
+func GetPollenRegions() ([]PollenRegion, error) {
+	return defaultClient.GetPollenRegions()
+}
+
+// GetPollenRegions fetches the regions of the pollen forecasts.
+func (c *Client) GetPollenRegions() ([]PollenRegion, error) {
+	var err error
+
+	var decodedData pollenRegionsAPI
+	if err = c.getJSON(pollenURL+"/regions", &decodedData); err != nil {
+		return nil, err
+	}
+
+	var ret []PollenRegion
+	for _, r := range decodedData.Items {
+		pr := PollenRegion{ID: r.ID, Name: r.Name}
+		if pr.Lon, err = r.Longitude.Float64(); err != nil {
+			return nil, err
+		}
+		if pr.Lat, err = r.Latitude.Float64(); err != nil {
+			return nil, err
+		}
+		ret = append(ret, pr)
+	}
+
+	return ret, nil
+}
+
+// GetPollenForecast fetches the current pollen forecast of the region that
+// is nearest to the given longitude and latitude using the default client.
+func GetPollenForecast(lon, lat float64) (*PollenForecast, error) {
+	return defaultClient.GetPollenForecast(lon, lat)
+}
+
+// GetPollenForecast fetches the current pollen forecast of the region that
+// is nearest to the given longitude and latitude. The levels are sorted by
+// date and species.
+func (c *Client) GetPollenForecast(lon, lat float64) (*PollenForecast, error) {
+	var err error
+
+	// Find the nearest region.
+	var regions []PollenRegion
+	if regions, err = c.GetPollenRegions(); err != nil {
+		return nil, err
+	}
+	if len(regions) == 0 {
+		return nil, ErrNoPollenRegions
+	}
+	region := regions[0]
+	for _, r := range regions[1:] {
+		if distance(lon, lat, r.Lon, r.Lat) < distance(lon, lat, region.Lon, region.Lat) {
+			region = r
+		}
+	}
+
+	// The species are only given by their ids in the forecast.
+	var types pollenTypesAPI
+	if err = c.getJSON(pollenURL+"/pollen-types", &types); err != nil {
+		return nil, err
+	}
+	species := make(map[string]string)
+	for _, t := range types.Items {
+		species[t.ID] = t.Name
+	}
+
+	var decodedData PollenForecastAPI
+	if err = c.getJSON(fmt.Sprintf("%s/forecasts?region_id=%s&current=true", pollenURL, url.QueryEscape(region.ID)), &decodedData); err != nil {
+		return nil, err
+	}
+	if len(decodedData.Items) == 0 {
+		return nil, errNotFound
+	}
+
+	f := decodedData.Items[0]
+	ret := PollenForecast{Region: region, Text: f.Text}
+	if ret.Start, err = parsePollenTime(f.StartDate); err != nil {
+		return nil, err
+	}
+	if ret.End, err = parsePollenTime(f.EndDate); err != nil {
+		return nil, err
+	}
+
+	for _, l := range f.LevelSeries {
+		var v PollenLevelValue
+		if v.Date, err = parsePollenTime(l.Time); err != nil {
+			return nil, err
+		}
+		v.Species = species[l.PollenID]
+		if v.Species == "" {
+			v.Species = l.PollenID
+		}
+		v.Level = PollenLevel(l.Level)
+		if c.descriptions {
+			v.Description = getPollenLevelDescription(v.Level)
+		}
+		ret.Levels = append(ret.Levels, v)
+	}
+
+	sort.SliceStable(ret.Levels, func(i, j int) bool {
+		if !ret.Levels[i].Date.Equal(ret.Levels[j].Date) {
+			return ret.Levels[i].Date.Before(ret.Levels[j].Date)
+		}
+		return ret.Levels[i].Species < ret.Levels[j].Species
+	})
+
+	return &ret, nil
+}
+
+// parsePollenTime parses a time of the pollen API, which is either a date
+// or a date and a time.
+func parsePollenTime(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse("2006-01-02T15:04:05", s); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", s)
+}
+
+// getPollenLevelDescription returns a friendly pollen level description.
+func getPollenLevelDescription(l PollenLevel) map[string]string {
+	ret := make(map[string]string)
+
+	switch l {
+	case NoPollen:
+		ret["sv-SE"] = "Inga halter"
+		ret["en-US"] = "None"
+		break
+	case LowPollen:
+		ret["sv-SE"] = "Låga halter"
+		ret["en-US"] = "Low"
+		break
+	case LowModeratePollen:
+		ret["sv-SE"] = "Låga till måttliga halter"
+		ret["en-US"] = "Low to moderate"
+		break
+	case ModeratePollen:
+		ret["sv-SE"] = "Måttliga halter"
+		ret["en-US"] = "Moderate"
+		break
+	case ModerateHighPollen:
+		ret["sv-SE"] = "Måttliga till höga halter"
+		ret["en-US"] = "Moderate to high"
+		break
+	case HighPollen:
+		ret["sv-SE"] = "Höga halter"
+		ret["en-US"] = "High"
+		break
+	case VeryHighPollen:
+		ret["sv-SE"] = "Mycket höga halter"
+		ret["en-US"] = "Very high"
+		break
+	}
+
+	return ret
+}
@@ -0,0 +1,87 @@
+package smhi
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Precision maps the names of SMHI parameters to the number of decimals
+// that their values are rounded to, the parameters that aren't in the map
+// are left as they are.
+type Precision map[string]int
+
+// DefaultPrecision rounds the temperatures, wind speeds, visibility,
+// precipitation and waves to one decimal and the pressure to whole hPa.
+var DefaultPrecision = Precision{
+	"msl":     0,
+	"t":       1,
+	"vis":     1,
+	"ws":      1,
+	"gust":    1,
+	"pmin":    1,
+	"pmax":    1,
+	"pmean":   1,
+	"pmedian": 1,
+
+	"sea_surface_wave_significant_height": 1,
+	"sea_surface_wave_mean_period":        1,
+}
+
+// ParsePrecision parses a precision of comma separated parameters and
+// decimals, such as "t=1,msl=0".
+func ParsePrecision(s string) (Precision, error) {
+	ret := make(Precision)
+
+	for _, kv := range strings.Split(s, ",") {
+		kv = strings.TrimSpace(kv)
+		if kv == "" {
+			continue
+		}
+
+		i := strings.IndexByte(kv, '=')
+		if i < 0 {
+			return nil, fmt.Errorf("smhi: invalid precision %q", kv)
+		}
+		d, err := strconv.Atoi(kv[i+1:])
+		if err != nil || d < 0 {
+			return nil, fmt.Errorf("smhi: invalid precision %q", kv)
+		}
+		ret[kv[:i]] = d
+	}
+
+	return ret, nil
+}
+
+// WithPrecision makes the client round the values of the point forecasts
+// with the given precision, so that they are free of floating point noise
+// such as 13.600000000000001 when they are printed or marshaled.
+func WithPrecision(p Precision) Option {
+	return func(c *Client) {
+		c.precision = p
+	}
+}
+
+// Round rounds the values of the time steps with the given precision, the
+// apparent temperature is rounded like the air temperature.
+func (pf *PointForecast) Round(p Precision) {
+	for i := range pf.TimeSeries {
+		f := &pf.TimeSeries[i]
+		for name, d := range p {
+			if v, ok := f.Value(name); ok {
+				f.setValue(name, roundTo(v, d))
+			}
+		}
+		if d, ok := p["t"]; ok {
+			f.ApparentTemperature = roundTo(f.ApparentTemperature, d)
+		}
+		f.Hash = getHash(f)
+	}
+}
+
+// roundTo rounds the value to the number of decimals.
+func roundTo(v float64, decimals int) float64 {
+	scale := math.Pow(10, float64(decimals))
+	return math.Round(v*scale) / scale
+}
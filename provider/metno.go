@@ -0,0 +1,218 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/osm/smhi"
+)
+
+const (
+	metNorwayURL = "https://api.met.no/weatherapi/locationforecast/2.0/complete?lat=%.4f&lon=%.4f"
+)
+
+// metNorwaySymbols maps the MET Norway symbol codes, without the _day,
+// _night and _polartwilight suffixes, to weather symbols. The codes that
+// end with andthunder are mapped by getMetNorwaySymbol.
+var metNorwaySymbols = map[string]smhi.WeatherSymbol{
+	"clearsky":          smhi.ClearSky,
+	"fair":              smhi.NearlyClearSky,
+	"partlycloudy":      smhi.HalfclearSky,
+	"cloudy":            smhi.CloudySky,
+	"fog":               smhi.Fog,
+	"lightrainshowers":  smhi.LightRainShowers,
+	"rainshowers":       smhi.ModerateRainShowers,
+	"heavyrainshowers":  smhi.HeavyRainShowers,
+	"lightsleetshowers": smhi.LightSleetShowers,
+	"sleetshowers":      smhi.ModerateSleetShowers,
+	"heavysleetshowers": smhi.HeavySleetShowers,
+	"lightsnowshowers":  smhi.LightSnowShowers,
+	"snowshowers":       smhi.ModerateSnowShowers,
+	"heavysnowshowers":  smhi.HeavySnowShowers,
+	"lightrain":         smhi.LightRain,
+	"rain":              smhi.ModerateRain,
+	"heavyrain":         smhi.HeavyRain,
+	"lightsleet":        smhi.LightSleet,
+	"sleet":             smhi.ModerateSleet,
+	"heavysleet":        smhi.HeavySleet,
+	"lightsnow":         smhi.LightSnowfall,
+	"snow":              smhi.ModerateSnowfall,
+	"heavysnow":         smhi.HeavySnowfall,
+}
+
+// MetNorwayAPI defines the data structure that is returned by the
+// locationforecast API of MET Norway.
+type MetNorwayAPI struct {
+	Geometry struct {
+		Type        string
+		Coordinates []float64
+	}
+	Properties struct {
+		Meta struct {
+			UpdatedAt string `json:"updated_at"`
+		}
+		TimeSeries []struct {
+			Time string
+			Data struct {
+				Instant struct {
+					Details metNorwayDetails
+				}
+				Next1Hours  metNorwayPeriod `json:"next_1_hours"`
+				Next6Hours  metNorwayPeriod `json:"next_6_hours"`
+				Next12Hours metNorwayPeriod `json:"next_12_hours"`
+			}
+		} `json:"timeseries"`
+	}
+}
+
+// metNorwayDetails holds the values of a MET Norway time step.
+type metNorwayDetails struct {
+	AirPressureAtSeaLevel float64 `json:"air_pressure_at_sea_level"`
+	AirTemperature        float64 `json:"air_temperature"`
+	CloudAreaFraction     float64 `json:"cloud_area_fraction"`
+	RelativeHumidity      float64 `json:"relative_humidity"`
+	WindFromDirection     float64 `json:"wind_from_direction"`
+	WindSpeed             float64 `json:"wind_speed"`
+	WindSpeedOfGust       float64 `json:"wind_speed_of_gust"`
+	PrecipitationAmount   float64 `json:"precipitation_amount"`
+	ProbabilityOfThunder  float64 `json:"probability_of_thunder"`
+}
+
+// metNorwayPeriod holds the summary and the values of the period that
+// follows a MET Norway time step.
+type metNorwayPeriod struct {
+	Summary struct {
+		SymbolCode string `json:"symbol_code"`
+	}
+	Details *metNorwayDetails
+}
+
+// MetNorway is the Provider of the location forecast of the Norwegian
+// Meteorological Institute from api.met.no, which covers the Nordic
+// countries in detail and the rest of the world more coarsely.
+type MetNorway struct {
+	// UserAgent identifies the application, api.met.no rejects requests
+	// without one.
+	UserAgent string
+
+	// Transport sends the requests, the default transport of net/http is
+	// used if it's nil.
+	Transport http.RoundTripper
+}
+
+// Name returns the name of the provider.
+func (m *MetNorway) Name() string {
+	return "metno"
+}
+
+// PointForecast fetches the forecast for the given longitude and latitude.
+// The responses other than 200 OK are returned as an smhi.APIError.
+func (m *MetNorway) PointForecast(lon, lat float64) (*PointForecast, error) {
+	var err error
+
+	url := fmt.Sprintf(metNorwayURL, lat, lon)
+	var req *http.Request
+	if req, err = http.NewRequest(http.MethodGet, url, nil); err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", m.UserAgent)
+
+	var res *http.Response
+	c := &http.Client{Transport: m.Transport}
+	if res, err = c.Do(req); err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(res.Body)
+		return nil, &smhi.APIError{StatusCode: res.StatusCode, Status: res.Status, URL: url, Body: body}
+	}
+
+	var decodedData MetNorwayAPI
+	if err = json.NewDecoder(res.Body).Decode(&decodedData); err != nil {
+		return nil, err
+	}
+
+	return fromMetNorway(&decodedData, lon, lat)
+}
+
+// fromMetNorway converts the MetNorwayAPI object to a PointForecast
+// object, the given longitude and latitude are used if the response has
+// no point.
+func fromMetNorway(d *MetNorwayAPI, lon, lat float64) (*PointForecast, error) {
+	var err error
+
+	ret := &PointForecast{Provider: "metno", Lon: lon, Lat: lat}
+	if ret.Updated, err = time.Parse(time.RFC3339, d.Properties.Meta.UpdatedAt); err != nil {
+		return nil, err
+	}
+	if len(d.Geometry.Coordinates) >= 2 {
+		ret.Lon, ret.Lat = d.Geometry.Coordinates[0], d.Geometry.Coordinates[1]
+	}
+
+	for _, t := range d.Properties.TimeSeries {
+		var f Forecast
+		if f.Time, err = time.Parse(time.RFC3339, t.Time); err != nil {
+			return nil, err
+		}
+
+		i := t.Data.Instant.Details
+		f.Temperature = i.AirTemperature
+		f.Pressure = i.AirPressureAtSeaLevel
+		f.Humidity = i.RelativeHumidity
+		f.WindSpeed = i.WindSpeed
+		f.WindGust = i.WindSpeedOfGust
+		f.WindDirection = i.WindFromDirection
+		f.CloudCover = i.CloudAreaFraction
+		f.ApparentTemperature = smhi.ApparentTemperature(&smhi.Forecast{
+			Timestamp:                  f.Time,
+			AirTemperature:             f.Temperature,
+			RelativeHumidity:           uint8(math.Round(f.Humidity)),
+			WindSpeed:                  f.WindSpeed,
+			MeanValueOfTotalCloudCover: uint8(math.Round(math.Max(0, math.Min(100, f.CloudCover)) / 12.5)),
+		}, ret.Lon, ret.Lat)
+
+		// The hourly period is used when there is one, the precipitation
+		// of the longer periods is spread evenly over their hours.
+		period, hours := t.Data.Next1Hours, 1.0
+		if period.Details == nil {
+			period, hours = t.Data.Next6Hours, 6
+		}
+		if period.Summary.SymbolCode == "" {
+			period.Summary = t.Data.Next12Hours.Summary
+		}
+		f.Symbol = getMetNorwaySymbol(period.Summary.SymbolCode)
+		f.Condition = getCondition(f.Symbol)
+		if p := period.Details; p != nil {
+			f.Precipitation = p.PrecipitationAmount / hours
+			f.ThunderProbability = p.ProbabilityOfThunder
+		}
+
+		ret.Steps = append(ret.Steps, f)
+	}
+
+	return ret, nil
+}
+
+// getMetNorwaySymbol returns the weather symbol of a MET Norway symbol
+// code.
+func getMetNorwaySymbol(code string) smhi.WeatherSymbol {
+	if i := strings.IndexByte(code, '_'); i >= 0 {
+		code = code[:i]
+	}
+
+	if strings.HasSuffix(code, "andthunder") {
+		if strings.Contains(code, "showers") {
+			return smhi.Thunderstorm
+		}
+		return smhi.Thunder
+	}
+
+	return metNorwaySymbols[code]
+}
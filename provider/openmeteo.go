@@ -0,0 +1,148 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/osm/smhi"
+)
+
+const (
+	openMeteoURL = "https://api.open-meteo.com/v1/forecast?latitude=%.4f&longitude=%.4f&hourly=temperature_2m,apparent_temperature,relative_humidity_2m,pressure_msl,wind_speed_10m,wind_gusts_10m,wind_direction_10m,cloud_cover,precipitation,weather_code&wind_speed_unit=ms&timeformat=unixtime"
+)
+
+// openMeteoSymbols maps the WMO weather codes that are returned by
+// Open-Meteo to weather symbols.
+var openMeteoSymbols = map[int]smhi.WeatherSymbol{
+	0:  smhi.ClearSky,
+	1:  smhi.NearlyClearSky,
+	2:  smhi.HalfclearSky,
+	3:  smhi.Overcast,
+	45: smhi.Fog,
+	48: smhi.Fog,
+	51: smhi.LightRain,
+	53: smhi.LightRain,
+	55: smhi.ModerateRain,
+	56: smhi.LightRain,
+	57: smhi.ModerateRain,
+	61: smhi.LightRain,
+	63: smhi.ModerateRain,
+	65: smhi.HeavyRain,
+	66: smhi.LightRain,
+	67: smhi.HeavyRain,
+	71: smhi.LightSnowfall,
+	73: smhi.ModerateSnowfall,
+	75: smhi.HeavySnowfall,
+	77: smhi.LightSnowfall,
+	80: smhi.LightRainShowers,
+	81: smhi.ModerateRainShowers,
+	82: smhi.HeavyRainShowers,
+	85: smhi.LightSnowShowers,
+	86: smhi.HeavySnowShowers,
+	95: smhi.Thunderstorm,
+	96: smhi.Thunderstorm,
+	99: smhi.Thunderstorm,
+}
+
+// OpenMeteoAPI defines the data structure that is returned by the
+// Open-Meteo forecast API.
+type OpenMeteoAPI struct {
+	Latitude  float64
+	Longitude float64
+	Hourly    struct {
+		Time                []int64
+		Temperature2m       []float64 `json:"temperature_2m"`
+		ApparentTemperature []float64 `json:"apparent_temperature"`
+		RelativeHumidity2m  []float64 `json:"relative_humidity_2m"`
+		PressureMSL         []float64 `json:"pressure_msl"`
+		WindSpeed10m        []float64 `json:"wind_speed_10m"`
+		WindGusts10m        []float64 `json:"wind_gusts_10m"`
+		WindDirection10m    []float64 `json:"wind_direction_10m"`
+		CloudCover          []float64 `json:"cloud_cover"`
+		Precipitation       []float64
+		WeatherCode         []int `json:"weather_code"`
+	}
+}
+
+// OpenMeteo is the Provider of the Open-Meteo forecast, which covers the
+// whole world. Combined with Fallback(SMHI(nil), &OpenMeteo{}) the
+// locations outside of the SMHI grid get the Open-Meteo forecast.
+type OpenMeteo struct {
+	// Transport sends the requests, the default transport of net/http is
+	// used if it's nil.
+	Transport http.RoundTripper
+}
+
+// Name returns the name of the provider.
+func (o *OpenMeteo) Name() string {
+	return "openmeteo"
+}
+
+// PointForecast fetches the forecast for the given longitude and latitude.
+// Updated is the time of the request, since the API doesn't tell when the
+// forecast was made.
+func (o *OpenMeteo) PointForecast(lon, lat float64) (*PointForecast, error) {
+	var err error
+
+	var res *http.Response
+	c := &http.Client{Transport: o.Transport}
+	if res, err = c.Get(fmt.Sprintf(openMeteoURL, lat, lon)); err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("provider: open-meteo responded with %s", res.Status)
+	}
+
+	var decodedData OpenMeteoAPI
+	if err = json.NewDecoder(res.Body).Decode(&decodedData); err != nil {
+		return nil, err
+	}
+
+	return fromOpenMeteo(&decodedData, time.Now().UTC()), nil
+}
+
+// fromOpenMeteo converts the OpenMeteoAPI object to a PointForecast object.
+func fromOpenMeteo(d *OpenMeteoAPI, updated time.Time) *PointForecast {
+	ret := &PointForecast{
+		Provider: "openmeteo",
+		Lon:      d.Longitude,
+		Lat:      d.Latitude,
+		Updated:  updated,
+	}
+
+	// The values are missing in the arrays that are shorter than the time
+	// array, such as the gusts of some models.
+	h := d.Hourly
+	at := func(vs []float64, i int) float64 {
+		if i < len(vs) {
+			return vs[i]
+		}
+		return 0
+	}
+
+	for i, t := range h.Time {
+		f := Forecast{
+			Time:                time.Unix(t, 0).UTC(),
+			Temperature:         at(h.Temperature2m, i),
+			ApparentTemperature: at(h.ApparentTemperature, i),
+			Pressure:            at(h.PressureMSL, i),
+			Humidity:            at(h.RelativeHumidity2m, i),
+			WindSpeed:           at(h.WindSpeed10m, i),
+			WindGust:            at(h.WindGusts10m, i),
+			WindDirection:       at(h.WindDirection10m, i),
+			CloudCover:          at(h.CloudCover, i),
+			Precipitation:       at(h.Precipitation, i),
+		}
+		if i < len(h.WeatherCode) {
+			f.Symbol = openMeteoSymbols[h.WeatherCode[i]]
+			f.Condition = getCondition(f.Symbol)
+		}
+		ret.Steps = append(ret.Steps, f)
+	}
+
+	return ret
+}
@@ -0,0 +1,196 @@
+// Package provider defines a forecast model and interface that don't depend
+// on the forecast provider, so that applications can combine SMHI with other
+// sources. SMHI is the reference implementation.
+package provider
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/osm/smhi"
+)
+
+// ErrNoProviders is returned by a fallback without any providers.
+var ErrNoProviders = errors.New("provider: no providers")
+
+// Condition constants.
+const (
+	Clear        Condition = "clear"
+	PartlyCloudy Condition = "partly-cloudy"
+	Cloudy       Condition = "cloudy"
+	Fog          Condition = "fog"
+	Rain         Condition = "rain"
+	Sleet        Condition = "sleet"
+	Snow         Condition = "snow"
+	Thunder      Condition = "thunder"
+)
+
+// Condition is a coarse description of the weather that all providers can
+// be mapped onto.
+type Condition string
+
+// Forecast is a time step of a forecast. Temperatures are in C, the
+// pressure in hPa, wind speeds in m/s, the wind direction in degrees, the
+// cloud cover, humidity and thunder probability in percent and the
+// precipitation in mm/h.
+type Forecast struct {
+	Time                time.Time
+	Temperature         float64
+	ApparentTemperature float64
+	Pressure            float64
+	Humidity            float64
+	WindSpeed           float64
+	WindGust            float64
+	WindDirection       float64
+	CloudCover          float64
+	Precipitation       float64
+	ThunderProbability  float64
+	Condition           Condition
+	Symbol              smhi.WeatherSymbol
+}
+
+// PointForecast is the forecast of a location by a provider.
+type PointForecast struct {
+	Provider string
+	Lon      float64
+	Lat      float64
+	Updated  time.Time
+	Steps    []Forecast
+}
+
+// Provider fetches point forecasts.
+type Provider interface {
+	// Name returns the name of the provider, such as "smhi".
+	Name() string
+
+	// PointForecast fetches the forecast for the given longitude and
+	// latitude.
+	PointForecast(lon, lat float64) (*PointForecast, error)
+}
+
+// adapter is a Provider that converts the forecasts of an SMHI client.
+type adapter struct {
+	name string
+	c    *smhi.Client
+}
+
+// New returns a Provider with the given name for an SMHI client, such as a
+// client of another forecast category.
+func New(name string, c *smhi.Client) Provider {
+	return &adapter{name: name, c: c}
+}
+
+// SMHI returns the SMHI provider, the default client is used if c is nil.
+func SMHI(c *smhi.Client) Provider {
+	if c == nil {
+		c = smhi.NewClient()
+	}
+	return New("smhi", c)
+}
+
+// Name returns the name of the provider.
+func (a *adapter) Name() string {
+	return a.name
+}
+
+// PointForecast fetches the forecast and converts it.
+func (a *adapter) PointForecast(lon, lat float64) (*PointForecast, error) {
+	var err error
+
+	var pf *smhi.PointForecast
+	if pf, err = a.c.GetPointForecast(lon, lat); err != nil {
+		return nil, err
+	}
+
+	return FromSMHI(a.name, lon, lat, pf), nil
+}
+
+// FromSMHI converts an SMHI point forecast of the given provider and
+// location.
+func FromSMHI(name string, lon, lat float64, pf *smhi.PointForecast) *PointForecast {
+	ret := &PointForecast{
+		Provider: name,
+		Lon:      lon,
+		Lat:      lat,
+		Updated:  pf.ApprovedTime,
+	}
+
+	for _, f := range pf.TimeSeries {
+		ret.Steps = append(ret.Steps, Forecast{
+			Time:                f.Timestamp,
+			Temperature:         f.AirTemperature,
+			ApparentTemperature: f.ApparentTemperature,
+			Pressure:            f.AirPressure,
+			Humidity:            float64(f.RelativeHumidity),
+			WindSpeed:           f.WindSpeed,
+			WindGust:            f.WindGustSpeed,
+			WindDirection:       float64(f.WindDirection),
+			CloudCover:          float64(f.MeanValueOfTotalCloudCover) * 12.5,
+			Precipitation:       f.MeanPrecipitationIntensity,
+			ThunderProbability:  float64(f.ThunderProbability),
+			Condition:           getCondition(f.WeatherSymbol),
+			Symbol:              f.WeatherSymbol,
+		})
+	}
+
+	return ret
+}
+
+// getCondition returns the condition of a weather symbol.
+func getCondition(ws smhi.WeatherSymbol) Condition {
+	switch ws {
+	case smhi.ClearSky, smhi.NearlyClearSky:
+		return Clear
+	case smhi.VariableCloudiness, smhi.HalfclearSky:
+		return PartlyCloudy
+	case smhi.CloudySky, smhi.Overcast:
+		return Cloudy
+	case smhi.Fog:
+		return Fog
+	case smhi.Thunderstorm, smhi.Thunder:
+		return Thunder
+	}
+
+	switch {
+	case ws >= smhi.LightRainShowers && ws <= smhi.HeavyRainShowers, ws >= smhi.LightRain && ws <= smhi.HeavyRain:
+		return Rain
+	case ws >= smhi.LightSleetShowers && ws <= smhi.HeavySleetShowers, ws >= smhi.LightSleet && ws <= smhi.HeavySleet:
+		return Sleet
+	case ws >= smhi.LightSnowShowers && ws <= smhi.HeavySnowShowers, ws >= smhi.LightSnowfall && ws <= smhi.HeavySnowfall:
+		return Snow
+	}
+
+	return ""
+}
+
+// fallback is a Provider that tries its providers in order.
+type fallback []Provider
+
+// Fallback returns a Provider that returns the forecast of the first of
+// the providers that succeeds, the error of the last one is returned if
+// they all fail.
+func Fallback(providers ...Provider) Provider {
+	return fallback(providers)
+}
+
+// Name returns the names of the providers.
+func (f fallback) Name() string {
+	var names []string
+	for _, p := range f {
+		names = append(names, p.Name())
+	}
+	return strings.Join(names, ",")
+}
+
+// PointForecast fetches the forecast of the first provider that succeeds.
+func (f fallback) PointForecast(lon, lat float64) (*PointForecast, error) {
+	err := ErrNoProviders
+	for _, p := range f {
+		var pf *PointForecast
+		if pf, err = p.PointForecast(lon, lat); err == nil {
+			return pf, nil
+		}
+	}
+	return nil, err
+}
@@ -0,0 +1,161 @@
+package smhi
+
+import (
+	"math"
+	"strconv"
+)
+
+// Unit constants.
+const (
+	Celsius            Unit = "C"
+	Fahrenheit         Unit = "F"
+	Kelvin             Unit = "K"
+	MetersPerSecond    Unit = "m/s"
+	KilometersPerHour  Unit = "km/h"
+	Knots              Unit = "kn"
+	MilesPerHour       Unit = "mph"
+	Hectopascal        Unit = "hPa"
+	InchesOfMercury    Unit = "inHg"
+	Kilometers         Unit = "km"
+	Miles              Unit = "mi"
+	Meters             Unit = "m"
+	Feet               Unit = "ft"
+	MillimetersPerHour Unit = "mm/h"
+	InchesPerHour      Unit = "in/h"
+	Percent            Unit = "%"
+	Degrees            Unit = "°"
+	Octas              Unit = "octas"
+	Seconds            Unit = "s"
+)
+
+// Unit is the unit of a Quantity.
+type Unit string
+
+// unitScales maps each unit to its base unit and the factor that converts a
+// value in the unit to the base unit, the temperatures are converted by
+// toCelsius and fromCelsius instead.
+var unitScales = map[Unit]struct {
+	base   Unit
+	factor float64
+}{
+	MetersPerSecond:    {MetersPerSecond, 1},
+	KilometersPerHour:  {MetersPerSecond, 1 / 3.6},
+	Knots:              {MetersPerSecond, 1 / knotsPerMeterPerSecond},
+	MilesPerHour:       {MetersPerSecond, 0.44704},
+	Hectopascal:        {Hectopascal, 1},
+	InchesOfMercury:    {Hectopascal, 33.8639},
+	Kilometers:         {Meters, 1000},
+	Miles:              {Meters, 1609.344},
+	Meters:             {Meters, 1},
+	Feet:               {Meters, 0.3048},
+	MillimetersPerHour: {MillimetersPerHour, 1},
+	InchesPerHour:      {MillimetersPerHour, 25.4},
+	Percent:            {Percent, 1},
+	Degrees:            {Degrees, 1},
+	Octas:              {Octas, 1},
+	Seconds:            {Seconds, 1},
+}
+
+// Quantity is a value with its unit.
+type Quantity struct {
+	Value float64
+	Unit  Unit
+}
+
+// In converts the quantity to the given unit, the value is NaN if the units
+// measure different things, such as a speed and a pressure.
+func (q Quantity) In(u Unit) Quantity {
+	if q.Unit == u {
+		return q
+	}
+
+	if isTemperature(q.Unit) && isTemperature(u) {
+		return Quantity{fromCelsius(toCelsius(q.Value, q.Unit), u), u}
+	}
+
+	from, okFrom := unitScales[q.Unit]
+	to, okTo := unitScales[u]
+	if !okFrom || !okTo || from.base != to.base {
+		return Quantity{math.NaN(), u}
+	}
+	return Quantity{q.Value * from.factor / to.factor, u}
+}
+
+// String returns the quantity with as few digits as needed and its unit,
+// such as "4.1 m/s".
+func (q Quantity) String() string {
+	return strconv.FormatFloat(q.Value, 'f', -1, 64) + " " + string(q.Unit)
+}
+
+// isTemperature returns true for the units of temperature.
+func isTemperature(u Unit) bool {
+	return u == Celsius || u == Fahrenheit || u == Kelvin
+}
+
+// toCelsius converts a temperature in the unit to C.
+func toCelsius(v float64, u Unit) float64 {
+	switch u {
+	case Fahrenheit:
+		return (v - 32) * 5 / 9
+	case Kelvin:
+		return v - 273.15
+	}
+	return v
+}
+
+// fromCelsius converts a temperature in C to the unit.
+func fromCelsius(v float64, u Unit) float64 {
+	switch u {
+	case Fahrenheit:
+		return v*9/5 + 32
+	case Kelvin:
+		return v + 273.15
+	}
+	return v
+}
+
+// Temperature returns the air temperature.
+func (f *Forecast) Temperature() Quantity {
+	return Quantity{f.AirTemperature, Celsius}
+}
+
+// FeelsLike returns the apparent temperature.
+func (f *Forecast) FeelsLike() Quantity {
+	return Quantity{f.ApparentTemperature, Celsius}
+}
+
+// Wind returns the wind speed.
+func (f *Forecast) Wind() Quantity {
+	return Quantity{f.WindSpeed, MetersPerSecond}
+}
+
+// Gust returns the wind gust speed.
+func (f *Forecast) Gust() Quantity {
+	return Quantity{f.WindGustSpeed, MetersPerSecond}
+}
+
+// Pressure returns the air pressure at mean sea level.
+func (f *Forecast) Pressure() Quantity {
+	return Quantity{f.AirPressure, Hectopascal}
+}
+
+// Visibility returns the horizontal visibility.
+func (f *Forecast) Visibility() Quantity {
+	return Quantity{f.HorizontalVisibility, Kilometers}
+}
+
+// Precipitation returns the mean precipitation intensity.
+func (f *Forecast) Precipitation() Quantity {
+	return Quantity{f.MeanPrecipitationIntensity, MillimetersPerHour}
+}
+
+// Humidity returns the relative humidity.
+func (f *Forecast) Humidity() Quantity {
+	return Quantity{float64(f.RelativeHumidity), Percent}
+}
+
+// WaveHeight returns the significant wave height, the value is NaN for
+// points without waves.
+func (f *Forecast) WaveHeight() Quantity {
+	return Quantity{f.SignificantWaveHeight.Or(math.NaN()), Meters}
+}
@@ -0,0 +1,155 @@
+package smhi
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color/palette"
+	"image/draw"
+	"image/gif"
+	"image/png"
+	"io"
+	"sort"
+	"time"
+)
+
+const (
+	radarURL = "https://opendata-download-radar.smhi.se/api/version/latest/area/sweden/product/comp"
+)
+
+// ErrNoRadarImages is returned when there are no radar images for a
+// period.
+var ErrNoRadarImages = errors.New("smhi: no radar images")
+
+// RadarImage is a composite radar image of Sweden.
+type RadarImage struct {
+	Key   string
+	Valid time.Time
+	URL   string
+}
+
+// RadarAPI defines the data structure that is returned by the SMHI radar
+// API for the images of a day.
+type RadarAPI struct {
+	Files []struct {
+		Key     string
+		Valid   string
+		Formats []struct {
+			Key  string
+			Link string
+		}
+	}
+}
+
+// GetRadarImages fetches the list of the PNG radar images of the day of
+// the given time in UTC using the default client.
+func GetRadarImages(day time.Time) ([]RadarImage, error) {
+	return defaultClient.GetRadarImages(day)
+}
+
+// GetRadarImages fetches the list of the PNG radar images of the day of
+// the given time in UTC, sorted by their valid time.
+func (c *Client) GetRadarImages(day time.Time) ([]RadarImage, error) {
+	var err error
+
+	day = day.UTC()
+
+	var decodedData RadarAPI
+	if err = c.getJSON(fmt.Sprintf("%s/%d/%d/%d", radarURL, day.Year(), day.Month(), day.Day()), &decodedData); err != nil {
+		return nil, err
+	}
+
+	var ret []RadarImage
+	for _, f := range decodedData.Files {
+		for _, fm := range f.Formats {
+			if fm.Key != "png" {
+				continue
+			}
+
+			img := RadarImage{Key: f.Key, URL: fm.Link}
+			if img.Valid, err = time.Parse("2006-01-02 15:04", f.Valid); err != nil {
+				return nil, err
+			}
+			ret = append(ret, img)
+		}
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Valid.Before(ret[j].Valid)
+	})
+
+	return ret, nil
+}
+
+// GetRecentRadarImages fetches the list of the PNG radar images of the
+// given duration up to now using the default client.
+func GetRecentRadarImages(d time.Duration) ([]RadarImage, error) {
+	return defaultClient.GetRecentRadarImages(d)
+}
+
+// GetRecentRadarImages fetches the list of the PNG radar images of the
+// given duration up to now, ErrNoRadarImages is returned if there are
+// none.
+func (c *Client) GetRecentRadarImages(d time.Duration) ([]RadarImage, error) {
+	var err error
+
+	now := time.Now().UTC()
+	from := now.Add(-d)
+
+	// The period may start the day before.
+	var ret []RadarImage
+	for day := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC); !day.After(now); day = day.AddDate(0, 0, 1) {
+		var images []RadarImage
+		if images, err = c.GetRadarImages(day); err != nil && !errors.Is(err, errNotFound) {
+			return nil, err
+		}
+		for _, img := range images {
+			if !img.Valid.Before(from) {
+				ret = append(ret, img)
+			}
+		}
+	}
+	if len(ret) == 0 {
+		return nil, ErrNoRadarImages
+	}
+
+	return ret, nil
+}
+
+// GetRadarImage fetches the PNG data of the radar image using the default
+// client.
+func GetRadarImage(img RadarImage) ([]byte, error) {
+	return defaultClient.GetRadarImage(img)
+}
+
+// GetRadarImage fetches the PNG data of the radar image.
+func (c *Client) GetRadarImage(img RadarImage) ([]byte, error) {
+	return c.get(img.URL)
+}
+
+// EncodeRadarGIF writes the PNG radar images as an animated GIF that shows
+// each image for the given delay, the images are drawn on white and mapped
+// to the Plan 9 palette.
+func EncodeRadarGIF(w io.Writer, images [][]byte, delay time.Duration) error {
+	if len(images) == 0 {
+		return ErrNoRadarImages
+	}
+
+	var ret gif.GIF
+	for _, data := range images {
+		img, err := png.Decode(bytes.NewReader(data))
+		if err != nil {
+			return err
+		}
+
+		frame := image.NewPaletted(img.Bounds(), palette.Plan9)
+		draw.Draw(frame, frame.Rect, image.White, image.Point{}, draw.Src)
+		draw.Draw(frame, frame.Rect, img, img.Bounds().Min, draw.Over)
+
+		ret.Image = append(ret.Image, frame)
+		ret.Delay = append(ret.Delay, int(delay/(10*time.Millisecond)))
+	}
+
+	return gif.EncodeAll(w, &ret)
+}
@@ -0,0 +1,69 @@
+package smhi
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// rateLimiter is a token bucket that holds up to burst tokens, which are
+// refilled at rate tokens per second.
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// WithRateLimit limits the requests of the client to the given number of
+// requests per second on average, with bursts of up to burst requests. The
+// requests wait for their turn, or until their context is done. Each
+// attempt of a retried request counts, and the limit is shared by the
+// copies of Client.With. The requests aren't limited if rps isn't
+// positive.
+func WithRateLimit(rps float64, burst int) Option {
+	return func(c *Client) {
+		if rps <= 0 {
+			c.limiter = nil
+			return
+		}
+		if burst < 1 {
+			burst = 1
+		}
+		c.limiter = &rateLimiter{rate: rps, burst: float64(burst), tokens: float64(burst)}
+	}
+}
+
+// wait takes a token from the bucket, waiting for it to be refilled if
+// it's empty. The token is taken up front so that the waiting requests are
+// let through in order, and it's given back if the context is done before
+// it's the turn of the request.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	if !l.last.IsZero() {
+		l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	}
+	l.last = now
+	l.tokens--
+	tokens := l.tokens
+	l.mu.Unlock()
+
+	if tokens >= 0 {
+		return nil
+	}
+
+	t := time.NewTimer(time.Duration(-tokens / l.rate * float64(time.Second)))
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,46 @@
+package smhi
+
+import (
+	"sync"
+)
+
+// ParameterDecoder decodes the values of an SMHI parameter into the
+// forecast, unknown parameters can be kept in the Extra map.
+type ParameterDecoder func(values []float64, f *Forecast)
+
+// decoders holds the registered parameter decoders.
+var decoders = struct {
+	sync.RWMutex
+	m map[string]ParameterDecoder
+}{m: make(map[string]ParameterDecoder)}
+
+// RegisterParameter registers a decoder for the SMHI parameter with the
+// given name, which makes parameters that aren't known by the package
+// usable. A registered decoder replaces the decoding of a known parameter,
+// and a nil decoder removes the registration.
+func RegisterParameter(name string, dec ParameterDecoder) {
+	decoders.Lock()
+	defer decoders.Unlock()
+
+	if dec == nil {
+		delete(decoders.m, name)
+		return
+	}
+	decoders.m[name] = dec
+}
+
+// decode decodes the values of the parameter into the forecast with the
+// registered decoder, or into the field of the parameter.
+func (f *Forecast) decode(name string, values []float64) {
+	decoders.RLock()
+	dec, ok := decoders.m[name]
+	decoders.RUnlock()
+
+	if ok {
+		dec(values, f)
+		return
+	}
+	if len(values) > 0 {
+		f.setValue(name, values[0])
+	}
+}
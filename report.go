@@ -0,0 +1,109 @@
+package smhi
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// reportParameter is the observation parameter of the report, which is the
+// air temperature of the latest hour.
+const reportParameter = 1
+
+// WeatherReport holds the forecast, the active warnings, the latest
+// observed air temperature at the nearest station and the sunrise and
+// sunset of today for a location.
+type WeatherReport struct {
+	Forecast        *PointForecast
+	Warnings        []Warning
+	Station         *Station
+	StationDistance float64
+	Observation     *Observation
+	Sunrise         time.Time
+	Sunset          time.Time
+}
+
+// GetWeatherReport fetches the weather report for the given longitude and
+// latitude using the default client.
+func GetWeatherReport(ctx context.Context, lon, lat float64) (*WeatherReport, error) {
+	return defaultClient.GetWeatherReport(ctx, lon, lat)
+}
+
+// GetWeatherReport fetches the forecast, the warnings and the latest
+// observation for the given longitude and latitude at the same time. The
+// first error that occurs is returned, and the rest of the requests are
+// canceled, or the error of the context if it's done before the report is
+// complete, in which case the requests are canceled as well.
+func (c *Client) GetWeatherReport(ctx context.Context, lon, lat float64) (*WeatherReport, error) {
+	ret := &WeatherReport{}
+	ret.Sunrise, ret.Sunset = SunTimes(time.Now(), lon, lat)
+
+	parent := ctx
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var mu sync.Mutex
+	var firstErr error
+	var wg sync.WaitGroup
+	fetch := func(f func() error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := f(); err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+					cancel()
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	fetch(func() (err error) {
+		ret.Forecast, err = c.GetPointForecastContext(ctx, lon, lat)
+		return err
+	})
+	fetch(func() (err error) {
+		ret.Warnings, err = c.GetWarningsAtContext(ctx, lon, lat)
+		return err
+	})
+	fetch(func() error {
+		var err error
+
+		var stations []Station
+		if stations, err = c.GetStationsContext(ctx, reportParameter); err != nil {
+			return err
+		}
+		if ret.Station, ret.StationDistance, err = nearestStation(FilterStations(stations, ActiveStations()), lon, lat); err != nil {
+			return err
+		}
+
+		var o *Observations
+		if o, err = c.GetObservationsContext(ctx, reportParameter, ret.Station.ID, PeriodLatestHour); err != nil {
+			return err
+		}
+		if len(o.Values) > 0 {
+			ret.Observation = &o.Values[len(o.Values)-1]
+		}
+
+		return nil
+	})
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-parent.Done():
+		return nil, parent.Err()
+	}
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,90 @@
+package smhi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestGetWeatherReportCancelsRequests(t *testing.T) {
+	// The forecast, the warnings and the stations are requested at once,
+	// and each request waits until it's canceled.
+	const requests = 3
+	started := make(chan struct{}, requests)
+	canceled := make(chan string, requests)
+	rt := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		started <- struct{}{}
+		select {
+		case <-req.Context().Done():
+			canceled <- req.URL.Host
+			return nil, req.Context().Err()
+		case <-time.After(5 * time.Second):
+			return jsonResponse(req, "{}"), nil
+		}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		for i := 0; i < requests; i++ {
+			<-started
+		}
+		cancel()
+	}()
+
+	c := NewClient(WithTransport(rt))
+	if _, err := c.GetWeatherReport(ctx, 18.0686, 59.3293); err != context.Canceled {
+		t.Fatalf("got error %v, want %v", err, context.Canceled)
+	}
+
+	for i := 0; i < requests; i++ {
+		select {
+		case <-canceled:
+		case <-time.After(time.Second):
+			t.Fatalf("%d of %d requests were canceled", i, requests)
+		}
+	}
+}
+
+func TestGetWeatherReportCancelsOnError(t *testing.T) {
+	// The forecast fails at once, while the warnings and the stations wait
+	// until they are canceled.
+	canceled := make(chan string, 2)
+	rt := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Host == "opendata-download-metfcst.smhi.se" {
+			return &http.Response{
+				StatusCode: http.StatusInternalServerError,
+				Status:     "500 Internal Server Error",
+				Body:       http.NoBody,
+				Request:    req,
+			}, nil
+		}
+
+		select {
+		case <-req.Context().Done():
+			canceled <- req.URL.Host
+			return nil, req.Context().Err()
+		case <-time.After(5 * time.Second):
+			return jsonResponse(req, "{}"), nil
+		}
+	})
+
+	c := NewClient(WithTransport(rt))
+	start := time.Now()
+	var apiErr *APIError
+	if _, err := c.GetWeatherReport(context.Background(), 18.0686, 59.3293); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusInternalServerError {
+		t.Fatalf("got error %v, want the error of the forecast", err)
+	}
+	if d := time.Since(start); d > time.Second {
+		t.Errorf("returned after %s", d)
+	}
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-canceled:
+		case <-time.After(time.Second):
+			t.Fatalf("%d of 2 requests were canceled", i)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package smhi
+
+import (
+	"io"
+	"io/ioutil"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy configures how the client retries the requests that fail
+// with network errors or transient responses.
+type RetryPolicy struct {
+	// MaxAttempts is the number of attempts including the first one, the
+	// requests aren't retried if it's less than two.
+	MaxAttempts int
+
+	// Backoff is the delay before the first retry, which is doubled for
+	// each retry after it, one second is used if it's zero.
+	Backoff time.Duration
+
+	// MaxBackoff is the longest delay, 30 seconds is used if it's zero.
+	MaxBackoff time.Duration
+
+	// Jitter is the fraction of the delay that is randomized, such as 0.2
+	// for ±20%, which spreads out the retries of concurrent clients.
+	Jitter float64
+
+	// RetryOn tells whether an attempt is retried, RetryTransient is used
+	// if it's nil. The response is nil when there is an error.
+	RetryOn func(res *http.Response, err error) bool
+}
+
+// DefaultRetryPolicy makes three attempts with half a second between the
+// first two.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	Backoff:     500 * time.Millisecond,
+	MaxBackoff:  10 * time.Second,
+	Jitter:      0.2,
+}
+
+// RetryTransient retries network errors, 429 Too Many Requests and the 5xx
+// responses.
+func RetryTransient(res *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= 500
+}
+
+// WithRetry makes the client retry the requests according to the policy,
+// such as
+//
+//	smhi.NewClient(smhi.WithRetry(smhi.DefaultRetryPolicy))
+//
+// The requests aren't retried once their context is done.
+func WithRetry(p RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = &p
+	}
+}
+
+// delay returns the delay after the given attempt, a Retry-After header in
+// seconds is honoured if it asks for a longer delay.
+func (p *RetryPolicy) delay(attempt int, res *http.Response) time.Duration {
+	backoff, max := p.Backoff, p.MaxBackoff
+	if backoff <= 0 {
+		backoff = time.Second
+	}
+	if max <= 0 {
+		max = 30 * time.Second
+	}
+
+	d := time.Duration(math.Min(float64(backoff)*math.Pow(2, float64(attempt-1)), float64(max)))
+	if p.Jitter > 0 {
+		d += time.Duration(float64(d) * p.Jitter * (2*rand.Float64() - 1))
+	}
+
+	if res != nil {
+		if s, err := strconv.Atoi(res.Header.Get("Retry-After")); err == nil {
+			if ra := time.Duration(s) * time.Second; ra > d {
+				d = ra
+			}
+		}
+	}
+	if d > max {
+		d = max
+	}
+
+	return d
+}
+
+// doWithRetry sends the request with the HTTP client and retries it
+// according to the retry policy of the client. The bodies of the retried
+// responses are drained and closed.
+func (c *Client) doWithRetry(hc *http.Client, req *http.Request) (*http.Response, error) {
+	attempts := 1
+	retryOn := RetryTransient
+	if c.retry != nil {
+		if c.retry.MaxAttempts > 1 {
+			attempts = c.retry.MaxAttempts
+		}
+		if c.retry.RetryOn != nil {
+			retryOn = c.retry.RetryOn
+		}
+	}
+
+	for attempt := 1; ; attempt++ {
+		if c.limiter != nil {
+			if err := c.limiter.wait(req.Context()); err != nil {
+				return nil, err
+			}
+		}
+
+		c.startMetrics(req)
+		start := time.Now()
+		res, err := hc.Do(req)
+		c.journalRequest(req, res, err, start)
+		c.doneMetrics(req, res, err, start)
+
+		if attempt >= attempts || req.Context().Err() != nil || !retryOn(res, err) {
+			return res, err
+		}
+
+		d := c.retry.delay(attempt, res)
+		if res != nil {
+			io.Copy(ioutil.Discard, res.Body)
+			res.Body.Close()
+		}
+
+		t := time.NewTimer(d)
+		select {
+		case <-req.Context().Done():
+			t.Stop()
+			return nil, req.Context().Err()
+		case <-t.C:
+		}
+	}
+}
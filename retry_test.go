@@ -0,0 +1,141 @@
+package smhi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// failingServer returns a test server that responds with the status to
+// the first failures requests and with the forecast after them, along
+// with the number of requests that it has served.
+func failingServer(status, failures int) (*httptest.Server, *int64) {
+	var n int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt64(&n, 1) <= int64(failures) {
+			w.WriteHeader(status)
+			return
+		}
+		w.Write([]byte(testForecastJSON))
+	}))
+	return srv, &n
+}
+
+func TestRetry(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond}
+
+	tests := []struct {
+		name     string
+		status   int
+		failures int
+		attempts int64
+		ok       bool
+	}{
+		{"success", http.StatusOK, 0, 1, true},
+		{"transient failures", http.StatusServiceUnavailable, 2, 3, true},
+		{"too many requests", http.StatusTooManyRequests, 1, 2, true},
+		{"gives up", http.StatusInternalServerError, 3, 3, false},
+		{"client error", http.StatusBadRequest, 1, 1, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, n := failingServer(tt.status, tt.failures)
+			defer srv.Close()
+
+			c := NewClient(WithBaseURL(srv.URL), WithRetry(policy))
+			_, err := c.GetPointForecast(18.0686, 59.3293)
+			if tt.ok && err != nil {
+				t.Fatal(err)
+			}
+			if !tt.ok {
+				var apiErr *APIError
+				if !errors.As(err, &apiErr) || apiErr.StatusCode != tt.status {
+					t.Fatalf("got error %v, want an APIError with status %d", err, tt.status)
+				}
+			}
+			if got := atomic.LoadInt64(n); got != tt.attempts {
+				t.Errorf("got %d attempts, want %d", got, tt.attempts)
+			}
+		})
+	}
+}
+
+func TestRetryWithoutPolicy(t *testing.T) {
+	srv, n := failingServer(http.StatusServiceUnavailable, 1)
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL))
+	if _, err := c.GetPointForecast(18.0686, 59.3293); err == nil {
+		t.Fatal("got no error")
+	}
+	if got := atomic.LoadInt64(n); got != 1 {
+		t.Errorf("got %d attempts, want 1", got)
+	}
+}
+
+func TestRetryStopsWhenContextIsDone(t *testing.T) {
+	srv, n := failingServer(http.StatusServiceUnavailable, 10)
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	c := NewClient(WithBaseURL(srv.URL), WithRetry(RetryPolicy{MaxAttempts: 10, Backoff: time.Second}))
+	start := time.Now()
+	if _, err := c.GetPointForecastContext(ctx, 18.0686, 59.3293); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got error %v, want %v", err, context.DeadlineExceeded)
+	}
+	if d := time.Since(start); d > 500*time.Millisecond {
+		t.Errorf("returned after %s", d)
+	}
+	if got := atomic.LoadInt64(n); got != 1 {
+		t.Errorf("got %d attempts, want 1", got)
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	retryAfter := func(s int) *http.Response {
+		return &http.Response{Header: http.Header{"Retry-After": {strconv.Itoa(s)}}}
+	}
+
+	tests := []struct {
+		name    string
+		policy  RetryPolicy
+		attempt int
+		res     *http.Response
+		want    time.Duration
+	}{
+		{"first", RetryPolicy{Backoff: 100 * time.Millisecond}, 1, nil, 100 * time.Millisecond},
+		{"doubled", RetryPolicy{Backoff: 100 * time.Millisecond}, 2, nil, 200 * time.Millisecond},
+		{"doubled twice", RetryPolicy{Backoff: 100 * time.Millisecond}, 3, nil, 400 * time.Millisecond},
+		{"capped", RetryPolicy{Backoff: 100 * time.Millisecond, MaxBackoff: 300 * time.Millisecond}, 3, nil, 300 * time.Millisecond},
+		{"default backoff", RetryPolicy{}, 1, nil, time.Second},
+		{"default max backoff", RetryPolicy{}, 10, nil, 30 * time.Second},
+		{"retry after", RetryPolicy{Backoff: 100 * time.Millisecond}, 1, retryAfter(2), 2 * time.Second},
+		{"shorter retry after", RetryPolicy{Backoff: 3 * time.Second}, 1, retryAfter(1), 3 * time.Second},
+		{"capped retry after", RetryPolicy{Backoff: 100 * time.Millisecond, MaxBackoff: time.Second}, 1, retryAfter(60), time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.delay(tt.attempt, tt.res); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryDelayJitter(t *testing.T) {
+	p := RetryPolicy{Backoff: time.Second, Jitter: 0.2}
+	for i := 0; i < 100; i++ {
+		if d := p.delay(1, nil); d < 800*time.Millisecond || d > 1200*time.Millisecond {
+			t.Fatalf("got %s, want within 20%% of 1s", d)
+		}
+	}
+}
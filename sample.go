@@ -0,0 +1,115 @@
+package smhi
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// maxSamples is the largest number of points of a sampled grid.
+const maxSamples = 10000
+
+// ErrTooManySamples is returned when a sampled grid would have more than
+// 10000 points.
+var ErrTooManySamples = errors.New("smhi: too many sample points")
+
+// ForecastGrid is a regular grid of point forecasts, with Cols points from
+// west to east and Rows points from south to north, Step degrees apart.
+type ForecastGrid struct {
+	MinLon float64
+	MinLat float64
+	Step   float64
+	Cols   int
+	Rows   int
+
+	// Forecasts holds the forecasts by row from the south west corner,
+	// the forecasts that couldn't be fetched, such as the ones outside of
+	// the SMHI grid, are nil.
+	Forecasts []*PointForecast
+}
+
+// Sample fetches the forecasts of a grid of points over the bounding box,
+// Step degrees apart in both directions. The forecasts of the points that
+// fail are left as nil, only the error of the context is returned.
+func (b *Batch) Sample(ctx context.Context, minLon, minLat, maxLon, maxLat, step float64) (*ForecastGrid, error) {
+	if step <= 0 || maxLon < minLon || maxLat < minLat {
+		return nil, errors.New("smhi: invalid bounding box or step")
+	}
+
+	g := &ForecastGrid{
+		MinLon: minLon,
+		MinLat: minLat,
+		Step:   step,
+		Cols:   int(math.Floor((maxLon-minLon)/step+1e-9)) + 1,
+		Rows:   int(math.Floor((maxLat-minLat)/step+1e-9)) + 1,
+	}
+	if g.Cols*g.Rows > maxSamples {
+		return nil, ErrTooManySamples
+	}
+	g.Forecasts = make([]*PointForecast, g.Cols*g.Rows)
+
+	var points []BatchPoint
+	for i := range g.Forecasts {
+		lon, lat := g.Point(i%g.Cols, i/g.Cols)
+		points = append(points, BatchPoint{ID: strconv.Itoa(i), Lon: lon, Lat: lat})
+	}
+
+	var mu sync.Mutex
+	err := b.Fetch(ctx, points, func(r BatchResult) {
+		if r.Err != nil {
+			return
+		}
+
+		i, _ := strconv.Atoi(r.Point.ID)
+		mu.Lock()
+		g.Forecasts[i] = r.Forecast
+		mu.Unlock()
+	})
+
+	return g, err
+}
+
+// Point returns the longitude and latitude of the point at the column and
+// row.
+func (g *ForecastGrid) Point(col, row int) (lon, lat float64) {
+	return g.MinLon + float64(col)*g.Step, g.MinLat + float64(row)*g.Step
+}
+
+// At returns the forecast of the point at the column and row.
+func (g *ForecastGrid) At(col, row int) *PointForecast {
+	if col < 0 || col >= g.Cols || row < 0 || row >= g.Rows {
+		return nil
+	}
+	return g.Forecasts[row*g.Cols+col]
+}
+
+// Values returns the values of the SMHI parameter at the time by row and
+// column, such as for a heat map. The time step of a point is the last one
+// at or before the time, the points without one are NaN.
+func (g *ForecastGrid) Values(name string, t time.Time) [][]float64 {
+	ret := make([][]float64, g.Rows)
+	for row := range ret {
+		ret[row] = make([]float64, g.Cols)
+		for col := range ret[row] {
+			ret[row][col] = math.NaN()
+
+			pf := g.At(col, row)
+			if pf == nil {
+				continue
+			}
+			for i := range pf.TimeSeries {
+				if pf.TimeSeries[i].Timestamp.After(t) {
+					break
+				}
+				if v, ok := pf.TimeSeries[i].Value(name); ok {
+					ret[row][col] = v
+				}
+			}
+		}
+	}
+
+	return ret
+}
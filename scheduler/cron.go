@@ -0,0 +1,162 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule returns the next time to run after the given time.
+type Schedule interface {
+	Next(t time.Time) time.Time
+}
+
+// every is a schedule with a fixed interval.
+type every time.Duration
+
+// Every returns a schedule that runs at the given interval.
+func Every(d time.Duration) Schedule {
+	return every(d)
+}
+
+// Next returns the time one interval after t.
+func (e every) Next(t time.Time) time.Time {
+	return t.Add(time.Duration(e))
+}
+
+// cron is a schedule of a cron expression, each field holds the values
+// that match.
+type cron struct {
+	minute, hour, dom, month, dow map[int]bool
+	anyDom, anyDow                bool
+}
+
+// cronFields holds the ranges of the fields of cron expressions.
+var cronFields = []struct {
+	min, max int
+}{{0, 59}, {0, 23}, {1, 31}, {1, 12}, {0, 6}}
+
+// Cron parses a cron expression of the five fields minute, hour, day of
+// month, month and day of week, which may be "*", numbers, ranges such as
+// "1-5", lists such as "0,30" and steps such as "*/15". The times are
+// matched in the location of the time that is given to Next.
+func Cron(expr string) (Schedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != len(cronFields) {
+		return nil, fmt.Errorf("scheduler: cron expression %q doesn't have five fields", expr)
+	}
+
+	var sets []map[int]bool
+	for i, f := range fields {
+		set, err := parseCronField(f, cronFields[i].min, cronFields[i].max)
+		if err != nil {
+			return nil, fmt.Errorf("scheduler: invalid cron expression %q: %w", expr, err)
+		}
+		sets = append(sets, set)
+	}
+
+	// Sunday is also given as 7.
+	if sets[4][7] {
+		sets[4][0] = true
+	}
+
+	return &cron{
+		minute: sets[0],
+		hour:   sets[1],
+		dom:    sets[2],
+		month:  sets[3],
+		dow:    sets[4],
+		anyDom: fields[2] == "*",
+		anyDow: fields[4] == "*",
+	}, nil
+}
+
+// parseCronField parses a field of a cron expression.
+func parseCronField(field string, min, max int) (map[int]bool, error) {
+	ret := make(map[int]bool)
+
+	// Sunday may be given as 7 in the day of week field.
+	if max == 6 {
+		max = 7
+	}
+
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+			if step, err = strconv.Atoi(part[i+1:]); err != nil || step < 1 {
+				return nil, fmt.Errorf("invalid step %q", part)
+			}
+			part = part[:i]
+		}
+
+		lo, hi := min, max
+		if part != "*" {
+			var err error
+			if i := strings.Index(part, "-"); i >= 0 {
+				if lo, err = strconv.Atoi(part[:i]); err != nil {
+					return nil, fmt.Errorf("invalid range %q", part)
+				}
+				if hi, err = strconv.Atoi(part[i+1:]); err != nil {
+					return nil, fmt.Errorf("invalid range %q", part)
+				}
+			} else {
+				if lo, err = strconv.Atoi(part); err != nil {
+					return nil, fmt.Errorf("invalid value %q", part)
+				}
+				hi = lo
+			}
+		}
+		if lo < min || hi > max || lo > hi {
+			return nil, fmt.Errorf("%q is out of range", part)
+		}
+
+		for v := lo; v <= hi; v += step {
+			ret[v] = true
+		}
+	}
+
+	return ret, nil
+}
+
+// Next returns the first minute after t that matches the expression, or
+// the zero time if there is none within five years.
+func (c *cron) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	end := t.AddDate(5, 0, 0)
+
+	for t.Before(end) {
+		if !c.month[int(t.Month())] || !c.day(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !c.hour[t.Hour()] {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if !c.minute[t.Minute()] {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+
+	return time.Time{}
+}
+
+// day returns true if the day matches, either day field matches when both
+// are restricted.
+func (c *cron) day(t time.Time) bool {
+	dom, dow := c.dom[t.Day()], c.dow[int(t.Weekday())]
+
+	switch {
+	case c.anyDom && c.anyDow:
+		return true
+	case c.anyDom:
+		return dow
+	case c.anyDow:
+		return dom
+	}
+	return dom || dow
+}
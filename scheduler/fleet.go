@@ -0,0 +1,170 @@
+package scheduler
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/osm/smhi"
+)
+
+// DefaultCycle is the update cycle of the SMHI forecasts, which are
+// approved about once an hour.
+const DefaultCycle = time.Hour
+
+// Location is a location of a fleet, Callback is called with the forecast
+// or the error of each refresh. The locations with a higher priority are
+// refreshed first in each cycle.
+type Location struct {
+	Name     string
+	Lon      float64
+	Lat      float64
+	Priority int
+	Callback func(loc *Location, pf *smhi.PointForecast, err error)
+}
+
+// Fleet refreshes the forecasts of many locations once per update cycle,
+// with the requests spread evenly across the cycle to stay within a
+// budget. The cycles follow the approved time of the forecasts, a new
+// cycle isn't started until a cycle has passed since the latest approved
+// time.
+type Fleet struct {
+	// Client is used to fetch the forecasts, a new client is used if
+	// it's nil.
+	Client *smhi.Client
+
+	// Cycle is the update cycle of the forecasts, DefaultCycle is used if
+	// it's zero.
+	Cycle time.Duration
+
+	// Budget is the most requests of a cycle, all the locations are
+	// refreshed in each cycle if it's zero. The locations with the
+	// highest priority are refreshed when the budget doesn't cover all of
+	// them, and those that have waited the longest among equals.
+	Budget int
+
+	mu        sync.Mutex
+	locations []*fleetLocation
+	wake      chan struct{}
+}
+
+// fleetLocation is a location along with the time of its last refresh.
+type fleetLocation struct {
+	loc       *Location
+	refreshed time.Time
+}
+
+// Add adds the location to the fleet, it's refreshed from the next cycle.
+// Locations can be added while the fleet is running.
+func (f *Fleet) Add(loc *Location) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.locations = append(f.locations, &fleetLocation{loc: loc})
+	if f.wake != nil {
+		select {
+		case f.wake <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Run refreshes the locations until the context is done. The callbacks
+// are called from Run, so a slow callback delays the requests after it.
+func (f *Fleet) Run(ctx context.Context) error {
+	c := f.Client
+	if c == nil {
+		c = smhi.NewClient()
+	}
+	cycle := f.Cycle
+	if cycle <= 0 {
+		cycle = DefaultCycle
+	}
+
+	f.mu.Lock()
+	f.wake = make(chan struct{}, 1)
+	f.mu.Unlock()
+
+	var approved time.Time
+	for {
+		// Wait for a location to be added if there are none.
+		plan := f.plan()
+		if len(plan) == 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-f.wake:
+				continue
+			}
+		}
+
+		// The forecasts aren't updated until a cycle after they were
+		// approved, so there is no use in starting the cycle before.
+		start := time.Now()
+		if next := approved.Add(cycle); next.After(start) {
+			start = next
+		}
+
+		step := cycle / time.Duration(len(plan))
+		for i, fl := range plan {
+			if err := sleepUntil(ctx, start.Add(time.Duration(i)*step)); err != nil {
+				return err
+			}
+
+			pf, err := c.GetPointForecastContext(ctx, fl.loc.Lon, fl.loc.Lat)
+			if err == nil && pf.ApprovedTime.After(approved) {
+				approved = pf.ApprovedTime
+			}
+			if fl.loc.Callback != nil {
+				fl.loc.Callback(fl.loc, pf, err)
+			}
+
+			f.mu.Lock()
+			fl.refreshed = time.Now()
+			f.mu.Unlock()
+		}
+
+		// Wait for the rest of the cycle before the next one.
+		if err := sleepUntil(ctx, start.Add(cycle)); err != nil {
+			return err
+		}
+	}
+}
+
+// plan returns the locations to refresh in the next cycle, in order of
+// priority and then of the time since their last refresh.
+func (f *Fleet) plan() []*fleetLocation {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	ret := append([]*fleetLocation(nil), f.locations...)
+	sort.SliceStable(ret, func(i, j int) bool {
+		if ret[i].loc.Priority != ret[j].loc.Priority {
+			return ret[i].loc.Priority > ret[j].loc.Priority
+		}
+		return ret[i].refreshed.Before(ret[j].refreshed)
+	})
+	if f.Budget > 0 && len(ret) > f.Budget {
+		ret = ret[:f.Budget]
+	}
+	return ret
+}
+
+// sleepUntil waits until the time or until the context is done.
+func sleepUntil(ctx context.Context, t time.Time) error {
+	d := time.Until(t)
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
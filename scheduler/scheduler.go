@@ -0,0 +1,120 @@
+// Package scheduler refreshes the SMHI point forecasts of a set of
+// locations on schedules, with a shared limit on the rate of the requests.
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/osm/smhi"
+)
+
+// Job refreshes the forecast of a location on a schedule, Callback is
+// called with the forecast or the error of each refresh.
+type Job struct {
+	Name     string
+	Lon      float64
+	Lat      float64
+	Schedule Schedule
+	Callback func(job *Job, pf *smhi.PointForecast, err error)
+}
+
+// Scheduler runs the jobs when they are due, one request at a time.
+type Scheduler struct {
+	// Client is used to fetch the forecasts, a new client is used if
+	// it's nil.
+	Client *smhi.Client
+
+	// Interval is the least time between the start of two requests, the
+	// requests aren't limited if it's zero.
+	Interval time.Duration
+
+	mu   sync.Mutex
+	jobs []*scheduledJob
+	wake chan struct{}
+}
+
+// scheduledJob is a job along with the next time it's due.
+type scheduledJob struct {
+	job  *Job
+	next time.Time
+}
+
+// Add adds the job to the scheduler, it's first run at the first time of
+// its schedule. Jobs can be added while the scheduler is running.
+func (s *Scheduler) Add(job *Job) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.jobs = append(s.jobs, &scheduledJob{job, job.Schedule.Next(time.Now())})
+	if s.wake != nil {
+		select {
+		case s.wake <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Run runs the jobs until the context is done. The callbacks are called
+// from Run, so a slow callback delays the jobs after it.
+func (s *Scheduler) Run(ctx context.Context) error {
+	c := s.Client
+	if c == nil {
+		c = smhi.NewClient()
+	}
+
+	s.mu.Lock()
+	s.wake = make(chan struct{}, 1)
+	s.mu.Unlock()
+
+	var last time.Time
+	for {
+		// Wait for the job that is due first, or for a new job.
+		sj := s.due()
+		var timer <-chan time.Time
+		if sj != nil {
+			at := sj.next
+			if s.Interval > 0 && at.Before(last.Add(s.Interval)) {
+				at = last.Add(s.Interval)
+			}
+			timer = time.After(time.Until(at))
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-s.wake:
+			continue
+		case <-timer:
+		}
+
+		last = time.Now()
+		pf, err := c.GetPointForecastContext(ctx, sj.job.Lon, sj.job.Lat)
+		if sj.job.Callback != nil {
+			sj.job.Callback(sj.job, pf, err)
+		}
+
+		s.mu.Lock()
+		sj.next = sj.job.Schedule.Next(time.Now())
+		s.mu.Unlock()
+	}
+}
+
+// due returns the job that is due first, jobs without a next time are
+// never run.
+func (s *Scheduler) due() *scheduledJob {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var ret *scheduledJob
+	for _, sj := range s.jobs {
+		if sj.next.IsZero() {
+			continue
+		}
+		if ret == nil || sj.next.Before(ret.next) {
+			ret = sj
+		}
+	}
+	return ret
+}
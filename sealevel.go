@@ -0,0 +1,212 @@
+package smhi
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const (
+	ocobsURL  = "https://opendata-download-ocobs.smhi.se/api/version/latest"
+	ocfcstURL = "https://opendata-download-ocfcst.smhi.se/api/version/latest"
+)
+
+// Parameters of the oceanographic APIs.
+const (
+	OceanSeaTemperature = 5
+	OceanSeaLevel       = 6
+)
+
+// SeaLevel holds the sea level in cm relative to the mean sea level.
+type SeaLevel struct {
+	Timestamp time.Time
+	Level     float64
+}
+
+// toSeaLevels converts the observations to sea levels.
+func toSeaLevels(o *Observations) []SeaLevel {
+	ret := make([]SeaLevel, 0, len(o.Values))
+	for _, v := range o.Values {
+		ret = append(ret, SeaLevel{Timestamp: v.Timestamp, Level: v.Value})
+	}
+	return ret
+}
+
+// GetSeaLevelStations fetches the coastal stations that have sea level
+// forecasts using the default client.
+func GetSeaLevelStations() ([]Station, error) {
+	return defaultClient.GetSeaLevelStations()
+}
+
+// GetSeaLevelStations fetches the coastal stations that have sea level
+// forecasts.
+func (c *Client) GetSeaLevelStations() ([]Station, error) {
+	var err error
+
+	var decodedData *ParameterAPI
+	if decodedData, err = c.getParameter(context.Background(), ocfcstURL, OceanSeaLevel); err != nil {
+		return nil, err
+	}
+
+	return toStations(decodedData), nil
+}
+
+// NearestSeaLevelStation returns the sea level station that is nearest to
+// the given longitude and latitude, along with its distance in km, using
+// the default client.
+func NearestSeaLevelStation(lon, lat float64) (*Station, float64, error) {
+	return defaultClient.NearestSeaLevelStation(lon, lat)
+}
+
+// NearestSeaLevelStation returns the sea level station that is nearest to
+// the given longitude and latitude, along with its distance in km.
+func (c *Client) NearestSeaLevelStation(lon, lat float64) (*Station, float64, error) {
+	var err error
+
+	var stations []Station
+	if stations, err = c.GetSeaLevelStations(); err != nil {
+		return nil, 0, err
+	}
+
+	return nearestStation(stations, lon, lat)
+}
+
+// GetSeaLevelForecast fetches the sea level forecast of the station using
+// the default client.
+func GetSeaLevelForecast(station int) ([]SeaLevel, error) {
+	return defaultClient.GetSeaLevelForecast(station)
+}
+
+// GetSeaLevelForecast fetches the sea level forecast of the station.
+func (c *Client) GetSeaLevelForecast(station int) ([]SeaLevel, error) {
+	var err error
+
+	var o *Observations
+	if o, err = c.getObservations(context.Background(), ocfcstURL, OceanSeaLevel, station, PeriodLatestDay); err != nil {
+		return nil, err
+	}
+
+	return toSeaLevels(o), nil
+}
+
+// GetSeaLevelObservations fetches the observed sea levels of the station
+// for the given period using the default client.
+func GetSeaLevelObservations(station int, period ObservationPeriod) ([]SeaLevel, error) {
+	return defaultClient.GetSeaLevelObservations(station, period)
+}
+
+// GetSeaLevelObservations fetches the observed sea levels of the station
+// for the given period.
+func (c *Client) GetSeaLevelObservations(station int, period ObservationPeriod) ([]SeaLevel, error) {
+	var err error
+
+	var o *Observations
+	if o, err = c.getObservations(context.Background(), ocobsURL, OceanSeaLevel, station, period); err != nil {
+		return nil, err
+	}
+
+	return toSeaLevels(o), nil
+}
+
+// SeaLevelSummary summarizes the sea levels of a period, Trend is the
+// change in cm per hour.
+type SeaLevelSummary struct {
+	From        time.Time
+	To          time.Time
+	Min         SeaLevel
+	Max         SeaLevel
+	Trend       float64
+	Description map[string]string
+}
+
+// SummarizeSeaLevels summarizes the sea levels from the given time and the
+// duration of the horizon ahead, such as 24 or 48 hours, with their
+// expected extremes and trend.
+func SummarizeSeaLevels(levels []SeaLevel, from time.Time, horizon time.Duration) (*SeaLevelSummary, error) {
+	to := from.Add(horizon)
+	ret := &SeaLevelSummary{From: from, To: to}
+
+	var ts []time.Time
+	var vs []float64
+	for _, l := range levels {
+		if l.Timestamp.Before(from) || l.Timestamp.After(to) {
+			continue
+		}
+
+		if len(vs) == 0 || l.Level < ret.Min.Level {
+			ret.Min = l
+		}
+		if len(vs) == 0 || l.Level > ret.Max.Level {
+			ret.Max = l
+		}
+		ts, vs = append(ts, l.Timestamp), append(vs, l.Level)
+	}
+	if len(vs) == 0 {
+		return nil, ErrNoObservations
+	}
+	ret.Trend = hourlyTrend(ts, vs)
+	ret.Description = getSeaLevelSummaryDescription(ret)
+
+	return ret, nil
+}
+
+// GetSeaLevelSummary fetches the sea level forecast of the station and
+// summarizes the given duration ahead from now using the default client.
+func GetSeaLevelSummary(station int, horizon time.Duration) (*SeaLevelSummary, error) {
+	return defaultClient.GetSeaLevelSummary(station, horizon)
+}
+
+// GetSeaLevelSummary fetches the sea level forecast of the station and
+// summarizes the given duration ahead from now.
+func (c *Client) GetSeaLevelSummary(station int, horizon time.Duration) (*SeaLevelSummary, error) {
+	var err error
+
+	var levels []SeaLevel
+	if levels, err = c.GetSeaLevelForecast(station); err != nil {
+		return nil, err
+	}
+
+	return SummarizeSeaLevels(levels, time.Now(), horizon)
+}
+
+// getSeaLevelSummaryDescription returns a friendly description of the sea
+// level summary, trends below half a cm per hour are considered steady.
+func getSeaLevelSummaryDescription(s *SeaLevelSummary) map[string]string {
+	ret := make(map[string]string)
+
+	if s.Trend >= 0.5 {
+		ret["sv-SE"] = "Stigande vattenstånd"
+		ret["en-US"] = "Rising sea level"
+	} else if s.Trend <= -0.5 {
+		ret["sv-SE"] = "Sjunkande vattenstånd"
+		ret["en-US"] = "Falling sea level"
+	} else {
+		ret["sv-SE"] = "Oförändrat vattenstånd"
+		ret["en-US"] = "Steady sea level"
+	}
+
+	ret["sv-SE"] += fmt.Sprintf(", högst %+.0f cm och lägst %+.0f cm", s.Max.Level, s.Min.Level)
+	ret["en-US"] += fmt.Sprintf(", highest %+.0f cm and lowest %+.0f cm", s.Max.Level, s.Min.Level)
+
+	return ret
+}
+
+// hourlyTrend returns the slope per hour of the least squares line through
+// the values at the given times.
+func hourlyTrend(ts []time.Time, vs []float64) float64 {
+	var n, sx, sy, sxx, sxy float64
+	for i, t := range ts {
+		x := t.Sub(ts[0]).Hours()
+		n++
+		sx += x
+		sy += vs[i]
+		sxx += x * x
+		sxy += x * vs[i]
+	}
+
+	d := n*sxx - sx*sx
+	if d == 0 {
+		return 0
+	}
+	return (n*sxy - sx*sy) / d
+}
@@ -0,0 +1,148 @@
+package smhi
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// swedishWeekdays and swedishMonths hold the Swedish names of the days of
+// the week and the months of the year.
+var (
+	swedishWeekdays = []string{"söndag", "måndag", "tisdag", "onsdag", "torsdag", "fredag", "lördag"}
+	swedishMonths   = []string{"januari", "februari", "mars", "april", "maj", "juni", "juli", "augusti", "september", "oktober", "november", "december"}
+)
+
+// Spoken returns the summary as plain sentences that read well when they
+// are spoken, in the "sv-SE" or the "en-US" locale. Other locales fall back
+// to "en-US".
+func (s Summary) Spoken(locale string) string {
+	return strings.Join(s.sentences(locale), " ")
+}
+
+// SSML returns the summary as an SSML document with one sentence element
+// per sentence of Spoken.
+func (s Summary) SSML(locale string) string {
+	if locale != "sv-SE" {
+		locale = "en-US"
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, `<speak version="1.0" xmlns="http://www.w3.org/2001/10/synthesis" xml:lang="%s">`, locale)
+	for _, sentence := range s.sentences(locale) {
+		b.WriteString("<s>")
+		xml.EscapeText(&b, []byte(sentence))
+		b.WriteString("</s>")
+	}
+	b.WriteString("</speak>")
+
+	return b.String()
+}
+
+// sentences returns the sentences of the summary in the locale.
+func (s Summary) sentences(locale string) []string {
+	sv := locale == "sv-SE"
+	if !sv {
+		locale = "en-US"
+	}
+
+	var ret []string
+
+	// The day and the weather.
+	if sv {
+		ret = append(ret, fmt.Sprintf("%s %d %s.", capitalize(swedishWeekdays[s.From.Weekday()]), s.From.Day(), swedishMonths[s.From.Month()-1]))
+	} else {
+		ret = append(ret, s.From.Format("Monday, January 2."))
+	}
+	if d := getWeatherSymbolDescription(s.WeatherSymbol)[locale]; d != "" {
+		ret = append(ret, d+".")
+	}
+
+	// The temperatures in whole degrees.
+	min, max := spokenNumber(s.MinAirTemperature, 0, sv), spokenNumber(s.MaxAirTemperature, 0, sv)
+	if sv {
+		if min == max {
+			ret = append(ret, fmt.Sprintf("Temperatur %s grader.", max))
+		} else {
+			ret = append(ret, fmt.Sprintf("Temperatur mellan %s och %s grader.", min, max))
+		}
+	} else {
+		if min == max {
+			ret = append(ret, fmt.Sprintf("Temperature %s degrees.", max))
+		} else {
+			ret = append(ret, fmt.Sprintf("Temperatures between %s and %s degrees.", min, max))
+		}
+	}
+
+	// The wind and the gusts when they are notably stronger.
+	wind, gust := spokenNumber(s.MaxWindSpeed, 0, sv), spokenNumber(s.MaxWindGustSpeed, 0, sv)
+	withGusts := s.MaxWindGustSpeed-s.MaxWindSpeed >= 3
+	if sv {
+		if withGusts {
+			ret = append(ret, fmt.Sprintf("Vind upp till %s meter per sekund, i byarna upp till %s.", wind, gust))
+		} else {
+			ret = append(ret, fmt.Sprintf("Vind upp till %s meter per sekund.", wind))
+		}
+	} else {
+		if withGusts {
+			ret = append(ret, fmt.Sprintf("Wind up to %s meters per second, with gusts up to %s.", wind, gust))
+		} else {
+			ret = append(ret, fmt.Sprintf("Wind up to %s meters per second.", wind))
+		}
+	}
+
+	// The precipitation, with one decimal below 10 mm.
+	if s.Precipitation >= 0.1 {
+		decimals := 1
+		if s.Precipitation >= 10 {
+			decimals = 0
+		}
+		p := spokenNumber(s.Precipitation, decimals, sv)
+		if sv {
+			ret = append(ret, fmt.Sprintf("Cirka %s millimeter nederbörd.", p))
+		} else {
+			ret = append(ret, fmt.Sprintf("About %s millimeters of precipitation.", p))
+		}
+	}
+
+	if s.MaxThunderProbability >= 10 {
+		if sv {
+			ret = append(ret, fmt.Sprintf("Risk för åska %d procent.", s.MaxThunderProbability))
+		} else {
+			ret = append(ret, fmt.Sprintf("Risk of thunder %d percent.", s.MaxThunderProbability))
+		}
+	}
+
+	return ret
+}
+
+// spokenNumber formats the number with the given decimals so that it's
+// read out as a number, with the word minus for the negative numbers and a
+// decimal comma in Swedish.
+func spokenNumber(v float64, decimals int, sv bool) string {
+	scale := math.Pow(10, float64(decimals))
+	v = math.Round(v*scale) / scale
+
+	s := strconv.FormatFloat(math.Abs(v), 'f', -1, 64)
+	if sv {
+		s = strings.Replace(s, ".", ",", 1)
+	}
+
+	if v < 0 {
+		return "minus " + s
+	}
+	return s
+}
+
+// capitalize returns the string with its first letter in upper case.
+func capitalize(s string) string {
+	for i := range s {
+		if i > 0 {
+			return strings.ToUpper(s[:i]) + s[i:]
+		}
+	}
+	return strings.ToUpper(s)
+}
@@ -0,0 +1,300 @@
+package smhi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// DefaultStationTTL is the time a station list is cached unless another
+// TTL is given to NewStationDirectory.
+const DefaultStationTTL = 24 * time.Hour
+
+// ErrNoStation is returned when no station is found.
+var ErrNoStation = errors.New("smhi: no station found")
+
+// StationDirectory caches the station lists of the observation parameters
+// in memory and optionally on disk, the lists are refreshed from the API
+// when they are older than the TTL. The lists of different parameters are
+// fetched independently of each other.
+type StationDirectory struct {
+	client  *Client
+	base    string
+	name    string
+	ttl     time.Duration
+	dir     string
+	mu      sync.Mutex
+	entries map[int]*stationEntry
+	fetches map[int]*sync.Mutex
+}
+
+// stationEntry is a cached station list.
+type stationEntry struct {
+	Fetched  time.Time
+	Stations []Station
+}
+
+// NewStationDirectory returns a station directory that fetches the station
+// lists with the given client and caches them for ttl. The lists are also
+// cached in dir if it isn't empty, which lets them survive restarts.
+func NewStationDirectory(c *Client, ttl time.Duration, dir string) *StationDirectory {
+	return newStationDirectory(c, metobsURL, "stations", ttl, dir)
+}
+
+// newStationDirectory returns a station directory for the API at base, the
+// disk cache files are prefixed by name.
+func newStationDirectory(c *Client, base, name string, ttl time.Duration, dir string) *StationDirectory {
+	if c == nil {
+		c = defaultClient
+	}
+	if ttl <= 0 {
+		ttl = DefaultStationTTL
+	}
+
+	return &StationDirectory{
+		client:  c,
+		base:    base,
+		name:    name,
+		ttl:     ttl,
+		dir:     dir,
+		entries: make(map[int]*stationEntry),
+		fetches: make(map[int]*sync.Mutex),
+	}
+}
+
+// Stations returns the stations that observe the given parameter and
+// match all of the filters.
+func (d *StationDirectory) Stations(parameter int, filters ...StationFilter) ([]Station, error) {
+	var err error
+
+	var stations []Station
+	if stations, err = d.stations(parameter); err != nil {
+		return nil, err
+	}
+
+	return FilterStations(stations, filters...), nil
+}
+
+// stations returns the cached station list of the parameter.
+func (d *StationDirectory) stations(parameter int) ([]Station, error) {
+	if e := d.fresh(parameter); e != nil {
+		return e.Stations, nil
+	}
+
+	// Only one list of the parameter is fetched at a time, the calls that
+	// wait for it use the list that it fetched.
+	defer d.lock(parameter)()
+	if e := d.fresh(parameter); e != nil {
+		return e.Stations, nil
+	}
+
+	// Fall back to the disk cache before the API.
+	if e, err := d.load(parameter); err == nil && time.Since(e.Fetched) < d.ttl {
+		d.mu.Lock()
+		d.entries[parameter] = e
+		d.mu.Unlock()
+		return e.Stations, nil
+	}
+
+	e, err := d.refresh(parameter)
+	if err != nil {
+		return nil, err
+	}
+	return e.Stations, nil
+}
+
+// fresh returns the in-memory list of the parameter if it's younger than
+// the TTL, nil is returned otherwise.
+func (d *StationDirectory) fresh(parameter int) *stationEntry {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if e, ok := d.entries[parameter]; ok && time.Since(e.Fetched) < d.ttl {
+		return e
+	}
+	return nil
+}
+
+// lock locks the fetches of the parameter and returns the function that
+// unlocks them.
+func (d *StationDirectory) lock(parameter int) func() {
+	d.mu.Lock()
+	l, ok := d.fetches[parameter]
+	if !ok {
+		l = &sync.Mutex{}
+		d.fetches[parameter] = l
+	}
+	d.mu.Unlock()
+
+	l.Lock()
+	return l.Unlock
+}
+
+// Refresh fetches the station list of the parameter from the API
+// regardless of the age of the cached list.
+func (d *StationDirectory) Refresh(parameter int) error {
+	defer d.lock(parameter)()
+
+	_, err := d.refresh(parameter)
+	return err
+}
+
+// refresh fetches the station list of the parameter and stores it in the
+// caches, the fetches of the parameter must be locked. The list is used
+// even if it can't be written to disk, since the disk cache only saves a
+// request after a restart.
+func (d *StationDirectory) refresh(parameter int) (*stationEntry, error) {
+	var err error
+
+	var decodedData *ParameterAPI
+	if decodedData, err = d.client.getParameter(context.Background(), d.base, parameter); err != nil {
+		return nil, err
+	}
+
+	e := &stationEntry{Fetched: time.Now(), Stations: toStations(decodedData)}
+	d.mu.Lock()
+	d.entries[parameter] = e
+	d.mu.Unlock()
+
+	d.store(parameter, e)
+	return e, nil
+}
+
+// path returns the path of the disk cache for the parameter.
+func (d *StationDirectory) path(parameter int) string {
+	return filepath.Join(d.dir, fmt.Sprintf("%s-%d.json", d.name, parameter))
+}
+
+// load reads the station list of the parameter from disk.
+func (d *StationDirectory) load(parameter int) (*stationEntry, error) {
+	var err error
+
+	if d.dir == "" {
+		return nil, os.ErrNotExist
+	}
+
+	var data []byte
+	if data, err = ioutil.ReadFile(d.path(parameter)); err != nil {
+		return nil, err
+	}
+
+	var e stationEntry
+	if err = json.Unmarshal(data, &e); err != nil {
+		return nil, err
+	}
+
+	return &e, nil
+}
+
+// store writes the station list of the parameter to disk.
+func (d *StationDirectory) store(parameter int, e *stationEntry) error {
+	var err error
+
+	if d.dir == "" {
+		return nil
+	}
+	if err = os.MkdirAll(d.dir, 0755); err != nil {
+		return err
+	}
+
+	var data []byte
+	if data, err = json.Marshal(e); err != nil {
+		return err
+	}
+
+	return writeFile(d.path(parameter), data)
+}
+
+// NearestStation returns the station that observes the parameter, matches
+// all of the filters and is nearest to the given longitude and latitude,
+// along with its distance in km.
+func (d *StationDirectory) NearestStation(parameter int, lon, lat float64, filters ...StationFilter) (*Station, float64, error) {
+	var err error
+
+	var stations []Station
+	if stations, err = d.Stations(parameter, filters...); err != nil {
+		return nil, 0, err
+	}
+
+	return nearestStation(stations, lon, lat)
+}
+
+// nearestStation returns the station that is nearest to the given
+// longitude and latitude, along with its distance in km.
+func nearestStation(stations []Station, lon, lat float64) (*Station, float64, error) {
+	var ret *Station
+	var min float64
+	for _, s := range stations {
+		if dist := distance(lon, lat, s.Longitude, s.Latitude); ret == nil || dist < min {
+			s := s
+			ret, min = &s, dist
+		}
+	}
+	if ret == nil {
+		return nil, 0, ErrNoStation
+	}
+
+	return ret, min, nil
+}
+
+// StationFilter returns true for the stations that should be kept.
+type StationFilter func(s *Station) bool
+
+// FilterStations returns the stations that match all of the filters.
+func FilterStations(stations []Station, filters ...StationFilter) []Station {
+	var ret []Station
+
+next:
+	for _, s := range stations {
+		for _, f := range filters {
+			if !f(&s) {
+				continue next
+			}
+		}
+		ret = append(ret, s)
+	}
+
+	return ret
+}
+
+// ActiveStations keeps the stations that are active.
+func ActiveStations() StationFilter {
+	return func(s *Station) bool {
+		return s.Active
+	}
+}
+
+// CoreStations keeps the stations that belong to the core station set,
+// which are the high quality stations that SMHI maintains.
+func CoreStations() StationFilter {
+	return func(s *Station) bool {
+		return s.MeasuringStations == "CORE"
+	}
+}
+
+// StationOwner keeps the stations that are owned by any of the given
+// owners, either by name or by owner category such as "CLIM".
+func StationOwner(owners ...string) StationFilter {
+	return func(s *Station) bool {
+		for _, o := range owners {
+			if s.Owner == o || s.OwnerCategory == o {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// StationHeight keeps the stations that are located between the heights
+// min and max in meters, both inclusive.
+func StationHeight(min, max float64) StationFilter {
+	return func(s *Station) bool {
+		return s.Height >= min && s.Height <= max
+	}
+}
@@ -0,0 +1,118 @@
+package smhi
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// stationsServer returns a test server of the station lists, the list of
+// the parameter 1 isn't sent until release is closed, along with the
+// number of lists that it has served.
+func stationsServer(release chan struct{}) (*httptest.Server, *int64) {
+	var n int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(&n, 1)
+		if strings.Contains(r.URL.Path, "/parameter/1.json") {
+			<-release
+		}
+		w.Write([]byte(`{"key": "2", "station": [{"id": 98210, "name": "Stockholm", "latitude": 59.34, "longitude": 18.05}]}`))
+	}))
+	return srv, &n
+}
+
+func TestStationDirectoryLocksPerParameter(t *testing.T) {
+	release := make(chan struct{})
+	srv, _ := stationsServer(release)
+	defer srv.Close()
+	defer close(release)
+
+	d := newStationDirectory(NewClient(), srv.URL, "stations", time.Hour, "")
+	go d.Stations(1)
+
+	// The list of another parameter isn't held up by the slow one.
+	done := make(chan error, 1)
+	go func() {
+		_, err := d.Stations(2)
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("the list waited for the list of another parameter")
+	}
+}
+
+func TestStationDirectoryFetchesOnce(t *testing.T) {
+	release := make(chan struct{})
+	srv, n := stationsServer(release)
+	defer srv.Close()
+
+	d := newStationDirectory(NewClient(), srv.URL, "stations", time.Hour, "")
+	done := make(chan error, 5)
+	for i := 0; i < 5; i++ {
+		go func() {
+			_, err := d.Stations(1)
+			done <- err
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+
+	for i := 0; i < 5; i++ {
+		if err := <-done; err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := atomic.LoadInt64(n); got != 1 {
+		t.Errorf("got %d requests, want 1", got)
+	}
+}
+
+func TestStationDirectoryDiskCache(t *testing.T) {
+	release := make(chan struct{})
+	close(release)
+	srv, n := stationsServer(release)
+	defer srv.Close()
+
+	dir, err := ioutil.TempDir("", "stations")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if _, err = newStationDirectory(NewClient(), srv.URL, "stations", time.Hour, dir).Stations(2); err != nil {
+		t.Fatal(err)
+	}
+
+	// Another directory uses the list on disk.
+	stations, err := newStationDirectory(NewClient(), srv.URL, "stations", time.Hour, dir).Stations(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stations) != 1 || stations[0].Name != "Stockholm" {
+		t.Errorf("got stations %+v", stations)
+	}
+	if got := atomic.LoadInt64(n); got != 1 {
+		t.Errorf("got %d requests, want 1", got)
+	}
+
+	// A disk cache that can't be written doesn't fail the fetched list.
+	blocked := filepath.Join(dir, "file")
+	if err = ioutil.WriteFile(blocked, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if stations, err = newStationDirectory(NewClient(), srv.URL, "stations", time.Hour, blocked).Stations(2); err != nil || len(stations) != 1 {
+		t.Errorf("got %d stations and error %v", len(stations), err)
+	}
+}
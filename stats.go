@@ -0,0 +1,98 @@
+package smhi
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// Accessor returns a value of a time step, such as its air temperature.
+type Accessor func(f *Forecast) float64
+
+// ParameterAccessor returns an Accessor of the SMHI parameter with the
+// given name, such as "t".
+func ParameterAccessor(name string) Accessor {
+	return func(f *Forecast) float64 {
+		v, _ := f.Value(name)
+		return v
+	}
+}
+
+// Values returns the values of the time steps that are within the range
+// from and to, to is exclusive. A zero from or to leaves that end of the
+// range open.
+func (pf *PointForecast) Values(acc Accessor, from, to time.Time) []float64 {
+	var ret []float64
+	for i := range pf.TimeSeries {
+		f := &pf.TimeSeries[i]
+		if (!from.IsZero() && f.Timestamp.Before(from)) || (!to.IsZero() && !f.Timestamp.Before(to)) {
+			continue
+		}
+		ret = append(ret, acc(f))
+	}
+	return ret
+}
+
+// Min returns the minimum of the values within the range, NaN is returned
+// if there are none.
+func (pf *PointForecast) Min(acc Accessor, from, to time.Time) float64 {
+	values := pf.Values(acc, from, to)
+	if len(values) == 0 {
+		return math.NaN()
+	}
+	return AggMin("", values)
+}
+
+// Max returns the maximum of the values within the range, NaN is returned
+// if there are none.
+func (pf *PointForecast) Max(acc Accessor, from, to time.Time) float64 {
+	values := pf.Values(acc, from, to)
+	if len(values) == 0 {
+		return math.NaN()
+	}
+	return AggMax("", values)
+}
+
+// Mean returns the arithmetic mean of the values within the range, NaN is
+// returned if there are none.
+func (pf *PointForecast) Mean(acc Accessor, from, to time.Time) float64 {
+	values := pf.Values(acc, from, to)
+	if len(values) == 0 {
+		return math.NaN()
+	}
+	return AggMean("", values)
+}
+
+// StdDev returns the population standard deviation of the values within
+// the range, NaN is returned if there are none.
+func (pf *PointForecast) StdDev(acc Accessor, from, to time.Time) float64 {
+	values := pf.Values(acc, from, to)
+	if len(values) == 0 {
+		return math.NaN()
+	}
+
+	mean := AggMean("", values)
+	var sum float64
+	for _, v := range values {
+		sum += (v - mean) * (v - mean)
+	}
+	return math.Sqrt(sum / float64(len(values)))
+}
+
+// Percentile returns the p:th percentile of the values within the range,
+// with p from 0 to 100 and linear interpolation between the closest ranks.
+// NaN is returned if there are no values.
+func (pf *PointForecast) Percentile(acc Accessor, p float64, from, to time.Time) float64 {
+	values := pf.Values(acc, from, to)
+	if len(values) == 0 {
+		return math.NaN()
+	}
+	sort.Float64s(values)
+
+	rank := math.Max(0, math.Min(100, p)) / 100 * float64(len(values)-1)
+	lo := int(math.Floor(rank))
+	if lo == len(values)-1 {
+		return values[lo]
+	}
+	return values[lo] + (rank-float64(lo))*(values[lo+1]-values[lo])
+}
@@ -0,0 +1,76 @@
+package smhi
+
+import (
+	"fmt"
+	"time"
+)
+
+// StormSignal is raised when the air pressure falls rapidly, which often
+// precedes a storm. From and To are the timestamps of the time steps that
+// the fall is measured between.
+type StormSignal struct {
+	From         time.Time
+	To           time.Time
+	FromPressure float64
+	ToPressure   float64
+	Drop         float64
+	Description  map[string]string
+}
+
+// StormSignals returns a signal for each part of the time series where the
+// air pressure falls by at least drop hPa within the given duration, such
+// as 3 hPa within 3 hours. Overlapping falls are merged into one signal.
+func (pf *PointForecast) StormSignals(drop float64, within time.Duration) []StormSignal {
+	var ret []StormSignal
+	ts := pf.TimeSeries
+
+	for j := range ts {
+		// Find the largest fall that ends at this time step.
+		best, bestDrop := -1, 0.0
+		for i := j - 1; i >= 0 && ts[j].Timestamp.Sub(ts[i].Timestamp) <= within; i-- {
+			if d := ts[i].AirPressure - ts[j].AirPressure; d > bestDrop {
+				best, bestDrop = i, d
+			}
+		}
+		if best < 0 || bestDrop < drop {
+			continue
+		}
+
+		// Extend the previous signal if the falls overlap.
+		if n := len(ret); n > 0 && !ts[best].Timestamp.After(ret[n-1].To) {
+			s := &ret[n-1]
+			s.To = ts[j].Timestamp
+			s.ToPressure = ts[j].AirPressure
+			if bestDrop > s.Drop {
+				s.Drop = bestDrop
+			}
+			continue
+		}
+
+		ret = append(ret, StormSignal{
+			From:         ts[best].Timestamp,
+			To:           ts[j].Timestamp,
+			FromPressure: ts[best].AirPressure,
+			ToPressure:   ts[j].AirPressure,
+			Drop:         bestDrop,
+		})
+	}
+
+	for i := range ret {
+		ret[i].Description = getStormSignalDescription(&ret[i])
+	}
+
+	return ret
+}
+
+// getStormSignalDescription returns a friendly description of the storm
+// signal.
+func getStormSignalDescription(s *StormSignal) map[string]string {
+	ret := make(map[string]string)
+	hours := s.To.Sub(s.From).Hours()
+
+	ret["sv-SE"] = fmt.Sprintf("Snabbt fallande lufttryck, %.1f hPa på %.0f timmar", s.FromPressure-s.ToPressure, hours)
+	ret["en-US"] = fmt.Sprintf("Rapidly falling air pressure, %.1f hPa in %.0f hours", s.FromPressure-s.ToPressure, hours)
+
+	return ret
+}
@@ -0,0 +1,84 @@
+package smhi
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	strangURL = "https://opendata-download-metanalys.smhi.se/api/category/strang1g/version/1/geotype/point/lon/%f/lat/%f/parameter/%d/data.json?from=%s&to=%s&interval=hourly"
+)
+
+// StrangParameter is a parameter of the STRÅNG solar radiation model.
+type StrangParameter int
+
+// StrangParameter constants.
+const (
+	// CIE UV irradiance in mW/m².
+	StrangCIEUVIrradiance StrangParameter = 116
+
+	// Global irradiance in W/m².
+	StrangGlobalIrradiance StrangParameter = 117
+
+	// Direct normal irradiance in W/m².
+	StrangDirectNormalIrradiance StrangParameter = 118
+
+	// Sunshine duration in minutes.
+	StrangSunshineDuration StrangParameter = 119
+
+	// Photosynthetic photon flux density in µmol/m²s.
+	StrangPhotosyntheticPhotonFluxDensity StrangParameter = 120
+
+	// Direct horizontal irradiance in W/m².
+	StrangDirectHorizontalIrradiance StrangParameter = 121
+
+	// Diffuse irradiance in W/m².
+	StrangDiffuseIrradiance StrangParameter = 122
+)
+
+// StrangAPI defines the data structure that is returned by the SMHI STRÅNG
+// API.
+type StrangAPI []struct {
+	DateTime string `json:"date_time"`
+	Value    float64
+}
+
+// StrangValue holds the value of a STRÅNG parameter for an hour.
+type StrangValue struct {
+	Timestamp time.Time
+	Value     float64
+}
+
+// GetStrang fetches the hourly values of the STRÅNG parameter for the
+// given longitude and latitude between the dates from and to using the
+// default client.
+func GetStrang(param StrangParameter, lon, lat float64, from, to time.Time) ([]StrangValue, error) {
+	return defaultClient.GetStrang(param, lon, lat, from, to)
+}
+
+// GetStrang fetches the hourly values of the STRÅNG parameter for the
+// given longitude and latitude between the dates from and to.
+func (c *Client) GetStrang(param StrangParameter, lon, lat float64, from, to time.Time) ([]StrangValue, error) {
+	var err error
+
+	// Fetch the values for the given period and decode them into the data
+	// structure that's defined by SMHI.
+	var decodedData StrangAPI
+	if err = c.getJSON(fmt.Sprintf(strangURL, lon, lat, param,
+		from.UTC().Format("2006-01-02"), to.UTC().Format("2006-01-02")), &decodedData); err != nil {
+		return nil, err
+	}
+
+	ret := make([]StrangValue, 0, len(decodedData))
+	for _, d := range decodedData {
+		var v StrangValue
+		if v.Timestamp, err = time.Parse(time.RFC3339, d.DateTime); err != nil {
+			return nil, err
+		}
+		v.Value = d.Value
+
+		ret = append(ret, v)
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,165 @@
+package smhi
+
+import (
+	"time"
+)
+
+// Summary summarizes the forecast for a period.
+type Summary struct {
+	From                   time.Time
+	To                     time.Time
+	MinAirTemperature      float64
+	MaxAirTemperature      float64
+	MeanAirTemperature     float64
+	MinApparentTemperature float64
+	MaxApparentTemperature float64
+	MaxWindSpeed           float64
+	MaxWindGustSpeed       float64
+	Precipitation          float64
+	PrecipitationCategory  PrecipitationCategory
+	MaxThunderProbability  uint8
+	WeatherSymbol          WeatherSymbol
+}
+
+// Summarize summarizes the time steps of the forecast that are within the
+// range from and to, to is exclusive. Precipitation is the estimated
+// amount in mm, the precipitation category and weather symbol are the
+// most frequent ones, where any precipitation takes precedence over no
+// precipitation.
+func (pf *PointForecast) Summarize(from, to time.Time) Summary {
+	s := Summary{From: from, To: to}
+
+	var ts []Forecast
+	for i, f := range pf.TimeSeries {
+		if f.Timestamp.Before(from) || !f.Timestamp.Before(to) {
+			continue
+		}
+
+		// The mean precipitation intensity is given in mm/h and holds
+		// until the next time step.
+		step := time.Hour
+		if i+1 < len(pf.TimeSeries) {
+			step = pf.TimeSeries[i+1].Timestamp.Sub(f.Timestamp)
+		}
+		s.Precipitation += f.MeanPrecipitationIntensity * step.Hours()
+
+		ts = append(ts, f)
+	}
+	if len(ts) == 0 {
+		return s
+	}
+
+	s.MinAirTemperature, s.MaxAirTemperature = ts[0].AirTemperature, ts[0].AirTemperature
+	s.MinApparentTemperature, s.MaxApparentTemperature = ts[0].ApparentTemperature, ts[0].ApparentTemperature
+
+	var categories, symbols, wetSymbols []float64
+	for _, f := range ts {
+		s.MeanAirTemperature += f.AirTemperature / float64(len(ts))
+		if f.AirTemperature < s.MinAirTemperature {
+			s.MinAirTemperature = f.AirTemperature
+		}
+		if f.AirTemperature > s.MaxAirTemperature {
+			s.MaxAirTemperature = f.AirTemperature
+		}
+		if f.ApparentTemperature < s.MinApparentTemperature {
+			s.MinApparentTemperature = f.ApparentTemperature
+		}
+		if f.ApparentTemperature > s.MaxApparentTemperature {
+			s.MaxApparentTemperature = f.ApparentTemperature
+		}
+		if f.WindSpeed > s.MaxWindSpeed {
+			s.MaxWindSpeed = f.WindSpeed
+		}
+		if f.WindGustSpeed > s.MaxWindGustSpeed {
+			s.MaxWindGustSpeed = f.WindGustSpeed
+		}
+		if f.ThunderProbability > s.MaxThunderProbability {
+			s.MaxThunderProbability = f.ThunderProbability
+		}
+
+		symbols = append(symbols, float64(f.WeatherSymbol))
+		if f.PrecipitationCategory != NoPrecipitation {
+			categories = append(categories, float64(f.PrecipitationCategory))
+			wetSymbols = append(wetSymbols, float64(f.WeatherSymbol))
+		}
+	}
+
+	s.WeatherSymbol = WeatherSymbol(AggMode("Wsymb2", symbols))
+	if len(categories) > 0 {
+		s.PrecipitationCategory = PrecipitationCategory(AggMode("pcat", categories))
+		s.WeatherSymbol = WeatherSymbol(AggMode("Wsymb2", wetSymbols))
+	}
+
+	return s
+}
+
+// DayOption configures how Days divides the forecast into days.
+type DayOption func(*dayConfig)
+
+// dayConfig holds the hours of the day that are summarized.
+type dayConfig struct {
+	from, to int
+}
+
+// WithDayHours makes Days summarize the hours from and to of each day, such
+// as 6 and 18 for the daytime. The block ends the next day if to isn't
+// after from, such as 18 and 6 for the nights.
+func WithDayHours(from, to int) DayOption {
+	return func(c *dayConfig) {
+		c.from, c.to = from, to
+	}
+}
+
+// Days summarizes the forecast for each calendar day in the given
+// location, days that are only partially covered by the forecast are
+// summarized from the time steps that are available. The days run from
+// midnight to midnight unless WithDayHours says otherwise, and the days
+// without any time steps within their hours are left out. UTC is used if
+// the location is nil.
+func (pf *PointForecast) Days(loc *time.Location, opts ...DayOption) []Summary {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	c := dayConfig{from: 0, to: 24}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	var ret []Summary
+
+	for _, f := range pf.TimeSeries {
+		from, to := c.block(f.Timestamp.In(loc))
+		if f.Timestamp.Before(from) || !f.Timestamp.Before(to) {
+			continue
+		}
+		if len(ret) > 0 && ret[len(ret)-1].From.Equal(from) {
+			continue
+		}
+
+		ret = append(ret, pf.Summarize(from, to))
+	}
+
+	return ret
+}
+
+// block returns the hours of the day that the time belongs to, the night
+// blocks that start the day before are used for the early hours.
+func (c dayConfig) block(t time.Time) (from, to time.Time) {
+	day := func(d int) (time.Time, time.Time) {
+		from := time.Date(t.Year(), t.Month(), t.Day()+d, c.from, 0, 0, 0, t.Location())
+		end := c.to
+		if end <= c.from {
+			end += 24
+		}
+		return from, time.Date(t.Year(), t.Month(), t.Day()+d, end, 0, 0, 0, t.Location())
+	}
+
+	from, to = day(0)
+	if t.Before(from) {
+		if f, e := day(-1); t.Before(e) {
+			return f, e
+		}
+	}
+	return from, to
+}
@@ -0,0 +1,104 @@
+package smhi
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDaysWithoutLocation(t *testing.T) {
+	pf := &PointForecast{TimeSeries: hourly(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC), 24)}
+
+	days := pf.Days(nil)
+	if len(days) != 2 {
+		t.Fatalf("got %d days, want 2", len(days))
+	}
+	if want := time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC); !days[1].From.Equal(want) {
+		t.Errorf("got the second day from %s, want %s", days[1].From, want)
+	}
+}
+
+// rainyHours returns an hourly time series of the given number of hours
+// from start, which rains one mm/h in every step.
+func rainyHours(start time.Time, hours int) *PointForecast {
+	pf := &PointForecast{TimeSeries: hourly(start, hours)}
+	for i := range pf.TimeSeries {
+		pf.TimeSeries[i].MeanPrecipitationIntensity = 1
+	}
+	return pf
+}
+
+func TestDaysDST(t *testing.T) {
+	stockholm, err := time.LoadLocation("Europe/Stockholm")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The series start at local midnight of the day before the change and
+	// end at local midnight of the day after it.
+	tests := []struct {
+		name  string
+		opts  []DayOption
+		start string
+		hours int
+		want  []float64
+	}{
+		{"spring forward", nil, "2024-03-29T23:00:00Z", 71, []float64{24, 23, 24}},
+		{"fall back", nil, "2024-10-25T22:00:00Z", 73, []float64{24, 25, 24}},
+		{"spring forward days", []DayOption{WithDayHours(6, 18)}, "2024-03-29T23:00:00Z", 71, []float64{12, 12, 12}},
+		{"fall back days", []DayOption{WithDayHours(6, 18)}, "2024-10-25T22:00:00Z", 73, []float64{12, 12, 12}},
+		{"spring forward night", []DayOption{WithDayHours(18, 6)}, "2024-03-30T12:00:00Z", 24, []float64{11}},
+		{"fall back night", []DayOption{WithDayHours(18, 6)}, "2024-10-26T12:00:00Z", 24, []float64{13}},
+		{"spring forward early hours", []DayOption{WithDayHours(0, 3)}, "2024-03-30T23:00:00Z", 23, []float64{2}},
+		{"fall back early hours", []DayOption{WithDayHours(0, 3)}, "2024-10-26T22:00:00Z", 24, []float64{4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			days := rainyHours(mustParse(t, tt.start), tt.hours).Days(stockholm, tt.opts...)
+
+			if len(days) != len(tt.want) {
+				t.Fatalf("got %d days, want %d", len(days), len(tt.want))
+			}
+			for i, d := range days {
+				if hours := d.To.Sub(d.From).Hours(); hours != tt.want[i] || d.Precipitation != tt.want[i] {
+					t.Errorf("day %d from %s lasts %v hours with %v mm, want %v", i, d.From.Format(time.RFC3339), hours, d.Precipitation, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSummarizeDST(t *testing.T) {
+	stockholm, err := time.LoadLocation("Europe/Stockholm")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		day      int
+		month    time.Month
+		start    string
+		duration float64
+	}{
+		{"23-hour day", 31, time.March, "2024-03-30T12:00:00Z", 23},
+		{"25-hour day", 27, time.October, "2024-10-26T12:00:00Z", 25},
+		{"24-hour day", 1, time.May, "2024-04-30T12:00:00Z", 24},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pf := rainyHours(mustParse(t, tt.start), 48)
+			from := time.Date(2024, tt.month, tt.day, 0, 0, 0, 0, stockholm)
+			to := time.Date(2024, tt.month, tt.day+1, 0, 0, 0, 0, stockholm)
+
+			s := pf.Summarize(from, to)
+			if s.Precipitation != tt.duration {
+				t.Errorf("got %v mm, want %v", s.Precipitation, tt.duration)
+			}
+			if hours := s.To.Sub(s.From).Hours(); hours != tt.duration {
+				t.Errorf("got %v hours, want %v", hours, tt.duration)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package smhi
+
+import (
+	"math"
+	"time"
+)
+
+// solarElevation returns the approximate elevation of the sun in degrees
+// above the horizon at the given time and location.
+func solarElevation(t time.Time, lon, lat float64) float64 {
+	t = t.UTC()
+	day := float64(t.YearDay())
+
+	// Declination and equation of time in minutes.
+	decl := 23.44 * math.Sin(2*math.Pi*(284+day)/365) * math.Pi / 180
+	b := 2 * math.Pi * (day - 81) / 364
+	eot := 9.87*math.Sin(2*b) - 7.53*math.Cos(b) - 1.5*math.Sin(b)
+
+	// Hour angle of the sun from the local solar time.
+	minutes := float64(t.Hour()*60+t.Minute()) + float64(t.Second())/60
+	ha := ((minutes+4*lon+eot)/4 - 180) * math.Pi / 180
+
+	phi := lat * math.Pi / 180
+	sinh := math.Sin(phi)*math.Sin(decl) + math.Cos(phi)*math.Cos(decl)*math.Cos(ha)
+
+	return math.Asin(sinh) * 180 / math.Pi
+}
+
+// sunHorizon is the elevation of the center of the sun at sunrise and
+// sunset, which accounts for refraction and the radius of the sun.
+const sunHorizon = -0.833
+
+// SunTimes returns the sunrise and the sunset of the day of the given time
+// and location, in the time zone of the time. The sunrise or the sunset is
+// zero if the sun doesn't rise or set that day, such as during the polar
+// night and the midnight sun.
+func SunTimes(date time.Time, lon, lat float64) (sunrise, sunset time.Time) {
+	y, m, d := date.Date()
+	start := time.Date(y, m, d, 0, 0, 0, 0, date.Location())
+	end := start.AddDate(0, 0, 1)
+
+	// Step through the day to find the crossings of the horizon, then
+	// narrow each crossing down to the second.
+	const step = 10 * time.Minute
+	prev := solarElevation(start, lon, lat) - sunHorizon
+	for t := start.Add(step); !t.After(end); t = t.Add(step) {
+		cur := solarElevation(t, lon, lat) - sunHorizon
+		if prev < 0 && cur >= 0 && sunrise.IsZero() {
+			sunrise = crossing(t.Add(-step), t, lon, lat)
+		} else if prev >= 0 && cur < 0 && sunset.IsZero() {
+			sunset = crossing(t.Add(-step), t, lon, lat)
+		}
+		prev = cur
+	}
+
+	return sunrise, sunset
+}
+
+// crossing returns the time between from and to where the sun crosses the
+// horizon.
+func crossing(from, to time.Time, lon, lat float64) time.Time {
+	above := solarElevation(from, lon, lat) >= sunHorizon
+
+	for to.Sub(from) > time.Second {
+		mid := from.Add(to.Sub(from) / 2)
+		if (solarElevation(mid, lon, lat) >= sunHorizon) == above {
+			from = mid
+		} else {
+			to = mid
+		}
+	}
+
+	return from.Truncate(time.Second)
+}
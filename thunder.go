@@ -0,0 +1,66 @@
+package smhi
+
+import (
+	"time"
+)
+
+// ThunderstormWindow is a contiguous part of the time series with a risk
+// of thunderstorms, From and To are the timestamps of its first and last
+// time step.
+type ThunderstormWindow struct {
+	From                  time.Time
+	To                    time.Time
+	MaxThunderProbability uint8
+}
+
+// isThunderSymbol returns true if the weather symbol indicates thunder.
+func isThunderSymbol(ws WeatherSymbol) bool {
+	return ws == Thunderstorm || ws == Thunder
+}
+
+// ThunderstormWindows returns the contiguous windows of the time series
+// where the thunder probability is at least minProbability percent, or
+// where the weather symbol indicates thunder.
+func (pf *PointForecast) ThunderstormWindows(minProbability uint8) []ThunderstormWindow {
+	var ret []ThunderstormWindow
+
+	for _, w := range windows(pf.TimeSeries, func(f *Forecast) bool {
+		return f.ThunderProbability >= minProbability || isThunderSymbol(f.WeatherSymbol)
+	}) {
+		tw := ThunderstormWindow{
+			From: w[0].Timestamp,
+			To:   w[len(w)-1].Timestamp,
+		}
+		for _, f := range w {
+			if f.ThunderProbability > tw.MaxThunderProbability {
+				tw.MaxThunderProbability = f.ThunderProbability
+			}
+		}
+
+		ret = append(ret, tw)
+	}
+
+	return ret
+}
+
+// windows returns the contiguous parts of the time series where match
+// returns true.
+func windows(ts []Forecast, match func(f *Forecast) bool) [][]Forecast {
+	var ret [][]Forecast
+	var open bool
+
+	for i := range ts {
+		if !match(&ts[i]) {
+			open = false
+			continue
+		}
+
+		if !open {
+			ret = append(ret, nil)
+			open = true
+		}
+		ret[len(ret)-1] = append(ret[len(ret)-1], ts[i])
+	}
+
+	return ret
+}
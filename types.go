@@ -1,6 +1,7 @@
 package smhi
 
 import (
+	"net/http"
 	"time"
 )
 
@@ -57,6 +58,15 @@ type Geometry struct {
 	Coordinates []Coordinate
 }
 
+// point returns the longitude and latitude of the first coordinate of the
+// geometry.
+func (g Geometry) point() (lon, lat float64) {
+	if len(g.Coordinates) == 0 || len(g.Coordinates[0]) < 2 {
+		return 0, 0
+	}
+	return g.Coordinates[0][0], g.Coordinates[0][1]
+}
+
 // PointForecastAPI defines the data structure that is returned by the SMHI
 // point forecast API
 type PointForecastAPI struct {
@@ -78,30 +88,49 @@ type PointForecastAPI struct {
 // Forecast defines the structure that holds the converted TimeSeries data
 // from the data returned by the SMHI point forecast API.
 type Forecast struct {
-	Hash                               string
-	Timestamp                          time.Time
-	AirPressure                        float64
-	AirTemperature                     float64
-	HorizontalVisibility               float64
-	MaximumPrecipitationIntensity      float64
-	MeanPrecipitationIntensity         float64
-	MeanValueOfHighLevelCloudCover     uint8
-	MeanValueOfLowLevelCloudCover      uint8
-	MeanValueOfMediumLevelCloudCover   uint8
-	MeanValueOfTotalCloudCover         uint8
-	MedianPrecipitationIntensity       float64
-	MinimumPrecipitationIntensity      float64
-	PercentOfPrecipitationInFrozenForm int8
-	PrecipitationCategory              PrecipitationCategory
-	PrecipitationCategoryDescription   map[string]string
-	RelativeHumidity                   uint8
-	ThunderProbability                 uint8
-	WeatherSymbol                      WeatherSymbol
-	WeatherSymbolDescription           map[string]string
-	WindDirection                      uint8
-	WindGustSpeed                      float64
-	WindSpeed                          float64
-	WindSpeedDescription               map[string]string
+	Hash                                  string
+	Timestamp                             time.Time
+	AirPressure                           float64
+	AirTemperature                        float64
+	ApparentTemperature                   float64
+	ComfortDescription                    map[string]string
+	Extra                                 map[string]float64
+	HorizontalVisibility                  float64
+	HorizontalVisibilityDescription       map[string]string
+	MaximumPrecipitationIntensity         float64
+	MeanPrecipitationIntensity            float64
+	MeanValueOfHighLevelCloudCover        uint8
+	MeanValueOfLowLevelCloudCover         uint8
+	MeanValueOfMediumLevelCloudCover      uint8
+	MeanValueOfTotalCloudCover            uint8
+	MeanValueOfTotalCloudCoverDescription map[string]string
+	MedianPrecipitationIntensity          float64
+	MinimumPrecipitationIntensity         float64
+	PercentOfPrecipitationInFrozenForm    Optional[int8]
+	PrecipitationCategory                 PrecipitationCategory
+	PrecipitationCategoryDescription      map[string]string
+	RelativeHumidity                      uint8
+	SignificantWaveHeight                 Optional[float64]
+	ThunderProbability                    uint8
+	WaveDirection                         Optional[uint16]
+	WavePeriod                            Optional[float64]
+	WeatherSymbol                         WeatherSymbol
+	WeatherSymbolDescription              map[string]string
+	WindDirection                         uint16
+	WindGustSpeed                         float64
+	WindSpeed                             float64
+	WindSpeedDescription                  map[string]string
+}
+
+// Meta holds where and when a point forecast was fetched, and the caching
+// headers of the response. The times are zero if the headers are missing.
+type Meta struct {
+	URL          string
+	FetchedAt    time.Time
+	Date         time.Time
+	Expires      time.Time
+	LastModified time.Time
+	ETag         string
 }
 
 // PointForecast holds the data for a complete PointForecast request.
@@ -110,4 +139,11 @@ type PointForecast struct {
 	ReferenceTime time.Time
 	Geometry      Geometry
 	TimeSeries    []Forecast
+	Warnings      []Warning
+	Meta          Meta
+
+	// Raw and RawHeader hold the body and the header of the response
+	// when the client is created with WithRawResponse.
+	Raw       []byte      `json:"-"`
+	RawHeader http.Header `json:"-"`
 }
@@ -0,0 +1,115 @@
+package smhi
+
+import (
+	"time"
+)
+
+// SkinType is the Fitzpatrick skin type.
+type SkinType uint8
+
+// SkinType constants.
+const (
+	SkinTypeI SkinType = iota + 1
+	SkinTypeII
+	SkinTypeIII
+	SkinTypeIV
+	SkinTypeV
+	SkinTypeVI
+)
+
+// minimalErythemalDose holds the UV dose in J/m² that causes a barely
+// perceptible reddening of the skin for each skin type.
+var minimalErythemalDose = map[SkinType]float64{
+	SkinTypeI:   200,
+	SkinTypeII:  250,
+	SkinTypeIII: 350,
+	SkinTypeIV:  450,
+	SkinTypeV:   600,
+	SkinTypeVI:  1000,
+}
+
+// UVExposure holds the UV index and the estimated safe exposure time for
+// an hour.
+type UVExposure struct {
+	Timestamp          time.Time
+	UVIndex            float64
+	UVIndexDescription map[string]string
+	SafeExposureTime   time.Duration
+}
+
+// UVIndex returns the UV index for the given CIE weighted UV irradiance in
+// mW/m².
+func UVIndex(irradiance float64) float64 {
+	return irradiance / 25
+}
+
+// SafeExposureTime returns the estimated time it takes for the given CIE
+// weighted UV irradiance in mW/m² to cause sunburn on the skin type. Zero
+// is returned if there is no UV radiation.
+func SafeExposureTime(irradiance float64, skin SkinType) time.Duration {
+	med, ok := minimalErythemalDose[skin]
+	if !ok || irradiance <= 0 {
+		return 0
+	}
+
+	return time.Duration(med / (irradiance / 1000) * float64(time.Second))
+}
+
+// GetUVExposure fetches the CIE weighted UV irradiance from STRÅNG for
+// the given longitude and latitude between the dates from and to and
+// returns the hourly UV index and safe exposure time for the skin type
+// using the default client.
+func GetUVExposure(lon, lat float64, from, to time.Time, skin SkinType) ([]UVExposure, error) {
+	return defaultClient.GetUVExposure(lon, lat, from, to, skin)
+}
+
+// GetUVExposure fetches the CIE weighted UV irradiance from STRÅNG for
+// the given longitude and latitude between the dates from and to and
+// returns the hourly UV index and safe exposure time for the skin type.
+func (c *Client) GetUVExposure(lon, lat float64, from, to time.Time, skin SkinType) ([]UVExposure, error) {
+	var err error
+
+	var values []StrangValue
+	if values, err = c.GetStrang(StrangCIEUVIrradiance, lon, lat, from, to); err != nil {
+		return nil, err
+	}
+
+	ret := make([]UVExposure, 0, len(values))
+	for _, v := range values {
+		e := UVExposure{
+			Timestamp:        v.Timestamp,
+			UVIndex:          UVIndex(v.Value),
+			SafeExposureTime: SafeExposureTime(v.Value, skin),
+		}
+		if c.descriptions {
+			e.UVIndexDescription = getUVIndexDescription(e.UVIndex)
+		}
+
+		ret = append(ret, e)
+	}
+
+	return ret, nil
+}
+
+// getUVIndexDescription returns a friendly name for the UV index.
+func getUVIndexDescription(uvi float64) map[string]string {
+	ret := make(map[string]string)
+
+	if uvi < 3 {
+		ret["sv-SE"] = "Låg"
+		ret["en-US"] = "Low"
+	} else if uvi < 6 {
+		ret["sv-SE"] = "Måttlig"
+		ret["en-US"] = "Moderate"
+	} else if uvi < 8 {
+		ret["sv-SE"] = "Hög"
+		ret["en-US"] = "High"
+	} else if uvi < 11 {
+		ret["sv-SE"] = "Mycket hög"
+		ret["en-US"] = "Very high"
+	} else {
+		ret["sv-SE"] = "Extrem"
+		ret["en-US"] = "Extreme"
+	}
+	return ret
+}
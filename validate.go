@@ -0,0 +1,99 @@
+package smhi
+
+import (
+	"fmt"
+	"time"
+)
+
+// validRanges holds the physically plausible ranges of the SMHI parameters
+// that are checked by Validate.
+var validRanges = map[string][2]float64{
+	"msl":      {870, 1090},
+	"t":        {-60, 50},
+	"vis":      {0, 100},
+	"wd":       {0, 360},
+	"ws":       {0, 75},
+	"r":        {0, 100},
+	"tstm":     {0, 100},
+	"tcc_mean": {0, 8},
+	"lcc_mean": {0, 8},
+	"mcc_mean": {0, 8},
+	"hcc_mean": {0, 8},
+	"gust":     {0, 100},
+	"pmin":     {0, 500},
+	"pmax":     {0, 500},
+	"spp":      {0, 100},
+	"pcat":     {0, 6},
+	"pmean":    {0, 500},
+	"pmedian":  {0, 500},
+	"Wsymb2":   {1, 27},
+
+	"sea_surface_wave_significant_height": {0, 30},
+	"sea_surface_wave_from_direction":     {0, 360},
+	"sea_surface_wave_mean_period":        {0, 30},
+}
+
+// Finding is a problem with a time step of a forecast, Parameter is empty
+// for problems with the timestamp.
+type Finding struct {
+	Timestamp time.Time
+	Parameter string
+	Value     float64
+	Message   string
+}
+
+// String returns the finding as a line of text.
+func (f Finding) String() string {
+	if f.Parameter == "" {
+		return fmt.Sprintf("%s: %s", f.Timestamp.Format(time.RFC3339), f.Message)
+	}
+	return fmt.Sprintf("%s: %s=%g: %s", f.Timestamp.Format(time.RFC3339), f.Parameter, f.Value, f.Message)
+}
+
+// Validate checks that the timestamps of the time series increase, that
+// the values are within physically plausible ranges and that the related
+// values are consistent, such as pmin <= pmean <= pmax. A nil slice is
+// returned if there are no findings.
+func (pf *PointForecast) Validate() []Finding {
+	var ret []Finding
+
+	for i := range pf.TimeSeries {
+		f := &pf.TimeSeries[i]
+
+		if i > 0 && !f.Timestamp.After(pf.TimeSeries[i-1].Timestamp) {
+			ret = append(ret, Finding{Timestamp: f.Timestamp, Message: "timestamp doesn't increase"})
+		}
+
+		for _, name := range parameterNames {
+			r, ok := validRanges[name]
+			if !ok {
+				continue
+			}
+
+			// Zero is taken as missing for the parameters that can't be
+			// zero, such as the pressure of a forecast without it.
+			v, ok := f.Value(name)
+			if !ok || (v == 0 && r[0] > 0) {
+				continue
+			}
+			if v < r[0] || v > r[1] {
+				ret = append(ret, Finding{f.Timestamp, name, v, fmt.Sprintf("outside of %g to %g", r[0], r[1])})
+			}
+		}
+
+		if f.MinimumPrecipitationIntensity > f.MeanPrecipitationIntensity {
+			ret = append(ret, Finding{f.Timestamp, "pmin", f.MinimumPrecipitationIntensity, "greater than pmean"})
+		}
+		if f.MeanPrecipitationIntensity > f.MaximumPrecipitationIntensity {
+			ret = append(ret, Finding{f.Timestamp, "pmean", f.MeanPrecipitationIntensity, "greater than pmax"})
+		}
+		if f.MedianPrecipitationIntensity < f.MinimumPrecipitationIntensity || f.MedianPrecipitationIntensity > f.MaximumPrecipitationIntensity {
+			ret = append(ret, Finding{f.Timestamp, "pmedian", f.MedianPrecipitationIntensity, "outside of pmin to pmax"})
+		}
+		if f.WindGustSpeed > 0 && f.WindGustSpeed < f.WindSpeed {
+			ret = append(ret, Finding{f.Timestamp, "gust", f.WindGustSpeed, "less than ws"})
+		}
+	}
+
+	return ret
+}
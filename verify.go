@@ -0,0 +1,160 @@
+package smhi
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// VerificationParameter maps a forecast parameter to the metobs parameter
+// that observes it.
+type VerificationParameter struct {
+	// Parameter is the metobs parameter.
+	Parameter int
+
+	// Convert converts the observed value to the unit of the forecast,
+	// the value is used as it is if it's nil.
+	Convert func(v float64) float64
+}
+
+// VerificationParameters holds the forecast parameters that can be
+// verified against hourly observations.
+var VerificationParameters = map[string]VerificationParameter{
+	"t":    {Parameter: 1},
+	"ws":   {Parameter: 4},
+	"r":    {Parameter: 6},
+	"msl":  {Parameter: 9},
+	"vis":  {Parameter: 12, Convert: func(v float64) float64 { return v / 1000 }},
+	"gust": {Parameter: 21},
+}
+
+// VerificationScore holds the scores of a parameter for the forecasts with
+// the same lead time.
+type VerificationScore struct {
+	Parameter string
+	LeadTime  time.Duration
+	Count     int
+	Bias      float64
+	MAE       float64
+	RMSE      float64
+}
+
+// Verify pairs the time steps of the forecast runs of the iterator with
+// the observations at the same time and computes the scores per parameter
+// and lead time. The observations are keyed on forecast parameter names,
+// and the lead times, which are counted from the reference time of each
+// run, are grouped into steps of leadStep.
+func Verify(it *ArchiveIterator, observations map[string][]Observation, leadStep time.Duration) ([]VerificationScore, error) {
+	type key struct {
+		parameter string
+		lead      time.Duration
+	}
+	type sums struct {
+		count                 int
+		errSum, absSum, sqSum float64
+	}
+
+	// Index the observations by time for fast lookups.
+	obs := make(map[string]map[time.Time]float64)
+	for name, values := range observations {
+		obs[name] = make(map[time.Time]float64)
+		for _, o := range values {
+			obs[name][o.Timestamp.UTC()] = o.Value
+		}
+	}
+
+	acc := make(map[key]*sums)
+	for it.Next() {
+		var pf PointForecast
+		if err := it.Scan(&pf); err != nil {
+			return nil, err
+		}
+
+		for _, f := range pf.TimeSeries {
+			lead := f.Timestamp.Sub(pf.ReferenceTime).Truncate(leadStep)
+			for name, byTime := range obs {
+				o, ok := byTime[f.Timestamp.UTC()]
+				if !ok {
+					continue
+				}
+				v, ok := f.Value(name)
+				if !ok {
+					continue
+				}
+
+				k := key{name, lead}
+				if acc[k] == nil {
+					acc[k] = &sums{}
+				}
+				e := v - o
+				acc[k].count++
+				acc[k].errSum += e
+				acc[k].absSum += math.Abs(e)
+				acc[k].sqSum += e * e
+			}
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	var ret []VerificationScore
+	for k, s := range acc {
+		n := float64(s.count)
+		ret = append(ret, VerificationScore{
+			Parameter: k.parameter,
+			LeadTime:  k.lead,
+			Count:     s.count,
+			Bias:      s.errSum / n,
+			MAE:       s.absSum / n,
+			RMSE:      math.Sqrt(s.sqSum / n),
+		})
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].Parameter != ret[j].Parameter {
+			return ret[i].Parameter < ret[j].Parameter
+		}
+		return ret[i].LeadTime < ret[j].LeadTime
+	})
+
+	return ret, nil
+}
+
+// VerifyArchive verifies the forecast runs in the archive for the given
+// grid point that were approved within the range from and to against the
+// latest months of observations at the nearest active station of each of
+// the VerificationParameters.
+func (c *Client) VerifyArchive(a *Archive, dir *StationDirectory, lon, lat float64, from, to time.Time, leadStep time.Duration) ([]VerificationScore, error) {
+	var err error
+
+	observations := make(map[string][]Observation)
+	for name, vp := range VerificationParameters {
+		var s *Station
+		if s, _, err = dir.NearestStation(vp.Parameter, lon, lat, ActiveStations()); err != nil {
+			return nil, err
+		}
+
+		var o *Observations
+		if o, err = c.GetObservations(vp.Parameter, s.ID, PeriodLatestMonths); err != nil {
+			return nil, err
+		}
+
+		values := o.Values
+		if vp.Convert != nil {
+			values = make([]Observation, len(o.Values))
+			for i, v := range o.Values {
+				v.Value = vp.Convert(v.Value)
+				values[i] = v
+			}
+		}
+		observations[name] = values
+	}
+
+	var it *ArchiveIterator
+	if it, err = a.Query(lon, lat, from, to); err != nil {
+		return nil, err
+	}
+	defer it.Close()
+
+	return Verify(it, observations, leadStep)
+}
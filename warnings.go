@@ -0,0 +1,213 @@
+package smhi
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	warningsURL = "https://opendata-download-warnings.smhi.se/ibww/api/version/1/warning.json"
+)
+
+// WarningLevel constants, as given by the code of the warning level.
+const (
+	WarningMessage = "MESSAGE"
+	WarningYellow  = "YELLOW"
+	WarningOrange  = "ORANGE"
+	WarningRed     = "RED"
+)
+
+// Warning is a weather warning of an area, such as a gale warning of a sea
+// area or a high sea level warning of a coast. The localized fields are
+// keyed by "sv-SE" and "en-US".
+type Warning struct {
+	ID          int
+	Event       map[string]string
+	EventCode   string
+	Level       string
+	Area        map[string]string
+	Start       time.Time
+	End         time.Time
+	Description map[string]string
+	polygons    [][][][2]float64
+	min, max    [2]float64
+}
+
+// WarningsAPI defines the structure of the warnings as returned by the
+// impact based weather warnings API.
+type WarningsAPI []struct {
+	ID    int `json:"id"`
+	Event struct {
+		Sv   string `json:"sv"`
+		En   string `json:"en"`
+		Code string `json:"code"`
+	} `json:"event"`
+	WarningAreas []struct {
+		ID               int    `json:"id"`
+		ApproximateStart string `json:"approximateStart"`
+		ApproximateEnd   string `json:"approximateEnd"`
+		AreaName         struct {
+			Sv string `json:"sv"`
+			En string `json:"en"`
+		} `json:"areaName"`
+		WarningLevel struct {
+			Code string `json:"code"`
+		} `json:"warningLevel"`
+		EventDescription struct {
+			Sv   string `json:"sv"`
+			En   string `json:"en"`
+			Code string `json:"code"`
+		} `json:"eventDescription"`
+		Descriptions []struct {
+			Text struct {
+				Sv string `json:"sv"`
+				En string `json:"en"`
+			} `json:"text"`
+		} `json:"descriptions"`
+		Area struct {
+			Features []struct {
+				Geometry geoJSONGeometry `json:"geometry"`
+			} `json:"features"`
+		} `json:"area"`
+	} `json:"warningAreas"`
+}
+
+// WithWarnings makes the client attach the active warnings of the location
+// to the point forecasts, such as the sea and coastal warnings of points
+// that are over sea.
+func WithWarnings() Option {
+	return func(c *Client) {
+		c.warnings = true
+	}
+}
+
+// GetWarnings fetches the active warnings using the default client.
+func GetWarnings() ([]Warning, error) {
+	return defaultClient.GetWarnings()
+}
+
+// GetWarnings fetches the active warnings, with one warning per warned
+// area.
+func (c *Client) GetWarnings() ([]Warning, error) {
+	return c.GetWarningsContext(context.Background())
+}
+
+// GetWarningsContext fetches the active warnings using the default client,
+// the request is canceled when the context is done.
+func GetWarningsContext(ctx context.Context) ([]Warning, error) {
+	return defaultClient.GetWarningsContext(ctx)
+}
+
+// GetWarningsContext fetches the active warnings, the request is canceled
+// when the context is done.
+func (c *Client) GetWarningsContext(ctx context.Context) ([]Warning, error) {
+	var err error
+
+	var decodedData WarningsAPI
+	if err = c.getJSONContext(ctx, warningsURL, &decodedData); err != nil {
+		return nil, err
+	}
+
+	var ret []Warning
+	for _, w := range decodedData {
+		for _, a := range w.WarningAreas {
+			r := Warning{
+				ID:        a.ID,
+				Event:     map[string]string{"sv-SE": a.EventDescription.Sv, "en-US": a.EventDescription.En},
+				EventCode: a.EventDescription.Code,
+				Level:     a.WarningLevel.Code,
+				Area:      map[string]string{"sv-SE": a.AreaName.Sv, "en-US": a.AreaName.En},
+			}
+			if r.EventCode == "" {
+				r.Event = map[string]string{"sv-SE": w.Event.Sv, "en-US": w.Event.En}
+				r.EventCode = w.Event.Code
+			}
+
+			// The end is left as zero for warnings that are valid until
+			// further notice.
+			if r.Start, err = time.Parse(time.RFC3339, a.ApproximateStart); err != nil {
+				return nil, err
+			}
+			if a.ApproximateEnd != "" {
+				if r.End, err = time.Parse(time.RFC3339, a.ApproximateEnd); err != nil {
+					return nil, err
+				}
+			}
+
+			if len(a.Descriptions) > 0 {
+				r.Description = map[string]string{
+					"sv-SE": a.Descriptions[0].Text.Sv,
+					"en-US": a.Descriptions[0].Text.En,
+				}
+			}
+
+			for _, f := range a.Area.Features {
+				var p [][][][2]float64
+				if p, err = f.Geometry.polygons(); err != nil {
+					return nil, err
+				}
+				r.polygons = append(r.polygons, p...)
+			}
+			r.min, r.max = boundingBox(r.polygons)
+
+			ret = append(ret, r)
+		}
+	}
+
+	return ret, nil
+}
+
+// Contains returns true if the area of the warning contains the given
+// longitude and latitude.
+func (w *Warning) Contains(lon, lat float64) bool {
+	if !boxContains(w.min, w.max, lon, lat) {
+		return false
+	}
+
+	for _, p := range w.polygons {
+		if polygonContains(p, lon, lat) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetWarningsAt fetches the active warnings whose area contains the given
+// longitude and latitude using the default client.
+func GetWarningsAt(lon, lat float64) ([]Warning, error) {
+	return defaultClient.GetWarningsAt(lon, lat)
+}
+
+// GetWarningsAt fetches the active warnings whose area contains the given
+// longitude and latitude.
+func (c *Client) GetWarningsAt(lon, lat float64) ([]Warning, error) {
+	return c.GetWarningsAtContext(context.Background(), lon, lat)
+}
+
+// GetWarningsAtContext fetches the active warnings whose area contains the
+// given longitude and latitude using the default client, the request is
+// canceled when the context is done.
+func GetWarningsAtContext(ctx context.Context, lon, lat float64) ([]Warning, error) {
+	return defaultClient.GetWarningsAtContext(ctx, lon, lat)
+}
+
+// GetWarningsAtContext fetches the active warnings whose area contains the
+// given longitude and latitude, the request is canceled when the context
+// is done.
+func (c *Client) GetWarningsAtContext(ctx context.Context, lon, lat float64) ([]Warning, error) {
+	var err error
+
+	var warnings []Warning
+	if warnings, err = c.GetWarningsContext(ctx); err != nil {
+		return nil, err
+	}
+
+	var ret []Warning
+	for _, w := range warnings {
+		if w.Contains(lon, lat) {
+			ret = append(ret, w)
+		}
+	}
+
+	return ret, nil
+}
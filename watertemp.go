@@ -0,0 +1,139 @@
+package smhi
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNoObservations is returned when there are no observations.
+var ErrNoObservations = errors.New("smhi: no observations")
+
+// WaterTemperatureSource tells where a water temperature is measured.
+type WaterTemperatureSource int
+
+// WaterTemperatureSource constants.
+const (
+	CoastalWaterTemperature WaterTemperatureSource = iota
+	LakeWaterTemperature
+)
+
+// WaterTemperatureStation is a station that measures the water
+// temperature.
+type WaterTemperatureStation struct {
+	Station
+	Source    WaterTemperatureSource
+	parameter int
+}
+
+// GetWaterTemperatureStations fetches the coastal and the lake stations
+// that measure the water temperature using the default client.
+func GetWaterTemperatureStations() ([]WaterTemperatureStation, error) {
+	return defaultClient.GetWaterTemperatureStations()
+}
+
+// GetWaterTemperatureStations fetches the coastal and the lake stations
+// that measure the water temperature.
+func (c *Client) GetWaterTemperatureStations() ([]WaterTemperatureStation, error) {
+	var err error
+	var ret []WaterTemperatureStation
+
+	var d *ParameterAPI
+	if d, err = c.getParameter(context.Background(), ocobsURL, OceanSeaTemperature); err != nil {
+		return nil, err
+	}
+	for _, s := range toStations(d) {
+		ret = append(ret, WaterTemperatureStation{s, CoastalWaterTemperature, OceanSeaTemperature})
+	}
+
+	var p int
+	if p, err = c.HydroParameter("temperatur"); err != nil {
+		return nil, err
+	}
+	if d, err = c.getParameter(context.Background(), hydrobsURL, p); err != nil {
+		return nil, err
+	}
+	for _, s := range toStations(d) {
+		ret = append(ret, WaterTemperatureStation{s, LakeWaterTemperature, p})
+	}
+
+	return ret, nil
+}
+
+// GetWaterTemperatures fetches the water temperatures of the station for
+// the given period using the default client.
+func GetWaterTemperatures(s *WaterTemperatureStation, period ObservationPeriod) (*Observations, error) {
+	return defaultClient.GetWaterTemperatures(s, period)
+}
+
+// GetWaterTemperatures fetches the water temperatures of the station for
+// the given period.
+func (c *Client) GetWaterTemperatures(s *WaterTemperatureStation, period ObservationPeriod) (*Observations, error) {
+	base := ocobsURL
+	if s.Source == LakeWaterTemperature {
+		base = hydrobsURL
+	}
+
+	return c.getObservations(context.Background(), base, s.parameter, s.ID, period)
+}
+
+// NearestWaterTemperatureStation returns the station of the source that
+// measures the water temperature nearest to the given longitude and
+// latitude, along with its distance in km, using the default client.
+func NearestWaterTemperatureStation(lon, lat float64, source WaterTemperatureSource) (*WaterTemperatureStation, float64, error) {
+	return defaultClient.NearestWaterTemperatureStation(lon, lat, source)
+}
+
+// NearestWaterTemperatureStation returns the station of the source that
+// measures the water temperature nearest to the given longitude and
+// latitude, along with its distance in km.
+func (c *Client) NearestWaterTemperatureStation(lon, lat float64, source WaterTemperatureSource) (*WaterTemperatureStation, float64, error) {
+	var err error
+
+	var stations []WaterTemperatureStation
+	if stations, err = c.GetWaterTemperatureStations(); err != nil {
+		return nil, 0, err
+	}
+
+	var ret *WaterTemperatureStation
+	var min float64
+	for i := range stations {
+		s := &stations[i]
+		if s.Source != source {
+			continue
+		}
+		if dist := distance(lon, lat, s.Longitude, s.Latitude); ret == nil || dist < min {
+			ret, min = s, dist
+		}
+	}
+	if ret == nil {
+		return nil, 0, ErrNoStation
+	}
+
+	return ret, min, nil
+}
+
+// GetLatestWaterTemperature fetches the latest water temperature of the
+// station using the default client.
+func GetLatestWaterTemperature(s *WaterTemperatureStation) (*Observation, error) {
+	return defaultClient.GetLatestWaterTemperature(s)
+}
+
+// GetLatestWaterTemperature fetches the latest water temperature of the
+// station, from the latest day or the latest months for stations that
+// don't report daily.
+func (c *Client) GetLatestWaterTemperature(s *WaterTemperatureStation) (*Observation, error) {
+	var err error
+
+	var o *Observations
+	if o, err = c.GetWaterTemperatures(s, PeriodLatestDay); err == ErrUnsupportedPeriod {
+		o, err = c.GetWaterTemperatures(s, PeriodLatestMonths)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(o.Values) == 0 {
+		return nil, ErrNoObservations
+	}
+
+	return &o.Values[len(o.Values)-1], nil
+}
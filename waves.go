@@ -0,0 +1,64 @@
+package smhi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+const (
+	wavesURL = "https://opendata-download-ocfcst.smhi.se/api/category/wave/version/1/geotype/point/lon/%f/lat/%f/data.json"
+)
+
+// WithWaves makes the client merge the wave forecast into the point
+// forecasts of points that are over sea.
+func WithWaves() Option {
+	return func(c *Client) {
+		c.waves = true
+	}
+}
+
+// mergeWaves fetches the wave forecast for the given longitude and
+// latitude and merges it into the time steps of the forecast with the same
+// timestamps. The forecast is left as it is if the point isn't over sea.
+func (c *Client) mergeWaves(ctx context.Context, pf *PointForecast, lon, lat float64) error {
+	var err error
+
+	var body io.ReadCloser
+	if body, _, err = c.open(ctx, fmt.Sprintf(wavesURL, lon, lat)); errors.Is(err, errNotFound) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	var decodedData *PointForecastAPI
+	if decodedData, err = decodePointForecast(body); err != nil {
+		return err
+	}
+
+	steps := make(map[time.Time]*Forecast)
+	for i := range pf.TimeSeries {
+		steps[pf.TimeSeries[i].Timestamp] = &pf.TimeSeries[i]
+	}
+
+	for _, t := range decodedData.TimeSeries {
+		var ts time.Time
+		if ts, err = time.Parse(time.RFC3339, t.ValidTime); err != nil {
+			return err
+		}
+
+		f, ok := steps[ts]
+		if !ok {
+			continue
+		}
+		for _, p := range t.Parameters {
+			f.decode(p.Name, p.Values)
+		}
+		f.Hash = getHash(f)
+	}
+
+	return nil
+}